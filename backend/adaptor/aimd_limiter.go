@@ -0,0 +1,226 @@
+package adaptor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// AIMDLimiterOptions configures an AIMD concurrency limiter. Zero values take
+// the defaults noted on each field.
+type AIMDLimiterOptions struct {
+	// InitialLimit defaults to a fifth of MaxLimit
+	InitialLimit int
+	// MinLimit defaults to 1
+	MinLimit int
+	// MaxLimit defaults to 100
+	MaxLimit int
+	// LatencyTarget is the slowest an operation may be before the limit is
+	// lowered; defaults to 250ms
+	LatencyTarget time.Duration
+	// Backoff multiplies the limit when operations are too slow; defaults to 0.9
+	Backoff float64
+	// QueueSize is how many requests may wait for a slot; defaults to MaxLimit
+	QueueSize int
+	// QueueTimeout is how long a request may wait for a slot; defaults to 1s
+	QueueTimeout time.Duration
+}
+
+func (o *AIMDLimiterOptions) setDefaults() {
+	if o.MaxLimit <= 0 {
+		o.MaxLimit = 100
+	}
+	if o.MinLimit <= 0 {
+		o.MinLimit = 1
+	}
+	o.MinLimit = min(o.MinLimit, o.MaxLimit)
+	if o.InitialLimit <= 0 {
+		o.InitialLimit = max(o.MaxLimit/5, 1)
+	}
+	o.InitialLimit = min(max(o.InitialLimit, o.MinLimit), o.MaxLimit)
+	if o.LatencyTarget <= 0 {
+		o.LatencyTarget = 250 * time.Millisecond
+	}
+	if o.Backoff <= 0 || o.Backoff >= 1 {
+		o.Backoff = 0.9
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = o.MaxLimit
+	}
+	if o.QueueTimeout <= 0 {
+		o.QueueTimeout = time.Second
+	}
+}
+
+// aimdLimiter adapts its limit the way TCP adapts its congestion window: it
+// grows by one for every limit's worth of operations finishing within the
+// latency target, and shrinks by the backoff factor when they do not. It
+// shrinks at most once per latency target, since the operations in flight
+// when the service slows down all report it.
+//
+// Requests that find every slot taken wait in a queue per priority. Freed
+// slots go to the oldest request of the highest priority, and a full queue
+// sheds the newest request of a lower priority to make room.
+type aimdLimiter struct {
+	opts AIMDLimiterOptions
+
+	mu          sync.Mutex
+	limit       float64
+	inFlight    int
+	queues      [][]*aimdWaiter
+	queued      int
+	lastBackoff time.Time
+}
+
+type aimdWaiter struct {
+	priority domain.Priority
+	ready    chan struct{}
+	granted  bool
+}
+
+// NewAIMDLimiter returns a concurrency limiter with an additive increase,
+// multiplicative decrease limit driven by operation latency
+func NewAIMDLimiter(opts AIMDLimiterOptions) port.ConcurrencyLimiter {
+	opts.setDefaults()
+	return &aimdLimiter{
+		opts:   opts,
+		limit:  float64(opts.InitialLimit),
+		queues: make([][]*aimdWaiter, len(domain.Priorities)),
+	}
+}
+
+func (l *aimdLimiter) Acquire(ctx context.Context, priority domain.Priority) (func(err error), error) {
+	priority = min(max(priority, domain.PriorityLow), domain.PriorityHigh)
+
+	l.mu.Lock()
+	if l.queued == 0 && l.inFlight < l.currentLimit() {
+		l.inFlight++
+		l.mu.Unlock()
+		return l.releaser(), nil
+	}
+	if l.queued >= l.opts.QueueSize && !l.shedBelow(priority) {
+		l.mu.Unlock()
+		return nil, l.overloaded(ctx, priority, "queue full")
+	}
+	w := &aimdWaiter{priority: priority, ready: make(chan struct{})}
+	l.queues[priority] = append(l.queues[priority], w)
+	l.queued++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.opts.QueueTimeout)
+	defer timer.Stop()
+
+	var ctxErr error
+	timedOut := false
+	select {
+	case <-w.ready:
+	case <-timer.C:
+		timedOut = true
+	case <-ctx.Done():
+		ctxErr = ctx.Err()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case w.granted:
+		// Granted just as the wait ended; the operation sees its context
+		return l.releaser(), nil
+	case ctxErr != nil:
+		l.remove(w)
+		return nil, ctxErr
+	case timedOut:
+		l.remove(w)
+		return nil, l.overloaded(ctx, priority, "queue timeout")
+	default:
+		return nil, l.overloaded(ctx, priority, "shed for a higher priority request")
+	}
+}
+
+func (l *aimdLimiter) currentLimit() int {
+	return int(l.limit)
+}
+
+// releaser returns the release function of a granted slot, timing the
+// operation from now
+func (l *aimdLimiter) releaser() func(err error) {
+	start := time.Now()
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { l.release(time.Since(start), err) })
+	}
+}
+
+func (l *aimdLimiter) release(latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	congested := latency > l.opts.LatencyTarget ||
+		errors.Is(err, domain.ErrUnavailable) || errors.Is(err, context.DeadlineExceeded)
+	now := time.Now()
+	switch {
+	case congested && now.Sub(l.lastBackoff) >= l.opts.LatencyTarget:
+		l.lastBackoff = now
+		previous := l.currentLimit()
+		l.limit = math.Max(float64(l.opts.MinLimit), l.limit*l.opts.Backoff)
+		if l.currentLimit() != previous {
+			slog.Warn("Concurrency limit lowered", "component", "LoadShedding", "latency", latency.Round(time.Millisecond).String(), "limit", l.currentLimit())
+		}
+	case !congested:
+		l.limit = math.Min(float64(l.opts.MaxLimit), l.limit+1/l.limit)
+	}
+
+	l.dispatch()
+}
+
+// dispatch hands free slots to the oldest waiters of the highest priority
+func (l *aimdLimiter) dispatch() {
+	for priority := len(l.queues) - 1; priority >= 0; priority-- {
+		for len(l.queues[priority]) > 0 && l.inFlight < l.currentLimit() {
+			w := l.queues[priority][0]
+			l.queues[priority] = l.queues[priority][1:]
+			l.queued--
+			l.inFlight++
+			w.granted = true
+			close(w.ready)
+		}
+	}
+}
+
+// shedBelow drops the newest waiter with a priority lower than priority,
+// reporting whether there was one
+func (l *aimdLimiter) shedBelow(priority domain.Priority) bool {
+	for lower := domain.PriorityLow; lower < priority; lower++ {
+		queue := l.queues[lower]
+		if len(queue) == 0 {
+			continue
+		}
+		w := queue[len(queue)-1]
+		l.queues[lower] = queue[:len(queue)-1]
+		l.queued--
+		close(w.ready)
+		return true
+	}
+	return false
+}
+
+func (l *aimdLimiter) remove(w *aimdWaiter) {
+	queue := l.queues[w.priority]
+	if i := slices.Index(queue, w); i >= 0 {
+		l.queues[w.priority] = slices.Delete(queue, i, i+1)
+		l.queued--
+	}
+}
+
+func (l *aimdLimiter) overloaded(ctx context.Context, priority domain.Priority, reason string) error {
+	slog.WarnContext(ctx, "Request shed", "component", "LoadShedding", "priority", priority.String(), "reason", reason, "in_flight", l.inFlight, "limit", l.currentLimit())
+	return domain.NewOverloadedError(l.opts.QueueTimeout, "server overloaded, retry later")
+}
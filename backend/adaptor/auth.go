@@ -0,0 +1,149 @@
+package adaptor
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	"google.golang.org/grpc"
+)
+
+// publicPaths are served without credentials even when authentication is required
+var publicPaths = map[string]bool{
+	"/health":              true,
+	"/openapi.json":        true,
+	"/docs":                true,
+	"/grpc/descriptor-set": true,
+	"/metrics":             true,
+}
+
+// publicMethodPrefixes are gRPC methods served without credentials
+var publicMethodPrefixes = []string{
+	"/grpc.reflection.",
+	"/grpc.health.",
+}
+
+// Authenticator checks the credentials of HTTP requests and gRPC calls and
+// attaches the authenticated principal to their context. Credentials are an
+// API key in the X-API-Key header or x-api-key metadata, or a bearer token
+// in Authorization. Invalid credentials are always rejected; anonymous
+// callers are only rejected when required is set.
+//
+// The tenant of the call is attached to the context as well. Authenticated
+// callers belong to the tenant of their key or token, and naming another in
+// the X-Tenant-ID header or x-tenant-id metadata is rejected. Anonymous
+// callers choose their tenant with that header.
+type Authenticator struct {
+	auth     port.AuthService
+	required bool
+}
+
+func NewAuthenticator(auth port.AuthService, required bool) *Authenticator {
+	return &Authenticator{auth: auth, required: required}
+}
+
+// Middleware authenticates HTTP requests
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if publicPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, err := a.authenticate(r.Context(), r.Header.Get("X-API-Key"), r.Header.Get("Authorization"), r.Header.Get("X-Tenant-ID"))
+		if err != nil {
+			slog.WarnContext(r.Context(), "Authentication rejected", "component", "Auth", "path", r.URL.Path, "error", err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="stockorder"`)
+			respondError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// UnaryInterceptor authenticates unary gRPC calls
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	ctx, err := a.authenticate(ctx, metadataValue(ctx, "x-api-key"), metadataValue(ctx, "authorization"), metadataValue(ctx, "x-tenant-id"))
+	if err != nil {
+		slog.WarnContext(ctx, "Authentication rejected", "component", "Auth", "error", err)
+		return nil, grpcError(err, "authentication failed")
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor authenticates streaming gRPC calls
+func (a *Authenticator) StreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, stream)
+	}
+
+	ctx := stream.Context()
+	ctx, err := a.authenticate(ctx, metadataValue(ctx, "x-api-key"), metadataValue(ctx, "authorization"), metadataValue(ctx, "x-tenant-id"))
+	if err != nil {
+		slog.WarnContext(stream.Context(), "Authentication rejected", "component", "Auth", "error", err)
+		return grpcError(err, "authentication failed")
+	}
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate returns ctx with the principal the credentials belong to and
+// its tenant
+func (a *Authenticator) authenticate(ctx context.Context, apiKey, authorization, tenantID string) (context.Context, error) {
+	credentials := domain.Credentials{APIKey: apiKey}
+	if authorization != "" {
+		scheme, token, ok := strings.Cut(authorization, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nil, domain.NewUnauthenticatedError("authorization must use the Bearer scheme")
+		}
+		credentials.BearerToken = strings.TrimSpace(token)
+	}
+
+	principal, err := a.auth.Authenticate(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if principal == nil {
+		if a.required {
+			return nil, domain.NewUnauthenticatedError("an API key or bearer token is required")
+		}
+		if tenantID != "" {
+			ctx = domain.ContextWithTenantID(ctx, tenantID)
+		}
+		setRequestCaller(ctx)
+		return ctx, nil
+	}
+
+	ctx = domain.ContextWithPrincipal(ctx, principal)
+	own := domain.TenantIDFromContext(ctx)
+	if tenantID != "" && tenantID != own {
+		return nil, domain.NewUnauthenticatedError("credentials do not belong to tenant %q", tenantID)
+	}
+	setRequestCaller(ctx)
+	return ctx, nil
+}
+
+func isPublicMethod(fullMethod string) bool {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// contextServerStream replaces the context of a server stream
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
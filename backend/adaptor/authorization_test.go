@@ -0,0 +1,659 @@
+package adaptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+	pbv2 "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testAccount = "acct-1"
+
+// stubStockOrderService answers every operation successfully with orders
+// owned by account, so only the authorization layer can reject a call
+type stubStockOrderService struct {
+	account string
+}
+
+func (s *stubStockOrderService) order(id string) *domain.StockOrder {
+	return &domain.StockOrder{
+		ID:        id,
+		AccountID: s.account,
+		Symbol:    "AAPL",
+		OrderType: domain.OrderTypeLimit,
+		OrderSide: domain.OrderSideBuy,
+		Quantity:  10,
+		Price:     100,
+		Status:    domain.OrderStatusPending,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (s *stubStockOrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.StockOrder, error) {
+	return s.order("order-new"), nil
+}
+
+func (s *stubStockOrderService) GetOrder(ctx context.Context, orderID string) (*domain.StockOrder, error) {
+	return s.order(orderID), nil
+}
+
+func (s *stubStockOrderService) ListOrders(ctx context.Context, query domain.OrderQuery) (*domain.OrderPage, error) {
+	return &domain.OrderPage{Orders: []*domain.StockOrder{}}, nil
+}
+
+func (s *stubStockOrderService) ListOrderFills(ctx context.Context, orderID string) ([]domain.Trade, error) {
+	return nil, nil
+}
+
+func (s *stubStockOrderService) CancelOrder(ctx context.Context, orderID string) error {
+	return nil
+}
+
+func (s *stubStockOrderService) BatchCreateOrders(ctx context.Context, req domain.BatchCreateOrdersRequest) ([]domain.BatchOrderResult, error) {
+	return []domain.BatchOrderResult{}, nil
+}
+
+func (s *stubStockOrderService) MassCancel(ctx context.Context, req domain.MassCancelRequest) ([]domain.CancelResult, error) {
+	return []domain.CancelResult{}, nil
+}
+
+func (s *stubStockOrderService) AmendOrder(ctx context.Context, orderID string, req domain.AmendOrderRequest) (*domain.StockOrder, error) {
+	return s.order(orderID), nil
+}
+
+func (s *stubStockOrderService) GetMarketSession(ctx context.Context, symbol string) (*domain.MarketSession, error) {
+	return &domain.MarketSession{Symbol: symbol, Phase: domain.SessionPhaseContinuous}, nil
+}
+
+func (s *stubStockOrderService) GetAuctionState(ctx context.Context, symbol string) (*domain.AuctionState, error) {
+	return &domain.AuctionState{Symbol: symbol}, nil
+}
+
+func (s *stubStockOrderService) WatchOrders(ctx context.Context, filter domain.OrderEventFilter) (port.OrderEventSubscription, error) {
+	return newContextSubscription(ctx), nil
+}
+
+func (s *stubStockOrderService) ListInstruments(ctx context.Context) ([]*domain.Instrument, error) {
+	return []*domain.Instrument{}, nil
+}
+
+func (s *stubStockOrderService) UpsertInstrument(ctx context.Context, symbol string, req domain.UpsertInstrumentRequest) (*domain.Instrument, error) {
+	return &domain.Instrument{Symbol: symbol}, nil
+}
+
+func (s *stubStockOrderService) DeleteInstrument(ctx context.Context, symbol string) error {
+	return nil
+}
+
+func (s *stubStockOrderService) HaltSymbol(ctx context.Context, symbol string, req domain.HaltSymbolRequest) (*domain.Instrument, error) {
+	return &domain.Instrument{Symbol: symbol, Halted: true}, nil
+}
+
+func (s *stubStockOrderService) ResumeSymbol(ctx context.Context, symbol string) (*domain.Instrument, error) {
+	return &domain.Instrument{Symbol: symbol}, nil
+}
+
+// contextSubscription delivers no events and ends when its context is done
+type contextSubscription struct {
+	events chan domain.OrderEvent
+}
+
+func newContextSubscription(ctx context.Context) *contextSubscription {
+	sub := &contextSubscription{events: make(chan domain.OrderEvent)}
+	go func() {
+		<-ctx.Done()
+		close(sub.events)
+	}()
+	return sub
+}
+
+func (s *contextSubscription) Events() <-chan domain.OrderEvent { return s.events }
+
+func (s *contextSubscription) Err() error { return nil }
+
+type stubMarketDataService struct{}
+
+func (stubMarketDataService) GetQuote(ctx context.Context, symbol string) (*domain.SymbolQuote, error) {
+	return &domain.SymbolQuote{Symbol: symbol}, nil
+}
+
+func (stubMarketDataService) GetCandles(ctx context.Context, query domain.CandleQuery) ([]*domain.Candle, error) {
+	return []*domain.Candle{}, nil
+}
+
+func (stubMarketDataService) SubscribeQuotes(ctx context.Context, symbols []string) (<-chan domain.SymbolQuote, error) {
+	quotes := make(chan domain.SymbolQuote)
+	close(quotes)
+	return quotes, nil
+}
+
+func (stubMarketDataService) StreamOrderBook(ctx context.Context, symbol string, depth int) (<-chan domain.OrderBookUpdate, error) {
+	updates := make(chan domain.OrderBookUpdate)
+	close(updates)
+	return updates, nil
+}
+
+// authorizedServices wraps the stubs with the default policy
+func authorizedServices(t *testing.T) (port.StockOrderService, port.MarketDataService) {
+	t.Helper()
+	orders, err := service.NewAuthorizedStockOrderService(&stubStockOrderService{account: testAccount}, service.DefaultPolicy())
+	if err != nil {
+		t.Fatalf("failed to authorize order service: %v", err)
+	}
+	marketData, err := service.NewAuthorizedMarketDataService(stubMarketDataService{}, service.DefaultPolicy())
+	if err != nil {
+		t.Fatalf("failed to authorize market data service: %v", err)
+	}
+	return orders, marketData
+}
+
+// rolePrincipal is the principal of a test caller holding role, or no
+// principal for an anonymous caller
+func rolePrincipal(ctx context.Context, role string) context.Context {
+	if role == "" {
+		return ctx
+	}
+	return domain.ContextWithPrincipal(ctx, &domain.Principal{
+		Subject:   "user-" + role,
+		AccountID: testAccount,
+		Roles:     []string{role},
+		Method:    domain.AuthMethodAPIKey,
+	})
+}
+
+// testRoles are the callers every route and RPC is exercised with
+func testRoles() []string {
+	roles := []string{""}
+	for _, role := range domain.Roles {
+		roles = append(roles, string(role))
+	}
+	return roles
+}
+
+func granted(operation domain.Operation, role string) bool {
+	return slices.Contains(service.DefaultPolicy().Grants[operation], domain.Role(role))
+}
+
+func TestAuthorizationHTTPRoutes(t *testing.T) {
+	tests := []struct {
+		method    string
+		path      string
+		target    string
+		body      string
+		operation domain.Operation
+	}{
+		{"POST", "/api/orders", "/api/orders", `{"symbol":"AAPL","order_type":"LIMIT","order_side":"BUY","quantity":10,"price":100}`, domain.OperationCreateOrder},
+		{"GET", "/api/orders", "/api/orders", "", domain.OperationListOrders},
+		{"POST", "/api/orders/batch", "/api/orders/batch", `{"orders":[{"symbol":"AAPL","order_type":"MARKET","order_side":"BUY","quantity":10}]}`, domain.OperationBatchCreateOrders},
+		{"POST", "/api/orders/cancel-all", "/api/orders/cancel-all", "", domain.OperationMassCancel},
+		{"GET", "/api/orders/{id}", "/api/orders/order-1", "", domain.OperationGetOrder},
+		{"POST", "/api/orders/{id}/cancel", "/api/orders/order-1/cancel", "", domain.OperationCancelOrder},
+		{"GET", "/api/symbols/{symbol}/session", "/api/symbols/AAPL/session", "", domain.OperationGetMarketSession},
+		{"GET", "/api/symbols/{symbol}/auction", "/api/symbols/AAPL/auction", "", domain.OperationGetAuctionState},
+		{"GET", "/api/symbols/{symbol}/quote", "/api/symbols/AAPL/quote", "", domain.OperationGetQuote},
+		{"GET", "/api/symbols/{symbol}/candles", "/api/symbols/AAPL/candles?interval=1m", "", domain.OperationGetCandles},
+		{"GET", "/api/instruments", "/api/instruments", "", domain.OperationListInstruments},
+		{"PUT", "/api/admin/instruments/{symbol}", "/api/admin/instruments/AAPL", `{"name":"Apple","lot_size":1,"tick_size":0.01}`, domain.OperationUpsertInstrument},
+		{"DELETE", "/api/admin/instruments/{symbol}", "/api/admin/instruments/AAPL", "", domain.OperationDeleteInstrument},
+		{"POST", "/api/admin/symbols/{symbol}/halt", "/api/admin/symbols/AAPL/halt", `{"reason":"news pending"}`, domain.OperationHaltSymbol},
+		{"POST", "/api/admin/symbols/{symbol}/resume", "/api/admin/symbols/AAPL/resume", "", domain.OperationResumeSymbol},
+		{"GET", "/api/stream/orders", "/api/stream/orders", "", domain.OperationWatchOrders},
+	}
+
+	orders, marketData := authorizedServices(t)
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(rolePrincipal(r.Context(), r.Header.Get("X-Test-Role"))))
+		})
+	})
+	NewHTTPHandler(orders, WithMarketDataService(marketData)).RegisterRoutes(router)
+
+	covered := map[string]bool{"GET /ws": true}
+	for _, tt := range tests {
+		covered[tt.method+" "+tt.path] = true
+	}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, _ := route.GetPathTemplate()
+		methods, _ := route.GetMethods()
+		for _, method := range methods {
+			if !publicPaths[path] && !covered[method+" "+path] {
+				t.Errorf("route %s %s has no authorization test", method, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+
+	for _, tt := range tests {
+		for _, role := range testRoles() {
+			t.Run(tt.method+" "+tt.path+" as "+role, func(t *testing.T) {
+				var body io.Reader
+				if tt.body != "" {
+					body = strings.NewReader(tt.body)
+				}
+				// Streams run until the request context ends
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer cancel()
+				req := httptest.NewRequestWithContext(ctx, tt.method, tt.target, body)
+				req.Header.Set("X-Test-Role", role)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if want := granted(tt.operation, role); want != (w.Code != http.StatusForbidden) {
+					t.Errorf("got status %d, want granted=%v: %s", w.Code, want, w.Body.String())
+				}
+				if w.Code == http.StatusForbidden && !strings.Contains(w.Body.String(), `"PERMISSION_DENIED"`) {
+					t.Errorf("403 without PERMISSION_DENIED code: %s", w.Body.String())
+				}
+			})
+		}
+	}
+}
+
+func TestAuthorizationWebSocketChannels(t *testing.T) {
+	tests := []struct {
+		channel   string
+		operation domain.Operation
+	}{
+		{"orders", domain.OperationWatchOrders},
+		{"quotes", domain.OperationSubscribeQuotes},
+	}
+
+	orders, marketData := authorizedServices(t)
+	handler := NewHTTPHandler(orders, WithMarketDataService(marketData))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeWebSocket(w, r.WithContext(rolePrincipal(r.Context(), r.Header.Get("X-Test-Role"))))
+	}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		for _, role := range testRoles() {
+			t.Run(tt.channel+" as "+role, func(t *testing.T) {
+				header := http.Header{"X-Test-Role": {role}}
+				conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+				if err != nil {
+					t.Fatalf("failed to dial: %v", err)
+				}
+				defer conn.Close()
+
+				if err := conn.WriteJSON(wsCommand{Action: "subscribe", Channel: tt.channel, Symbols: []string{"AAPL"}}); err != nil {
+					t.Fatalf("failed to subscribe: %v", err)
+				}
+				conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+				var msg wsMessage
+				if err := conn.ReadJSON(&msg); err != nil {
+					t.Fatalf("failed to read reply: %v", err)
+				}
+
+				denied := msg.Type == "error" && msg.Code == "PERMISSION_DENIED"
+				if want := granted(tt.operation, role); want == denied {
+					t.Errorf("got %+v, want granted=%v", msg, want)
+				}
+			})
+		}
+	}
+}
+
+func TestAuthorizationGRPCMethods(t *testing.T) {
+	type call func(ctx context.Context, conn *grpc.ClientConn) error
+
+	tests := map[string]struct {
+		operation domain.Operation
+		call      call
+	}{
+		"/stockorder.v1.StockOrderService/CreateOrder": {domain.OperationCreateOrder, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).CreateOrder(ctx, &pb.CreateOrderRequest{
+				Symbol: "AAPL", OrderType: pb.OrderType_LIMIT, OrderSide: pb.OrderSide_BUY, Quantity: 10, Price: 100,
+			})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/GetOrder": {domain.OperationGetOrder, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).GetOrder(ctx, &pb.GetOrderRequest{OrderId: "order-1"})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/ListOrders": {domain.OperationListOrders, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).ListOrders(ctx, &pb.ListOrdersRequest{})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/CancelOrder": {domain.OperationCancelOrder, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: "order-1"})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/BatchCreateOrders": {domain.OperationBatchCreateOrders, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).BatchCreateOrders(ctx, &pb.BatchCreateOrdersRequest{
+				Orders: []*pb.CreateOrderRequest{{Symbol: "AAPL", OrderType: pb.OrderType_MARKET, OrderSide: pb.OrderSide_BUY, Quantity: 10}},
+			})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/MassCancel": {domain.OperationMassCancel, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).MassCancel(ctx, &pb.MassCancelRequest{})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/GetQuote": {domain.OperationGetQuote, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).GetQuote(ctx, &pb.GetQuoteRequest{Symbol: "AAPL"})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/GetCandles": {domain.OperationGetCandles, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).GetCandles(ctx, &pb.GetCandlesRequest{Symbol: "AAPL", Interval: "1m"})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/ListInstruments": {domain.OperationListInstruments, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewStockOrderServiceClient(conn).ListInstruments(ctx, &pb.ListInstrumentsRequest{})
+			return err
+		}},
+		"/stockorder.v1.StockOrderService/WatchOrders": {domain.OperationWatchOrders, func(ctx context.Context, conn *grpc.ClientConn) error {
+			// Authorized watches run until the deadline
+			ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			stream, err := pb.NewStockOrderServiceClient(conn).WatchOrders(ctx, &pb.WatchOrdersRequest{})
+			if err != nil {
+				return err
+			}
+			return recvUntilEOF(stream.Recv)
+		}},
+		"/stockorder.v1.StockOrderService/StreamOrderBook": {domain.OperationStreamOrderBook, func(ctx context.Context, conn *grpc.ClientConn) error {
+			stream, err := pb.NewStockOrderServiceClient(conn).StreamOrderBook(ctx, &pb.StreamOrderBookRequest{Symbol: "AAPL"})
+			if err != nil {
+				return err
+			}
+			return recvUntilEOF(stream.Recv)
+		}},
+		// The session itself only needs WatchOrders; each command is
+		// authorized as it is processed and rejected with its status code
+		"/stockorder.v1.StockOrderService/OrderSession": {domain.OperationCreateOrder, func(ctx context.Context, conn *grpc.ClientConn) error {
+			stream, err := pb.NewStockOrderServiceClient(conn).OrderSession(ctx)
+			if err != nil {
+				return err
+			}
+			err = stream.Send(&pb.OrderSessionRequest{
+				ClientOrderId: "session-1",
+				Command: &pb.OrderSessionRequest_Create{Create: &pb.CreateOrderRequest{
+					Symbol: "AAPL", OrderType: pb.OrderType_LIMIT, OrderSide: pb.OrderSide_BUY, Quantity: 10, Price: 100,
+				}},
+			})
+			if err != nil {
+				return err
+			}
+			resp, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			stream.CloseSend()
+			if reject := resp.GetReject(); reject != nil {
+				return status.Error(codes.Code(reject.Code), reject.Reason)
+			}
+			return nil
+		}},
+		"/stockorder.v1.AdminService/UpsertInstrument": {domain.OperationUpsertInstrument, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewAdminServiceClient(conn).UpsertInstrument(ctx, &pb.UpsertInstrumentRequest{Symbol: "AAPL", Name: "Apple", LotSize: 1, TickSize: 0.01})
+			return err
+		}},
+		"/stockorder.v1.AdminService/DeleteInstrument": {domain.OperationDeleteInstrument, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewAdminServiceClient(conn).DeleteInstrument(ctx, &pb.DeleteInstrumentRequest{Symbol: "AAPL"})
+			return err
+		}},
+		"/stockorder.v1.AdminService/HaltSymbol": {domain.OperationHaltSymbol, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewAdminServiceClient(conn).HaltSymbol(ctx, &pb.HaltSymbolRequest{Symbol: "AAPL", Reason: "news pending"})
+			return err
+		}},
+		"/stockorder.v1.AdminService/ResumeSymbol": {domain.OperationResumeSymbol, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pb.NewAdminServiceClient(conn).ResumeSymbol(ctx, &pb.ResumeSymbolRequest{Symbol: "AAPL"})
+			return err
+		}},
+		"/stockorder.v2.StockOrderService/CreateOrder": {domain.OperationCreateOrder, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pbv2.NewStockOrderServiceClient(conn).CreateOrder(ctx, &pbv2.CreateOrderRequest{
+				Symbol: "AAPL", OrderType: pbv2.OrderType_ORDER_TYPE_LIMIT, OrderSide: pbv2.OrderSide_ORDER_SIDE_BUY, Quantity: 10, Price: "100",
+			})
+			return err
+		}},
+		"/stockorder.v2.StockOrderService/GetOrder": {domain.OperationGetOrder, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pbv2.NewStockOrderServiceClient(conn).GetOrder(ctx, &pbv2.GetOrderRequest{OrderId: "order-1"})
+			return err
+		}},
+		"/stockorder.v2.StockOrderService/ListOrders": {domain.OperationListOrders, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pbv2.NewStockOrderServiceClient(conn).ListOrders(ctx, &pbv2.ListOrdersRequest{})
+			return err
+		}},
+		"/stockorder.v2.StockOrderService/CancelOrder": {domain.OperationCancelOrder, func(ctx context.Context, conn *grpc.ClientConn) error {
+			_, err := pbv2.NewStockOrderServiceClient(conn).CancelOrder(ctx, &pbv2.CancelOrderRequest{OrderId: "order-1"})
+			return err
+		}},
+	}
+
+	for _, desc := range []grpc.ServiceDesc{pb.StockOrderService_ServiceDesc, pb.AdminService_ServiceDesc, pbv2.StockOrderService_ServiceDesc} {
+		for _, method := range desc.Methods {
+			if _, ok := tests["/"+desc.ServiceName+"/"+method.MethodName]; !ok {
+				t.Errorf("RPC /%s/%s has no authorization test", desc.ServiceName, method.MethodName)
+			}
+		}
+		for _, stream := range desc.Streams {
+			if _, ok := tests["/"+desc.ServiceName+"/"+stream.StreamName]; !ok {
+				t.Errorf("RPC /%s/%s has no authorization test", desc.ServiceName, stream.StreamName)
+			}
+		}
+	}
+
+	orders, marketData := authorizedServices(t)
+	conn := newAuthorizationTestConn(t, orders, marketData)
+	for name, tt := range tests {
+		for _, role := range testRoles() {
+			t.Run(name+" as "+role, func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), "x-test-role", role), 5*time.Second)
+				defer cancel()
+
+				err := tt.call(ctx, conn)
+				denied := status.Code(err) == codes.PermissionDenied
+				if want := granted(tt.operation, role); want == denied {
+					t.Errorf("got %v, want granted=%v", err, want)
+				}
+			})
+		}
+	}
+}
+
+func TestAuthorizationHidesOtherAccounts(t *testing.T) {
+	orders, err := service.NewAuthorizedStockOrderService(&stubStockOrderService{account: "acct-2"}, service.DefaultPolicy())
+	if err != nil {
+		t.Fatalf("failed to authorize order service: %v", err)
+	}
+
+	tests := []struct {
+		role    domain.Role
+		wantErr error
+	}{
+		{domain.RoleTrader, domain.ErrNotFound},
+		{domain.RoleOps, nil},
+		{domain.RoleAdmin, nil},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			ctx := rolePrincipal(context.Background(), string(tt.role))
+			err := orders.CancelOrder(ctx, "order-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CancelOrder returned %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyKeepsAnonymousCallersToOneAccount(t *testing.T) {
+	policy := service.DefaultPolicy()
+	policy.Anonymous = []domain.Role{domain.RoleTrader}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("anonymous traders rejected: %v", err)
+	}
+
+	policy.Anonymous = []domain.Role{domain.RoleOps}
+	if err := policy.Validate(); err == nil {
+		t.Error("policy letting anonymous callers access every account was accepted")
+	}
+}
+
+func TestAnonymousCallersNeedAnAccount(t *testing.T) {
+	// Orders of anonymous callers that named no account belong to no account
+	policy := service.DefaultPolicy()
+	policy.Anonymous = []domain.Role{domain.RoleTrader}
+	orders, err := service.NewAuthorizedStockOrderService(&stubStockOrderService{}, policy)
+	if err != nil {
+		t.Fatalf("failed to authorize order service: %v", err)
+	}
+
+	t.Run("HTTP", func(t *testing.T) {
+		router := mux.NewRouter()
+		NewHTTPHandler(orders).RegisterRoutes(router)
+
+		for _, target := range []string{"GET /api/orders", "GET /api/orders/order-1", "POST /api/orders/order-1/cancel"} {
+			method, path, _ := strings.Cut(target, " ")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("%s without an account returned %d, want 401: %s", target, w.Code, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("gRPC", func(t *testing.T) {
+		client := pb.NewStockOrderServiceClient(newAuthorizationTestConn(t, orders, stubMarketDataService{}))
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		calls := map[string]func() error{
+			"ListOrders": func() error {
+				_, err := client.ListOrders(ctx, &pb.ListOrdersRequest{})
+				return err
+			},
+			"GetOrder": func() error {
+				_, err := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: "order-1"})
+				return err
+			},
+			"CancelOrder": func() error {
+				_, err := client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: "order-1"})
+				return err
+			},
+		}
+		for name, call := range calls {
+			if err := call(); status.Code(err) != codes.Unauthenticated {
+				t.Errorf("%s without an account returned %v, want Unauthenticated", name, err)
+			}
+		}
+	})
+}
+
+func TestAnonymousCallersActForTheirAccountOnEveryRPC(t *testing.T) {
+	policy := service.DefaultPolicy()
+	policy.Anonymous = []domain.Role{domain.RoleTrader}
+	orders, err := service.NewAuthorizedStockOrderService(&stubStockOrderService{account: testAccount}, policy)
+	if err != nil {
+		t.Fatalf("failed to authorize order service: %v", err)
+	}
+	conn := newAuthorizationTestConn(t, orders, stubMarketDataService{})
+	v1, v2 := pb.NewStockOrderServiceClient(conn), pbv2.NewStockOrderServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), "x-account-id", testAccount), 5*time.Second)
+	defer cancel()
+
+	calls := map[string]func() error{
+		"v1 GetOrder": func() error {
+			_, err := v1.GetOrder(ctx, &pb.GetOrderRequest{OrderId: "order-1"})
+			return err
+		},
+		"v1 ListOrders": func() error {
+			_, err := v1.ListOrders(ctx, &pb.ListOrdersRequest{})
+			return err
+		},
+		"v1 CancelOrder": func() error {
+			_, err := v1.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: "order-1"})
+			return err
+		},
+		"v2 GetOrder": func() error {
+			_, err := v2.GetOrder(ctx, &pbv2.GetOrderRequest{OrderId: "order-1"})
+			return err
+		},
+		"v2 ListOrders": func() error {
+			_, err := v2.ListOrders(ctx, &pbv2.ListOrdersRequest{})
+			return err
+		},
+		"v2 CancelOrder": func() error {
+			_, err := v2.CancelOrder(ctx, &pbv2.CancelOrderRequest{OrderId: "order-1"})
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); err != nil {
+			t.Errorf("%s of the caller's own order returned %v", name, err)
+		}
+	}
+}
+
+// newAuthorizationTestConn serves handlers of the authorized services over
+// an in-memory listener. Callers pick their role with the x-test-role
+// metadata.
+func newAuthorizationTestConn(t *testing.T, orders port.StockOrderService, marketData port.MarketDataService) *grpc.ClientConn {
+	t.Helper()
+	principal := func(ctx context.Context) context.Context {
+		return rolePrincipal(ctx, metadataValue(ctx, "x-test-role"))
+	}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return handler(principal(ctx), req)
+		}, AccountUnaryInterceptor),
+		grpc.ChainStreamInterceptor(func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &contextServerStream{ServerStream: stream, ctx: principal(stream.Context())})
+		}, AccountStreamInterceptor),
+	)
+	pb.RegisterStockOrderServiceServer(server, NewGRPCHandler(orders, WithMarketDataService(marketData)))
+	pb.RegisterAdminServiceServer(server, NewGRPCAdminHandler(orders))
+	pbv2.RegisterStockOrderServiceServer(server, NewGRPCHandlerV2(orders))
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// recvUntilEOF drains a server stream, returning nil when it ends cleanly
+func recvUntilEOF[T any](recv func() (T, error)) error {
+	for {
+		if _, err := recv(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package adaptor
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+	pbv2 "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// descriptorSet is the FileDescriptorSet of the gRPC API, built once from the
+// compiled v1 and v2 protos with every import listed before the files that use it
+var descriptorSet = sync.OnceValues(func() ([]byte, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	add(pb.File_proto_stockorder_v1_stock_order_proto)
+	add(pbv2.File_proto_stockorder_v2_stock_order_proto)
+
+	return proto.Marshal(set)
+})
+
+// DescriptorSet serves the FileDescriptorSet of the gRPC API for tools that
+// cannot use server reflection, for example grpcurl -protoset
+func (h *HTTPHandler) DescriptorSet(w http.ResponseWriter, r *http.Request) {
+	data, err := descriptorSet()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to build descriptor set", "component", "DescriptorSet", "error", err)
+		respondError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Disposition", `attachment; filename="stock_order.binpb"`)
+	w.Write(data)
+}
@@ -0,0 +1,141 @@
+package adaptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain identifies this service in gRPC ErrorInfo details
+const errorDomain = "stockorder.kkp-dime"
+
+// errorKind is how one domain error kind is reported by each transport
+type errorKind struct {
+	kind       error
+	code       string
+	httpStatus int
+	grpcCode   codes.Code
+}
+
+var errorKinds = []errorKind{
+	{domain.ErrValidation, "VALIDATION", http.StatusBadRequest, codes.InvalidArgument},
+	{domain.ErrNotFound, "NOT_FOUND", http.StatusNotFound, codes.NotFound},
+	{domain.ErrConflict, "CONFLICT", http.StatusConflict, codes.AlreadyExists},
+	{domain.ErrInvalidState, "INVALID_STATE", http.StatusConflict, codes.FailedPrecondition},
+	{domain.ErrUnavailable, "UNAVAILABLE", http.StatusServiceUnavailable, codes.Unavailable},
+	{domain.ErrUnauthenticated, "UNAUTHENTICATED", http.StatusUnauthorized, codes.Unauthenticated},
+	{domain.ErrPermissionDenied, "PERMISSION_DENIED", http.StatusForbidden, codes.PermissionDenied},
+	{domain.ErrRateLimited, "RATE_LIMITED", http.StatusTooManyRequests, codes.ResourceExhausted},
+}
+
+var (
+	internalErrorKind        = errorKind{code: "INTERNAL", httpStatus: http.StatusInternalServerError, grpcCode: codes.Internal}
+	payloadTooLargeErrorKind = errorKind{code: "PAYLOAD_TOO_LARGE", httpStatus: http.StatusRequestEntityTooLarge, grpcCode: codes.ResourceExhausted}
+)
+
+func classifyError(err error) errorKind {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return payloadTooLargeErrorKind
+	}
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind.kind) {
+			return kind
+		}
+	}
+	return internalErrorKind
+}
+
+// errorFields returns the field, resource and field violations a domain
+// error refers to, if any
+func errorFields(err error) (field, resource string, violations []domain.FieldViolation) {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Field, domainErr.Resource, domainErr.Violations
+	}
+	return "", "", nil
+}
+
+func newErrorResponse(err error) ErrorResponse {
+	field, resource, violations := errorFields(err)
+	return ErrorResponse{
+		Error:      err.Error(),
+		Code:       classifyError(err).code,
+		Field:      field,
+		Resource:   resource,
+		Violations: violations,
+	}
+}
+
+// errorRetryAfter returns when a rate limited or shed request may be retried
+func errorRetryAfter(err error) time.Duration {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.RetryAfter
+	}
+	return 0
+}
+
+// respondError writes err as an ErrorResponse with the status of its kind,
+// and a Retry-After header for rate limited and shed requests
+func respondError(w http.ResponseWriter, err error) {
+	setRetryAfter(w, errorRetryAfter(err))
+	respondJSON(w, classifyError(err).httpStatus, newErrorResponse(err))
+}
+
+// grpcError converts err to a status with the code of its kind. The status
+// carries an ErrorInfo whose reason is the error code, plus a BadRequest
+// listing the invalid fields of validation errors, a ResourceInfo for
+// missing resources and a RetryInfo for rate limited and shed calls.
+func grpcError(err error, message string) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	kind := classifyError(err)
+	field, resource, violations := errorFields(err)
+	st := status.New(kind.grpcCode, fmt.Sprintf("%s: %v", message, err))
+
+	info := &errdetails.ErrorInfo{Reason: kind.code, Domain: errorDomain}
+	if field != "" || resource != "" {
+		info.Metadata = map[string]string{}
+		if field != "" {
+			info.Metadata["field"] = field
+		}
+		if resource != "" {
+			info.Metadata["resource"] = resource
+		}
+	}
+	details := []protoadapt.MessageV1{info}
+	if len(violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if kind.grpcCode == codes.NotFound && resource != "" {
+		details = append(details, &errdetails.ResourceInfo{ResourceType: resource, Description: err.Error()})
+	}
+
+	if retryAfter := errorRetryAfter(err); retryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+
+	if withDetails, detailErr := st.WithDetails(details...); detailErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
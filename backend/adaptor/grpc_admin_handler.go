@@ -0,0 +1,65 @@
+package adaptor
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+)
+
+// GRPCAdminHandler serves the AdminService. Access is decided by the
+// authorization policy of the service, not by the handler.
+type GRPCAdminHandler struct {
+	pb.UnimplementedAdminServiceServer
+	service port.StockOrderService
+}
+
+func NewGRPCAdminHandler(service port.StockOrderService) *GRPCAdminHandler {
+	return &GRPCAdminHandler{service: service}
+}
+
+// UpsertInstrument handles the gRPC UpsertInstrument request
+func (h *GRPCAdminHandler) UpsertInstrument(ctx context.Context, req *pb.UpsertInstrumentRequest) (*pb.Instrument, error) {
+	instrument, err := h.service.UpsertInstrument(ctx, req.Symbol, domain.UpsertInstrumentRequest{
+		Name:     req.Name,
+		LotSize:  int(req.LotSize),
+		TickSize: req.TickSize,
+	})
+	if err != nil {
+		return nil, grpcError(err, "failed to upsert instrument")
+	}
+
+	return convertDomainInstrumentToProto(instrument), nil
+}
+
+// DeleteInstrument handles the gRPC DeleteInstrument request
+func (h *GRPCAdminHandler) DeleteInstrument(ctx context.Context, req *pb.DeleteInstrumentRequest) (*pb.DeleteInstrumentResponse, error) {
+	if err := h.service.DeleteInstrument(ctx, req.Symbol); err != nil {
+		return nil, grpcError(err, "failed to delete instrument")
+	}
+
+	return &pb.DeleteInstrumentResponse{
+		Message: "instrument deleted successfully",
+	}, nil
+}
+
+// HaltSymbol handles the gRPC HaltSymbol request
+func (h *GRPCAdminHandler) HaltSymbol(ctx context.Context, req *pb.HaltSymbolRequest) (*pb.Instrument, error) {
+	instrument, err := h.service.HaltSymbol(ctx, req.Symbol, domain.HaltSymbolRequest{Reason: req.Reason})
+	if err != nil {
+		return nil, grpcError(err, "failed to halt symbol")
+	}
+
+	return convertDomainInstrumentToProto(instrument), nil
+}
+
+// ResumeSymbol handles the gRPC ResumeSymbol request
+func (h *GRPCAdminHandler) ResumeSymbol(ctx context.Context, req *pb.ResumeSymbolRequest) (*pb.Instrument, error) {
+	instrument, err := h.service.ResumeSymbol(ctx, req.Symbol)
+	if err != nil {
+		return nil, grpcError(err, "failed to resume symbol")
+	}
+
+	return convertDomainInstrumentToProto(instrument), nil
+}
@@ -0,0 +1,71 @@
+package adaptor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+	pbv2 "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// NewGatewayHandler serves the REST routes generated from the google.api.http
+// options of the v1 and v2 protos, under /api/v1 and /api/v2. Requests are
+// passed to the servers in process, so the REST and gRPC APIs share one
+// implementation and cannot diverge.
+//
+// JSON uses the proto field names and enum names, unknown fields are rejected
+// and errors are returned as google.rpc.Status with their error details.
+// Streaming RPCs have no REST binding; use gRPC, SSE or the WebSocket.
+// Anonymous callers name their account in the X-Account-ID header, as on
+// the unversioned routes. opts add to the gateway's options, for example middlewares.
+func NewGatewayHandler(ctx context.Context, v1 pb.StockOrderServiceServer, admin pb.AdminServiceServer, v2 pbv2.StockOrderServiceServer, opts ...runtime.ServeMuxOption) (http.Handler, error) {
+	opts = append([]runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+		}),
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithErrorHandler(gatewayErrorHandler),
+	}, opts...)
+	mux := runtime.NewServeMux(opts...)
+
+	if err := pb.RegisterStockOrderServiceHandlerServer(ctx, mux, v1); err != nil {
+		return nil, fmt.Errorf("failed to register v1 gateway routes: %w", err)
+	}
+	if err := pb.RegisterAdminServiceHandlerServer(ctx, mux, admin); err != nil {
+		return nil, fmt.Errorf("failed to register admin gateway routes: %w", err)
+	}
+	if err := pbv2.RegisterStockOrderServiceHandlerServer(ctx, mux, v2); err != nil {
+		return nil, fmt.Errorf("failed to register v2 gateway routes: %w", err)
+	}
+
+	return accountMiddleware(http.MaxBytesHandler(mux, MaxRequestBytes)), nil
+}
+
+// gatewayErrorHandler adds a Retry-After header to rate limited responses
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			setRetryAfter(w, info.RetryDelay.AsDuration())
+		}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// gatewayHeaderMatcher passes the headers the gRPC handler reads from
+// metadata, in addition to the gateway's defaults
+func gatewayHeaderMatcher(key string) (string, bool) {
+	switch key = strings.ToLower(key); key {
+	case "idempotency-key":
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
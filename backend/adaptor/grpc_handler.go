@@ -2,41 +2,65 @@ package adaptor
 
 import (
 	"context"
+	"errors"
 
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
-	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type GRPCHandler struct {
 	pb.UnimplementedStockOrderServiceServer
+	handlerDeps
 	service port.StockOrderService
 }
 
-func NewGRPCHandler(service port.StockOrderService) *GRPCHandler {
+func NewGRPCHandler(service port.StockOrderService, opts ...HandlerOption) *GRPCHandler {
 	return &GRPCHandler{
-		service: service,
+		handlerDeps: newHandlerDeps(opts),
+		service:     service,
 	}
 }
 
+// legacyServiceName is the name StockOrderService had before the API was
+// split into stockorder.v1 and stockorder.v2
+const legacyServiceName = "stockorder.StockOrderService"
+
+// RegisterLegacyService registers server under legacyServiceName as well, so
+// clients generated from the unversioned proto keep working. The messages
+// are unchanged, only the package name differs.
+func RegisterLegacyService(registrar grpc.ServiceRegistrar, server pb.StockOrderServiceServer) {
+	desc := pb.StockOrderService_ServiceDesc
+	desc.ServiceName = legacyServiceName
+	registrar.RegisterService(&desc, server)
+}
+
 // CreateOrder handles the gRPC CreateOrder request
 func (h *GRPCHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.StockOrder, error) {
+	clientOrderID, err := resolveClientOrderID(req.ClientOrderId, metadataValue(ctx, "idempotency-key"))
+	if err != nil {
+		return nil, grpcError(err, "invalid request")
+	}
+
 	// Convert protobuf request to domain request
 	domainReq := domain.CreateOrderRequest{
-		Symbol:    req.Symbol,
-		OrderType: convertProtoOrderTypeToDomain(req.OrderType),
-		OrderSide: convertProtoOrderSideToDomain(req.OrderSide),
-		Quantity:  int(req.Quantity),
-		Price:     req.Price,
+		ClientOrderID: clientOrderID,
+		Symbol:        req.Symbol,
+		OrderType:     convertProtoOrderTypeToDomain(req.OrderType),
+		OrderSide:     convertProtoOrderSideToDomain(req.OrderSide),
+		Quantity:      int(req.Quantity),
+		Price:         req.Price,
 	}
 
 	// Call service
 	order, err := h.service.CreateOrder(ctx, domainReq)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "failed to create order: %v", err)
+		return nil, grpcError(err, "failed to create order")
 	}
 
 	// Convert domain order to protobuf
@@ -47,7 +71,7 @@ func (h *GRPCHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderReques
 func (h *GRPCHandler) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.StockOrder, error) {
 	order, err := h.service.GetOrder(ctx, req.OrderId)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "order not found: %v", err)
+		return nil, grpcError(err, "failed to get order")
 	}
 
 	return convertDomainOrderToProto(order), nil
@@ -55,19 +79,36 @@ func (h *GRPCHandler) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*p
 
 // ListOrders handles the gRPC ListOrders request
 func (h *GRPCHandler) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
-	orders, err := h.service.ListOrders(ctx)
+	query := domain.OrderQuery{
+		Symbol:    req.Symbol,
+		OrderSide: convertProtoOrderSideToDomain(req.OrderSide),
+		OrderType: convertProtoOrderTypeToDomain(req.OrderType),
+		Status:    convertProtoOrderStatusToDomain(req.Status),
+		Sort:      convertProtoOrderSortToDomain(req.Sort),
+		Limit:     int(req.PageSize),
+		PageToken: req.PageToken,
+	}
+	if req.CreatedFrom != nil {
+		query.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		query.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	page, err := h.service.ListOrders(ctx, query)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
+		return nil, grpcError(err, "failed to list orders")
 	}
 
 	// Convert domain orders to protobuf
-	protoOrders := make([]*pb.StockOrder, 0, len(orders))
-	for _, order := range orders {
+	protoOrders := make([]*pb.StockOrder, 0, len(page.Orders))
+	for _, order := range page.Orders {
 		protoOrders = append(protoOrders, convertDomainOrderToProto(order))
 	}
 
 	return &pb.ListOrdersResponse{
-		Orders: protoOrders,
+		Orders:        protoOrders,
+		NextPageToken: page.NextPageToken,
 	}, nil
 }
 
@@ -75,7 +116,7 @@ func (h *GRPCHandler) ListOrders(ctx context.Context, req *pb.ListOrdersRequest)
 func (h *GRPCHandler) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
 	err := h.service.CancelOrder(ctx, req.OrderId)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "failed to cancel order: %v", err)
+		return nil, grpcError(err, "failed to cancel order")
 	}
 
 	return &pb.CancelOrderResponse{
@@ -83,20 +124,248 @@ func (h *GRPCHandler) CancelOrder(ctx context.Context, req *pb.CancelOrderReques
 	}, nil
 }
 
+// BatchCreateOrders handles the gRPC BatchCreateOrders request. Rolled back
+// atomic batches are not an error; they are reported with committed unset.
+func (h *GRPCHandler) BatchCreateOrders(ctx context.Context, req *pb.BatchCreateOrdersRequest) (*pb.BatchCreateOrdersResponse, error) {
+	batch := domain.BatchCreateOrdersRequest{Atomic: req.Atomic}
+	for _, order := range req.Orders {
+		batch.Orders = append(batch.Orders, domain.CreateOrderRequest{
+			ClientOrderID: order.ClientOrderId,
+			Symbol:        order.Symbol,
+			OrderType:     convertProtoOrderTypeToDomain(order.OrderType),
+			OrderSide:     convertProtoOrderSideToDomain(order.OrderSide),
+			Quantity:      int(order.Quantity),
+			Price:         order.Price,
+		})
+	}
+
+	results, err := h.service.BatchCreateOrders(ctx, batch)
+	committed := !errors.Is(err, domain.ErrBatchRolledBack)
+	if err != nil && committed {
+		return nil, grpcError(err, "failed to create orders")
+	}
+
+	response := &pb.BatchCreateOrdersResponse{Committed: committed}
+	for _, result := range results {
+		protoResult := &pb.BatchOrderResult{
+			Index: int32(result.Index),
+			Error: result.Error,
+		}
+		if result.Order != nil {
+			protoResult.Order = convertDomainOrderToProto(result.Order)
+		}
+		response.Results = append(response.Results, protoResult)
+	}
+
+	return response, nil
+}
+
+// MassCancel handles the gRPC MassCancel request
+func (h *GRPCHandler) MassCancel(ctx context.Context, req *pb.MassCancelRequest) (*pb.MassCancelResponse, error) {
+	results, err := h.service.MassCancel(ctx, domain.MassCancelRequest{
+		AccountID:   req.AccountId,
+		AllAccounts: req.AllAccounts,
+		Symbol:      req.Symbol,
+		OrderSide:   convertProtoOrderSideToDomain(req.OrderSide),
+	})
+	if err != nil {
+		return nil, grpcError(err, "failed to cancel orders")
+	}
+
+	response := &pb.MassCancelResponse{}
+	for _, result := range results {
+		if result.Cancelled {
+			response.Cancelled++
+		}
+		response.Results = append(response.Results, &pb.CancelResult{
+			OrderId:   result.OrderID,
+			Cancelled: result.Cancelled,
+			Error:     result.Error,
+		})
+	}
+
+	return response, nil
+}
+
+// GetQuote handles the gRPC GetQuote request
+func (h *GRPCHandler) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (*pb.Quote, error) {
+	if h.marketData == nil {
+		return nil, status.Error(codes.Unimplemented, "market data is not configured")
+	}
+
+	quote, err := h.marketData.GetQuote(ctx, req.Symbol)
+	if err != nil {
+		return nil, grpcError(err, "failed to get quote")
+	}
+
+	return convertDomainQuoteToProto(quote), nil
+}
+
+// GetCandles handles the gRPC GetCandles request
+func (h *GRPCHandler) GetCandles(ctx context.Context, req *pb.GetCandlesRequest) (*pb.GetCandlesResponse, error) {
+	if h.marketData == nil {
+		return nil, status.Error(codes.Unimplemented, "market data is not configured")
+	}
+
+	query := domain.CandleQuery{
+		Symbol:   req.Symbol,
+		Interval: domain.CandleInterval(req.Interval),
+		Limit:    int(req.Limit),
+	}
+	if req.From != nil {
+		query.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		query.To = req.To.AsTime()
+	}
+
+	candles, err := h.marketData.GetCandles(ctx, query)
+	if err != nil {
+		return nil, grpcError(err, "failed to get candles")
+	}
+
+	protoCandles := make([]*pb.Candle, 0, len(candles))
+	for _, candle := range candles {
+		protoCandles = append(protoCandles, convertDomainCandleToProto(candle))
+	}
+
+	return &pb.GetCandlesResponse{
+		Candles: protoCandles,
+	}, nil
+}
+
+// ListInstruments handles the gRPC ListInstruments request
+func (h *GRPCHandler) ListInstruments(ctx context.Context, req *pb.ListInstrumentsRequest) (*pb.ListInstrumentsResponse, error) {
+	instruments, err := h.service.ListInstruments(ctx)
+	if err != nil {
+		return nil, grpcError(err, "failed to list instruments")
+	}
+
+	response := &pb.ListInstrumentsResponse{
+		Instruments: make([]*pb.Instrument, 0, len(instruments)),
+	}
+	for _, instrument := range instruments {
+		response.Instruments = append(response.Instruments, convertDomainInstrumentToProto(instrument))
+	}
+
+	return response, nil
+}
+
+// WatchOrders streams order status changes until the client goes away or
+// the server shuts down
+func (h *GRPCHandler) WatchOrders(req *pb.WatchOrdersRequest, stream pb.StockOrderService_WatchOrdersServer) error {
+	ctx := stream.Context()
+
+	sub, err := h.service.WatchOrders(ctx, domain.OrderEventFilter{
+		Symbol:       req.Symbol,
+		FromSequence: req.FromSequence,
+	})
+	if err != nil {
+		return grpcError(err, "failed to watch orders")
+	}
+
+	for event := range sub.Events() {
+		if err := stream.Send(convertDomainOrderEventToProto(event)); err != nil {
+			return err
+		}
+	}
+
+	if err := sub.Err(); err != nil {
+		return status.Errorf(codes.Aborted, "order stream ended: %v", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	// The event hub was closed for graceful shutdown
+	return nil
+}
+
+// StreamOrderBook streams an L2 snapshot of a symbol's order book followed by
+// incremental price level updates
+func (h *GRPCHandler) StreamOrderBook(req *pb.StreamOrderBookRequest, stream pb.StockOrderService_StreamOrderBookServer) error {
+	if h.marketData == nil {
+		return status.Error(codes.Unimplemented, "market data is not configured")
+	}
+
+	ctx := stream.Context()
+	updates, err := h.marketData.StreamOrderBook(ctx, req.Symbol, int(req.Depth))
+	if err != nil {
+		return grpcError(err, "failed to stream order book")
+	}
+
+	for update := range updates {
+		if err := stream.Send(convertDomainOrderBookUpdateToProto(update)); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	// Market data subscriptions were closed for graceful shutdown
+	return nil
+}
+
+// AccountUnaryInterceptor attaches the account named in the x-account-id
+// metadata to unary calls of anonymous callers, so that no handler can miss
+// it. It must run after the authentication interceptor.
+func AccountUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(accountContext(ctx), req)
+}
+
+// AccountStreamInterceptor is AccountUnaryInterceptor for streaming calls
+func AccountStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextServerStream{ServerStream: stream, ctx: accountContext(stream.Context())})
+}
+
+// accountContext attaches the account named in the x-account-id metadata.
+// Authenticated callers always act for their own account.
+func accountContext(ctx context.Context) context.Context {
+	if domain.PrincipalFromContext(ctx) != nil {
+		return ctx
+	}
+	if accountID := metadataValue(ctx, "x-account-id"); accountID != "" {
+		ctx = domain.ContextWithAccountID(ctx, accountID)
+		setRequestCaller(ctx)
+	}
+	return ctx
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
 // Helper functions to convert between protobuf and domain types
 
 func convertDomainOrderToProto(order *domain.StockOrder) *pb.StockOrder {
 	return &pb.StockOrder{
-		Id:          order.ID,
-		Symbol:      order.Symbol,
-		OrderType:   convertDomainOrderTypeToProto(order.OrderType),
-		OrderSide:   convertDomainOrderSideToProto(order.OrderSide),
-		Quantity:    int32(order.Quantity),
-		Price:       order.Price,
-		Status:      convertDomainOrderStatusToProto(order.Status),
-		CreatedAt:   timestamppb.New(order.CreatedAt),
-		UpdatedAt:   timestamppb.New(order.UpdatedAt),
-		Description: order.Description,
+		Id:             order.ID,
+		AccountId:      order.AccountID,
+		ClientOrderId:  order.ClientOrderID,
+		Symbol:         order.Symbol,
+		OrderType:      convertDomainOrderTypeToProto(order.OrderType),
+		OrderSide:      convertDomainOrderSideToProto(order.OrderSide),
+		Quantity:       int32(order.Quantity),
+		Price:          order.Price,
+		Status:         convertDomainOrderStatusToProto(order.Status),
+		CreatedAt:      timestamppb.New(order.CreatedAt),
+		UpdatedAt:      timestamppb.New(order.UpdatedAt),
+		Description:    order.Description,
+		FilledQuantity: int32(order.FilledQuantity),
+		AveragePrice:   order.AveragePrice,
+	}
+}
+
+func convertDomainOrderEventToProto(event domain.OrderEvent) *pb.OrderEvent {
+	return &pb.OrderEvent{
+		Sequence:   event.Sequence,
+		Order:      convertDomainOrderToProto(&event.Order),
+		OccurredAt: timestamppb.New(event.OccurredAt),
 	}
 }
 
@@ -148,6 +417,8 @@ func convertDomainOrderStatusToProto(orderStatus domain.OrderStatus) pb.OrderSta
 	switch orderStatus {
 	case domain.OrderStatusPending:
 		return pb.OrderStatus_PENDING
+	case domain.OrderStatusPartiallyFilled:
+		return pb.OrderStatus_PARTIALLY_FILLED
 	case domain.OrderStatusFilled:
 		return pb.OrderStatus_FILLED
 	case domain.OrderStatusCancelled:
@@ -158,3 +429,95 @@ func convertDomainOrderStatusToProto(orderStatus domain.OrderStatus) pb.OrderSta
 		return pb.OrderStatus_ORDER_STATUS_UNSPECIFIED
 	}
 }
+
+func convertProtoOrderStatusToDomain(orderStatus pb.OrderStatus) domain.OrderStatus {
+	switch orderStatus {
+	case pb.OrderStatus_PENDING:
+		return domain.OrderStatusPending
+	case pb.OrderStatus_PARTIALLY_FILLED:
+		return domain.OrderStatusPartiallyFilled
+	case pb.OrderStatus_FILLED:
+		return domain.OrderStatusFilled
+	case pb.OrderStatus_CANCELLED:
+		return domain.OrderStatusCancelled
+	case pb.OrderStatus_REJECTED:
+		return domain.OrderStatusRejected
+	default:
+		return ""
+	}
+}
+
+func convertProtoOrderSortToDomain(sort pb.OrderSort) domain.OrderSort {
+	if sort == pb.OrderSort_CREATED_AT_ASC {
+		return domain.OrderSortCreatedAtAsc
+	}
+	return domain.OrderSortCreatedAtDesc
+}
+
+func convertDomainQuoteToProto(quote *domain.SymbolQuote) *pb.Quote {
+	protoQuote := &pb.Quote{
+		Symbol:       quote.Symbol,
+		LastPrice:    quote.LastPrice,
+		LastQuantity: int32(quote.LastQuantity),
+		BidPrice:     quote.BidPrice,
+		BidSize:      int32(quote.BidSize),
+		AskPrice:     quote.AskPrice,
+		AskSize:      int32(quote.AskSize),
+		Bids:         convertDomainPriceLevelsToProto(quote.Bids),
+		Asks:         convertDomainPriceLevelsToProto(quote.Asks),
+		UpdatedAt:    timestamppb.New(quote.UpdatedAt),
+	}
+	if quote.LastTradeAt != nil {
+		protoQuote.LastTradeAt = timestamppb.New(*quote.LastTradeAt)
+	}
+	return protoQuote
+}
+
+func convertDomainPriceLevelsToProto(levels []domain.PriceLevel) []*pb.PriceLevel {
+	protoLevels := make([]*pb.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		protoLevels = append(protoLevels, &pb.PriceLevel{
+			Price:      level.Price,
+			Quantity:   int32(level.Quantity),
+			OrderCount: int32(level.OrderCount),
+		})
+	}
+	return protoLevels
+}
+
+func convertDomainOrderBookUpdateToProto(update domain.OrderBookUpdate) *pb.OrderBookUpdate {
+	return &pb.OrderBookUpdate{
+		Symbol:    update.Symbol,
+		Sequence:  update.Sequence,
+		Snapshot:  update.Snapshot,
+		Bids:      convertDomainPriceLevelsToProto(update.Bids),
+		Asks:      convertDomainPriceLevelsToProto(update.Asks),
+		UpdatedAt: timestamppb.New(update.UpdatedAt),
+	}
+}
+
+func convertDomainCandleToProto(candle *domain.Candle) *pb.Candle {
+	return &pb.Candle{
+		Symbol:     candle.Symbol,
+		Interval:   string(candle.Interval),
+		OpenTime:   timestamppb.New(candle.OpenTime),
+		Open:       candle.Open,
+		High:       candle.High,
+		Low:        candle.Low,
+		Close:      candle.Close,
+		Volume:     candle.Volume,
+		TradeCount: int32(candle.TradeCount),
+	}
+}
+
+func convertDomainInstrumentToProto(instrument *domain.Instrument) *pb.Instrument {
+	return &pb.Instrument{
+		Symbol:     instrument.Symbol,
+		Name:       instrument.Name,
+		LotSize:    int32(instrument.LotSize),
+		TickSize:   instrument.TickSize,
+		Halted:     instrument.Halted,
+		HaltReason: instrument.HaltReason,
+		UpdatedAt:  timestamppb.New(instrument.UpdatedAt),
+	}
+}
@@ -0,0 +1,288 @@
+package adaptor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	pbv2 "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCHandlerV2 serves stockorder.v2 by translating its messages to and from
+// the domain types used by the v1 handler, so both versions share one service
+type GRPCHandlerV2 struct {
+	pbv2.UnimplementedStockOrderServiceServer
+	service port.StockOrderService
+}
+
+func NewGRPCHandlerV2(service port.StockOrderService) *GRPCHandlerV2 {
+	return &GRPCHandlerV2{service: service}
+}
+
+// CreateOrder handles the gRPC CreateOrder request
+func (h *GRPCHandlerV2) CreateOrder(ctx context.Context, req *pbv2.CreateOrderRequest) (*pbv2.Order, error) {
+	clientOrderID, err := resolveClientOrderID(req.ClientOrderId, metadataValue(ctx, "idempotency-key"))
+	if err != nil {
+		return nil, grpcError(err, "invalid request")
+	}
+
+	price, err := parseDecimal("price", req.Price)
+	if err != nil {
+		return nil, grpcError(err, "invalid request")
+	}
+
+	order, err := h.service.CreateOrder(ctx, domain.CreateOrderRequest{
+		ClientOrderID: clientOrderID,
+		Symbol:        req.Symbol,
+		OrderType:     convertV2OrderTypeToDomain(req.OrderType),
+		OrderSide:     convertV2OrderSideToDomain(req.OrderSide),
+		Quantity:      int(req.Quantity),
+		Price:         price,
+	})
+	if err != nil {
+		return nil, grpcError(err, "failed to create order")
+	}
+
+	return h.orderWithFills(ctx, order)
+}
+
+// GetOrder handles the gRPC GetOrder request
+func (h *GRPCHandlerV2) GetOrder(ctx context.Context, req *pbv2.GetOrderRequest) (*pbv2.Order, error) {
+	order, err := h.service.GetOrder(ctx, req.OrderId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get order")
+	}
+
+	return h.orderWithFills(ctx, order)
+}
+
+// ListOrders handles the gRPC ListOrders request
+func (h *GRPCHandlerV2) ListOrders(ctx context.Context, req *pbv2.ListOrdersRequest) (*pbv2.ListOrdersResponse, error) {
+	query := domain.OrderQuery{
+		Symbol:    req.Symbol,
+		OrderSide: convertV2OrderSideToDomain(req.OrderSide),
+		OrderType: convertV2OrderTypeToDomain(req.OrderType),
+		Status:    convertV2OrderStatusToDomain(req.Status),
+		Sort:      convertV2OrderSortToDomain(req.Sort),
+		Limit:     int(req.PageSize),
+		PageToken: req.PageToken,
+		// One query for the fills of the page rather than one per order
+		IncludeFills: true,
+	}
+	if req.CreatedFrom != nil {
+		query.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		query.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	page, err := h.service.ListOrders(ctx, query)
+	if err != nil {
+		return nil, grpcError(err, "failed to list orders")
+	}
+
+	response := &pbv2.ListOrdersResponse{
+		Orders:        make([]*pbv2.Order, 0, len(page.Orders)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, order := range page.Orders {
+		response.Orders = append(response.Orders, convertDomainOrderToV2(order, page.Fills[order.ID]))
+	}
+
+	return response, nil
+}
+
+// CancelOrder handles the gRPC CancelOrder request and returns the cancelled order
+func (h *GRPCHandlerV2) CancelOrder(ctx context.Context, req *pbv2.CancelOrderRequest) (*pbv2.Order, error) {
+	if err := h.service.CancelOrder(ctx, req.OrderId); err != nil {
+		return nil, grpcError(err, "failed to cancel order")
+	}
+
+	order, err := h.service.GetOrder(ctx, req.OrderId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get order")
+	}
+
+	return h.orderWithFills(ctx, order)
+}
+
+// orderWithFills converts order to v2 together with its executions
+func (h *GRPCHandlerV2) orderWithFills(ctx context.Context, order *domain.StockOrder) (*pbv2.Order, error) {
+	fills, err := h.service.ListOrderFills(ctx, order.ID)
+	if err != nil {
+		return nil, grpcError(err, "failed to list fills")
+	}
+
+	return convertDomainOrderToV2(order, fills), nil
+}
+
+// maxDecimalDigits is the number of significant digits a v2 decimal may
+// have. Prices are float64 in the domain, which holds 15 digits exactly.
+const maxDecimalDigits = 15
+
+// parseDecimal parses a v2 decimal string such as "101.25". Exponents, signs
+// and special values are rejected, as are values with more significant
+// digits than maxDecimalDigits, which would be rounded silently; an empty
+// string is zero.
+func parseDecimal(field, value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	digits, point := 0, false
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.' && !point && digits > 0 && i < len(value)-1:
+			point = true
+		default:
+			return 0, domain.NewValidationError(field, "%q is not a decimal number", value)
+		}
+	}
+
+	// Leading zeros and trailing zeros of the fraction are not significant
+	significant := value
+	if point {
+		significant = strings.TrimRight(significant, "0")
+	}
+	significant = strings.TrimLeft(strings.ReplaceAll(significant, ".", ""), "0")
+	if len(significant) > maxDecimalDigits {
+		return 0, domain.NewValidationError(field, "%q has more than %d significant digits", value, maxDecimalDigits)
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, domain.NewValidationError(field, "%q is out of range", value)
+	}
+	return parsed, nil
+}
+
+// formatDecimal formats v with the fewest digits that parse back to v; zero
+// is formatted as an empty string
+func formatDecimal(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Helper functions to convert between v2 protobuf and domain types
+
+func convertDomainOrderToV2(order *domain.StockOrder, fills []domain.Trade) *pbv2.Order {
+	protoOrder := &pbv2.Order{
+		Id:             order.ID,
+		AccountId:      order.AccountID,
+		ClientOrderId:  order.ClientOrderID,
+		Symbol:         order.Symbol,
+		OrderType:      convertDomainOrderTypeToV2(order.OrderType),
+		OrderSide:      convertDomainOrderSideToV2(order.OrderSide),
+		Quantity:       int64(order.Quantity),
+		Price:          formatDecimal(order.Price),
+		Status:         convertDomainOrderStatusToV2(order.Status),
+		FilledQuantity: int64(order.FilledQuantity),
+		AveragePrice:   formatDecimal(order.AveragePrice),
+		Fills:          make([]*pbv2.Fill, 0, len(fills)),
+		Description:    order.Description,
+		CreatedAt:      timestamppb.New(order.CreatedAt),
+		UpdatedAt:      timestamppb.New(order.UpdatedAt),
+	}
+	for _, fill := range fills {
+		protoOrder.Fills = append(protoOrder.Fills, &pbv2.Fill{
+			TradeId:    fill.ID,
+			Price:      formatDecimal(fill.Price),
+			Quantity:   int64(fill.Quantity),
+			ExecutedAt: timestamppb.New(fill.ExecutedAt),
+			Fee:        formatDecimal(fill.Fee),
+		})
+	}
+	return protoOrder
+}
+
+func convertDomainOrderTypeToV2(orderType domain.OrderType) pbv2.OrderType {
+	switch orderType {
+	case domain.OrderTypeMarket:
+		return pbv2.OrderType_ORDER_TYPE_MARKET
+	case domain.OrderTypeLimit:
+		return pbv2.OrderType_ORDER_TYPE_LIMIT
+	default:
+		return pbv2.OrderType_ORDER_TYPE_UNSPECIFIED
+	}
+}
+
+func convertV2OrderTypeToDomain(orderType pbv2.OrderType) domain.OrderType {
+	switch orderType {
+	case pbv2.OrderType_ORDER_TYPE_MARKET:
+		return domain.OrderTypeMarket
+	case pbv2.OrderType_ORDER_TYPE_LIMIT:
+		return domain.OrderTypeLimit
+	default:
+		return ""
+	}
+}
+
+func convertDomainOrderSideToV2(orderSide domain.OrderSide) pbv2.OrderSide {
+	switch orderSide {
+	case domain.OrderSideBuy:
+		return pbv2.OrderSide_ORDER_SIDE_BUY
+	case domain.OrderSideSell:
+		return pbv2.OrderSide_ORDER_SIDE_SELL
+	default:
+		return pbv2.OrderSide_ORDER_SIDE_UNSPECIFIED
+	}
+}
+
+func convertV2OrderSideToDomain(orderSide pbv2.OrderSide) domain.OrderSide {
+	switch orderSide {
+	case pbv2.OrderSide_ORDER_SIDE_BUY:
+		return domain.OrderSideBuy
+	case pbv2.OrderSide_ORDER_SIDE_SELL:
+		return domain.OrderSideSell
+	default:
+		return ""
+	}
+}
+
+func convertDomainOrderStatusToV2(orderStatus domain.OrderStatus) pbv2.OrderStatus {
+	switch orderStatus {
+	case domain.OrderStatusPending:
+		return pbv2.OrderStatus_ORDER_STATUS_PENDING
+	case domain.OrderStatusPartiallyFilled:
+		return pbv2.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED
+	case domain.OrderStatusFilled:
+		return pbv2.OrderStatus_ORDER_STATUS_FILLED
+	case domain.OrderStatusCancelled:
+		return pbv2.OrderStatus_ORDER_STATUS_CANCELLED
+	case domain.OrderStatusRejected:
+		return pbv2.OrderStatus_ORDER_STATUS_REJECTED
+	default:
+		return pbv2.OrderStatus_ORDER_STATUS_UNSPECIFIED
+	}
+}
+
+func convertV2OrderStatusToDomain(orderStatus pbv2.OrderStatus) domain.OrderStatus {
+	switch orderStatus {
+	case pbv2.OrderStatus_ORDER_STATUS_PENDING:
+		return domain.OrderStatusPending
+	case pbv2.OrderStatus_ORDER_STATUS_PARTIALLY_FILLED:
+		return domain.OrderStatusPartiallyFilled
+	case pbv2.OrderStatus_ORDER_STATUS_FILLED:
+		return domain.OrderStatusFilled
+	case pbv2.OrderStatus_ORDER_STATUS_CANCELLED:
+		return domain.OrderStatusCancelled
+	case pbv2.OrderStatus_ORDER_STATUS_REJECTED:
+		return domain.OrderStatusRejected
+	default:
+		return ""
+	}
+}
+
+func convertV2OrderSortToDomain(sort pbv2.OrderSort) domain.OrderSort {
+	if sort == pbv2.OrderSort_ORDER_SORT_CREATED_AT_ASC {
+		return domain.OrderSortCreatedAtAsc
+	}
+	return domain.OrderSortCreatedAtDesc
+}
@@ -0,0 +1,130 @@
+package adaptor
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	pbv2 "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+)
+
+func TestV2ListOrdersLoadsFillsInOneQuery(t *testing.T) {
+	metrics := NewMetrics()
+	dbPath := filepath.Join(t.TempDir(), "orders.db")
+	repo, err := NewSQLiteRepository(dbPath, WithSQLiteMetrics(metrics, "default"))
+	if err != nil {
+		t.Fatalf("failed to open order repository: %v", err)
+	}
+	trades, err := NewSQLiteTradeRepository(dbPath, WithSQLiteMetrics(metrics, "default"))
+	if err != nil {
+		t.Fatalf("failed to open trade repository: %v", err)
+	}
+
+	engine := service.NewMatchingEngine(repo, nil)
+	orders := service.NewStockOrderService(repo,
+		service.WithMatchingEngine(engine),
+		service.WithTradeRepository(trades),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		engine.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		trades.Close()
+		repo.Close()
+	})
+
+	caller := domain.ContextWithAccountID(context.Background(), testAccount)
+	ids := []string{}
+	for _, side := range []domain.OrderSide{domain.OrderSideBuy, domain.OrderSideSell, domain.OrderSideBuy} {
+		order, err := orders.CreateOrder(caller, domain.CreateOrderRequest{
+			Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: side, Quantity: 10, Price: 100,
+		})
+		if err != nil {
+			t.Fatalf("failed to create %s order: %v", side, err)
+		}
+		ids = append(ids, order.ID)
+	}
+
+	// The first two orders cross once the matching engine has run
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		order, err := orders.GetOrder(caller, ids[1])
+		if err != nil {
+			t.Fatalf("failed to get order: %v", err)
+		}
+		if order.Status == domain.OrderStatusFilled {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("orders did not cross, sell order is %s", order.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	resp, err := NewGRPCHandlerV2(orders).ListOrders(caller, &pbv2.ListOrdersRequest{})
+	if err != nil {
+		t.Fatalf("ListOrders failed: %v", err)
+	}
+	fills := map[string]int{}
+	for _, order := range resp.Orders {
+		fills[order.Id] = len(order.Fills)
+	}
+	if want := map[string]int{ids[0]: 1, ids[1]: 1, ids[2]: 0}; !maps.Equal(fills, want) {
+		t.Errorf("got fills per order %v, want %v", fills, want)
+	}
+
+	exposition := scrapeMetrics(t, metrics)
+	if want := `stockorder_sqlite_query_duration_seconds_count{database="default",operation="list_by_order_ids",repository="trades"} 1`; !strings.Contains(exposition, want) {
+		t.Errorf("metrics are missing %s", want)
+	}
+	if strings.Contains(exposition, `operation="list_by_order_id"`) {
+		t.Error("fills were loaded order by order")
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"101.25", 101.25, false},
+		{"0.000123", 0.000123, false},
+		{"007.50", 7.5, false},
+		{"123456789012.345", 123456789012.345, false},
+		{"100.123456789012000", 100.123456789012, false},
+		{"100.123456789012345678", 0, true},
+		{"1234567890123456", 0, true},
+		{"1e3", 0, true},
+		{"-1", 0, true},
+		{".5", 0, true},
+		{"5.", 0, true},
+		{"NaN", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDecimal("price", tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrValidation) {
+					t.Errorf("error = %v, want a validation error", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package adaptor
+
+import (
+	"context"
+	"io"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sessionCommandBuffer bounds the commands read ahead of processing. Once it
+// is full the session stops receiving and HTTP/2 flow control pushes back on
+// the client.
+const sessionCommandBuffer = 64
+
+// orderSession is the state of one OrderSession stream. It is only touched by
+// the session loop, so commands and executions are handled one at a time and
+// responses are sent in the order they happen.
+type orderSession struct {
+	handler  *GRPCHandler
+	ctx      context.Context
+	stream   pb.StockOrderService_OrderSessionServer
+	sequence uint64
+
+	clientOrders map[string]string        // client order ID -> order ID
+	orders       map[string]*sessionOrder // open orders by order ID
+}
+
+// sessionOrder is the last state of an order reported to the client
+type sessionOrder struct {
+	clientOrderID string
+	status        domain.OrderStatus
+	filled        int
+	averagePrice  float64
+}
+
+// OrderSession accepts create, cancel and amend commands on one stream. Every
+// command is answered with an ack or a reject before the next command is
+// processed, and executions of the session's orders are sent as they happen.
+// The session ends when the client closes its side of the stream.
+func (h *GRPCHandler) OrderSession(stream pb.StockOrderService_OrderSessionServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// Subscribe before accepting commands so no execution is missed
+	sub, err := h.service.WatchOrders(ctx, domain.OrderEventFilter{})
+	if err != nil {
+		return grpcError(err, "failed to start order session")
+	}
+
+	commands := make(chan *pb.OrderSessionRequest, sessionCommandBuffer)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(commands)
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					recvErr <- err
+				}
+				return
+			}
+			select {
+			case commands <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	session := &orderSession{
+		handler:      h,
+		ctx:          ctx,
+		stream:       stream,
+		clientOrders: map[string]string{},
+		orders:       map[string]*sessionOrder{},
+	}
+	return session.run(commands, recvErr, sub)
+}
+
+func (s *orderSession) run(commands <-chan *pb.OrderSessionRequest, recvErr <-chan error, sub port.OrderEventSubscription) error {
+	events := sub.Events()
+	for {
+		select {
+		case req, ok := <-commands:
+			if !ok {
+				select {
+				case err := <-recvErr:
+					return err
+				default:
+					// The client closed its side after all commands were answered
+					return nil
+				}
+			}
+			if err := s.handle(req); err != nil {
+				return err
+			}
+		case event, ok := <-events:
+			if !ok {
+				if err := sub.Err(); err != nil {
+					return status.Errorf(codes.Aborted, "order session ended: %v", err)
+				}
+				if err := s.ctx.Err(); err != nil {
+					return status.FromContextError(err).Err()
+				}
+				// The event hub was closed for graceful shutdown
+				return nil
+			}
+			if err := s.report(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *orderSession) handle(req *pb.OrderSessionRequest) error {
+	if req.ClientOrderId == "" {
+		return s.reject(req.ClientOrderId, codes.InvalidArgument, "client_order_id is required")
+	}
+
+	switch command := req.Command.(type) {
+	case *pb.OrderSessionRequest_Create:
+		return s.create(req.ClientOrderId, command.Create)
+	case *pb.OrderSessionRequest_Cancel:
+		return s.cancel(req.ClientOrderId, command.Cancel)
+	case *pb.OrderSessionRequest_Amend:
+		return s.amend(req.ClientOrderId, command.Amend)
+	default:
+		return s.reject(req.ClientOrderId, codes.InvalidArgument, "command is required")
+	}
+}
+
+// create submits a new order. The client order ID is stored with the order,
+// so resending a create after reconnecting acks the original order.
+func (s *orderSession) create(clientOrderID string, req *pb.CreateOrderRequest) error {
+	if _, err := resolveClientOrderID(req.ClientOrderId, clientOrderID); err != nil {
+		return s.rejectError(clientOrderID, err, "invalid request")
+	}
+
+	order, err := s.handler.service.CreateOrder(s.ctx, domain.CreateOrderRequest{
+		ClientOrderID: clientOrderID,
+		Symbol:        req.Symbol,
+		OrderType:     convertProtoOrderTypeToDomain(req.OrderType),
+		OrderSide:     convertProtoOrderSideToDomain(req.OrderSide),
+		Quantity:      int(req.Quantity),
+		Price:         req.Price,
+	})
+	if err != nil {
+		return s.rejectError(clientOrderID, err, "failed to create order")
+	}
+
+	s.clientOrders[clientOrderID] = order.ID
+	// The ack already reflects executions against the book at entry
+	s.track(clientOrderID, order)
+	return s.ack(clientOrderID, order)
+}
+
+func (s *orderSession) cancel(clientOrderID string, req *pb.SessionCancelOrder) error {
+	orderID, err := s.target(req.OrderId, req.OrigClientOrderId)
+	if err != nil {
+		return s.reject(clientOrderID, status.Code(err), status.Convert(err).Message())
+	}
+
+	if err := s.handler.service.CancelOrder(s.ctx, orderID); err != nil {
+		return s.rejectError(clientOrderID, err, "failed to cancel order")
+	}
+
+	order, err := s.handler.service.GetOrder(s.ctx, orderID)
+	if err != nil {
+		return s.rejectError(clientOrderID, err, "failed to load cancelled order")
+	}
+	s.track(order.ClientOrderID, order)
+	return s.ack(clientOrderID, order)
+}
+
+func (s *orderSession) amend(clientOrderID string, req *pb.SessionAmendOrder) error {
+	orderID, err := s.target(req.OrderId, req.OrigClientOrderId)
+	if err != nil {
+		return s.reject(clientOrderID, status.Code(err), status.Convert(err).Message())
+	}
+
+	order, err := s.handler.service.AmendOrder(s.ctx, orderID, domain.AmendOrderRequest{
+		Quantity: int(req.Quantity),
+		Price:    req.Price,
+	})
+	if err != nil {
+		return s.rejectError(clientOrderID, err, "failed to amend order")
+	}
+
+	s.track(order.ClientOrderID, order)
+	return s.ack(clientOrderID, order)
+}
+
+// target resolves the order a cancel or amend refers to. Orders named by ID
+// must belong to the session's account.
+func (s *orderSession) target(orderID, origClientOrderID string) (string, error) {
+	if origClientOrderID != "" {
+		id, ok := s.clientOrders[origClientOrderID]
+		if !ok {
+			return "", status.Errorf(codes.NotFound, "unknown client_order_id: %s", origClientOrderID)
+		}
+		return id, nil
+	}
+	if orderID == "" {
+		return "", status.Error(codes.InvalidArgument, "order_id or orig_client_order_id is required")
+	}
+
+	order, err := s.handler.service.GetOrder(s.ctx, orderID)
+	if err == nil && order.AccountID != domain.AccountIDFromContext(s.ctx) {
+		err = domain.NewNotFoundError("order", orderID)
+	}
+	if err != nil {
+		return "", grpcError(err, "failed to resolve order")
+	}
+	return order.ID, nil
+}
+
+// track records the state reported to the client; events that do not go
+// beyond it are not reported again
+func (s *orderSession) track(clientOrderID string, order *domain.StockOrder) {
+	if !order.IsOpen() {
+		delete(s.orders, order.ID)
+		return
+	}
+	s.orders[order.ID] = &sessionOrder{
+		clientOrderID: clientOrderID,
+		status:        order.Status,
+		filled:        order.FilledQuantity,
+		averagePrice:  order.AveragePrice,
+	}
+}
+
+// report sends an execution for fills and for status changes of session
+// orders that the client did not ask for, such as a market order remainder
+// being cancelled
+func (s *orderSession) report(event domain.OrderEvent) error {
+	order := event.Order
+	known, ok := s.orders[order.ID]
+	if !ok {
+		return nil
+	}
+
+	lastQuantity := order.FilledQuantity - known.filled
+	closed := order.Status != known.status && !order.IsOpen()
+	if lastQuantity <= 0 && !closed {
+		return nil
+	}
+
+	execution := &pb.OrderExecution{Order: convertDomainOrderToProto(&order)}
+	if lastQuantity > 0 {
+		notional := order.AveragePrice*float64(order.FilledQuantity) - known.averagePrice*float64(known.filled)
+		execution.LastQuantity = int32(lastQuantity)
+		execution.LastPrice = notional / float64(lastQuantity)
+	}
+
+	s.track(known.clientOrderID, &order)
+	return s.send(&pb.OrderSessionResponse{
+		ClientOrderId: known.clientOrderID,
+		Event:         &pb.OrderSessionResponse_Execution{Execution: execution},
+	})
+}
+
+func (s *orderSession) ack(clientOrderID string, order *domain.StockOrder) error {
+	return s.send(&pb.OrderSessionResponse{
+		ClientOrderId: clientOrderID,
+		Event: &pb.OrderSessionResponse_Ack{Ack: &pb.OrderAck{
+			Order: convertDomainOrderToProto(order),
+		}},
+	})
+}
+
+func (s *orderSession) reject(clientOrderID string, code codes.Code, reason string) error {
+	return s.send(&pb.OrderSessionResponse{
+		ClientOrderId: clientOrderID,
+		Event: &pb.OrderSessionResponse_Reject{Reject: &pb.OrderReject{
+			Reason: reason,
+			Code:   int32(code),
+		}},
+	})
+}
+
+// rejectError rejects a command with the status code of a service error
+func (s *orderSession) rejectError(clientOrderID string, err error, message string) error {
+	st := status.Convert(grpcError(err, message))
+	return s.reject(clientOrderID, st.Code(), st.Message())
+}
+
+// send blocks while the client is not reading; if that lasts long enough for
+// the order event subscription to fall behind, the session is aborted
+func (s *orderSession) send(resp *pb.OrderSessionResponse) error {
+	s.sequence++
+	resp.Sequence = s.sequence
+	return s.stream.Send(resp)
+}
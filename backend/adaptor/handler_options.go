@@ -0,0 +1,58 @@
+package adaptor
+
+import (
+	"strings"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// handlerDeps holds the optional services shared by the HTTP and gRPC handlers
+type handlerDeps struct {
+	marketData     port.MarketDataService
+	allowedOrigins map[string]bool
+}
+
+// HandlerOption configures optional services of the HTTP and gRPC handlers
+type HandlerOption func(*handlerDeps)
+
+// WithMarketDataService enables the quote and candle endpoints
+func WithMarketDataService(marketData port.MarketDataService) HandlerOption {
+	return func(d *handlerDeps) {
+		d.marketData = marketData
+	}
+}
+
+// WithAllowedOrigins lets pages served from origins, such as
+// "https://dashboard.example.com", open WebSockets in addition to pages of
+// the server's own host. "*" allows every origin.
+func WithAllowedOrigins(origins ...string) HandlerOption {
+	return func(d *handlerDeps) {
+		if d.allowedOrigins == nil {
+			d.allowedOrigins = map[string]bool{}
+		}
+		for _, origin := range origins {
+			d.allowedOrigins[strings.TrimSuffix(strings.ToLower(origin), "/")] = true
+		}
+	}
+}
+
+func newHandlerDeps(opts []HandlerOption) handlerDeps {
+	deps := handlerDeps{}
+	for _, opt := range opts {
+		opt(&deps)
+	}
+	return deps
+}
+
+// resolveClientOrderID combines the client order ID of a request body with an
+// idempotency key sent as a header or metadata; either may be used alone
+func resolveClientOrderID(clientOrderID, idempotencyKey string) (string, error) {
+	if clientOrderID != "" && idempotencyKey != "" && clientOrderID != idempotencyKey {
+		return "", domain.NewValidationError("client_order_id", "client_order_id %q does not match idempotency key %q", clientOrderID, idempotencyKey)
+	}
+	if clientOrderID != "" {
+		return clientOrderID, nil
+	}
+	return idempotencyKey, nil
+}
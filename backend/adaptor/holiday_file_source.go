@@ -0,0 +1,78 @@
+package adaptor
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+type fileHolidaySource struct {
+	path string
+}
+
+// NewFileHolidaySource reads holidays from a CSV file with the columns
+// date (YYYY-MM-DD), market, an optional name and an optional early close
+// (HH:MM) that makes the date a half day. Lines starting with '#' are
+// ignored and a market of "*" applies the holiday to every market.
+func NewFileHolidaySource(path string) port.HolidaySource {
+	return &fileHolidaySource{path: path}
+}
+
+func (s *fileHolidaySource) LoadHolidays(ctx context.Context) ([]domain.Holiday, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open holiday file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	holidays := []domain.Holiday{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read holiday file: %w", err)
+		}
+		if len(record) < 2 {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("holiday file line %d: expected date and market", line)
+		}
+
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("holiday file line %d: invalid date: %w", line, err)
+		}
+
+		holiday := domain.Holiday{
+			Market: strings.TrimSpace(record[1]),
+			Date:   date,
+		}
+		if len(record) > 2 {
+			holiday.Name = strings.TrimSpace(record[2])
+		}
+		if len(record) > 3 {
+			holiday.EarlyClose = strings.TrimSpace(record[3])
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	return holidays, nil
+}
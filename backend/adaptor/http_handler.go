@@ -2,49 +2,105 @@ package adaptor
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
 )
 
+// MaxRequestBytes bounds request bodies and gRPC messages; a batch of the
+// largest allowed size fits well within it
+const MaxRequestBytes = 1 << 20
+
 type HTTPHandler struct {
+	handlerDeps
 	service port.StockOrderService
+	streams *streamRegistry
 }
 
-func NewHTTPHandler(service port.StockOrderService) *HTTPHandler {
+func NewHTTPHandler(service port.StockOrderService, opts ...HandlerOption) *HTTPHandler {
 	return &HTTPHandler{
-		service: service,
+		handlerDeps: newHandlerDeps(opts),
+		service:     service,
+		streams:     newStreamRegistry(),
 	}
 }
 
+// ErrorResponse describes a failed request. Code is the kind of error, such
+// as VALIDATION or NOT_FOUND; Field and Resource are set when known, and
+// Violations lists every invalid field of a request that failed validation.
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error      string                  `json:"error"`
+	Code       string                  `json:"code"`
+	Field      string                  `json:"field,omitempty"`
+	Resource   string                  `json:"resource,omitempty"`
+	Violations []domain.FieldViolation `json:"violations,omitempty"`
 }
 
 type SuccessResponse struct {
 	Message string `json:"message"`
 }
 
+type BatchCreateOrdersResponse struct {
+	Committed bool                      `json:"committed"`
+	Results   []domain.BatchOrderResult `json:"results"`
+}
+
+type MassCancelResponse struct {
+	Cancelled int                   `json:"cancelled"`
+	Results   []domain.CancelResult `json:"results"`
+}
+
 func (h *HTTPHandler) RegisterRoutes(router *mux.Router) {
+	router.Use(accountMiddleware)
+
 	router.HandleFunc("/api/orders", h.CreateOrder).Methods("POST")
 	router.HandleFunc("/api/orders", h.ListOrders).Methods("GET")
+	router.HandleFunc("/api/orders/batch", h.BatchCreateOrders).Methods("POST")
+	router.HandleFunc("/api/orders/cancel-all", h.MassCancel).Methods("POST")
 	router.HandleFunc("/api/orders/{id}", h.GetOrder).Methods("GET")
 	router.HandleFunc("/api/orders/{id}/cancel", h.CancelOrder).Methods("POST")
+	router.HandleFunc("/api/symbols/{symbol}/session", h.GetMarketSession).Methods("GET")
+	router.HandleFunc("/api/symbols/{symbol}/auction", h.GetAuctionState).Methods("GET")
+	router.HandleFunc("/api/symbols/{symbol}/quote", h.GetQuote).Methods("GET")
+	router.HandleFunc("/api/symbols/{symbol}/candles", h.GetCandles).Methods("GET")
+	router.HandleFunc("/api/instruments", h.ListInstruments).Methods("GET")
+	router.HandleFunc("/api/admin/instruments/{symbol}", h.UpsertInstrument).Methods("PUT")
+	router.HandleFunc("/api/admin/instruments/{symbol}", h.DeleteInstrument).Methods("DELETE")
+	router.HandleFunc("/api/admin/symbols/{symbol}/halt", h.HaltSymbol).Methods("POST")
+	router.HandleFunc("/api/admin/symbols/{symbol}/resume", h.ResumeSymbol).Methods("POST")
+	router.HandleFunc("/api/stream/orders", h.StreamOrders).Methods("GET")
+	router.HandleFunc("/ws", h.ServeWebSocket).Methods("GET")
 	router.HandleFunc("/health", h.HealthCheck).Methods("GET")
+	router.HandleFunc("/openapi.json", h.OpenAPISpec).Methods("GET")
+	router.HandleFunc("/docs", h.APIDocs).Methods("GET")
+	router.HandleFunc("/grpc/descriptor-set", h.DescriptorSet).Methods("GET")
 }
 
 func (h *HTTPHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var req domain.CreateOrderRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "invalid request body")
+	if err := decodeJSON(w, r, &req, false); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	clientOrderID, err := resolveClientOrderID(req.ClientOrderID, r.Header.Get("Idempotency-Key"))
+	if err != nil {
+		respondError(w, err)
 		return
 	}
+	req.ClientOrderID = clientOrderID
 
 	order, err := h.service.CreateOrder(r.Context(), req)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondError(w, err)
 		return
 	}
 
@@ -57,7 +113,7 @@ func (h *HTTPHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 
 	order, err := h.service.GetOrder(r.Context(), orderID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, err.Error())
+		respondError(w, err)
 		return
 	}
 
@@ -65,13 +121,19 @@ func (h *HTTPHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *HTTPHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
-	orders, err := h.service.ListOrders(r.Context())
+	query, err := parseOrderQuery(r)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	page, err := h.service.ListOrders(r.Context(), query)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, orders)
+	respondJSON(w, http.StatusOK, page)
 }
 
 func (h *HTTPHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
@@ -79,23 +141,314 @@ func (h *HTTPHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
 	orderID := vars["id"]
 
 	if err := h.service.CancelOrder(r.Context(), orderID); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondError(w, err)
 		return
 	}
 
 	respondJSON(w, http.StatusOK, SuccessResponse{Message: "order cancelled successfully"})
 }
 
+// BatchCreateOrders reports a result per order. Atomic batches that were
+// rolled back are answered with 422 and the reason of every failed order.
+func (h *HTTPHandler) BatchCreateOrders(w http.ResponseWriter, r *http.Request) {
+	var req domain.BatchCreateOrdersRequest
+	if err := decodeJSON(w, r, &req, false); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	results, err := h.service.BatchCreateOrders(r.Context(), req)
+	if errors.Is(err, domain.ErrBatchRolledBack) {
+		respondJSON(w, http.StatusUnprocessableEntity, BatchCreateOrdersResponse{Results: results})
+		return
+	}
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, BatchCreateOrdersResponse{Committed: true, Results: results})
+}
+
+// MassCancel cancels the caller's open orders, optionally only those of one
+// symbol or side. The request body may be omitted.
+func (h *HTTPHandler) MassCancel(w http.ResponseWriter, r *http.Request) {
+	var req domain.MassCancelRequest
+	if err := decodeJSON(w, r, &req, true); err != nil {
+		respondError(w, err)
+		return
+	}
+	req.OrderSide = domain.OrderSide(strings.ToUpper(string(req.OrderSide)))
+
+	results, err := h.service.MassCancel(r.Context(), req)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	response := MassCancelResponse{Results: results}
+	for _, result := range results {
+		if result.Cancelled {
+			response.Cancelled++
+		}
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (h *HTTPHandler) GetMarketSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	symbol := vars["symbol"]
+
+	session, err := h.service.GetMarketSession(r.Context(), symbol)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, session)
+}
+
+func (h *HTTPHandler) GetAuctionState(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	symbol := vars["symbol"]
+
+	state, err := h.service.GetAuctionState(r.Context(), symbol)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, state)
+}
+
+func (h *HTTPHandler) GetQuote(w http.ResponseWriter, r *http.Request) {
+	if h.marketData == nil {
+		respondError(w, domain.NewUnavailableError(nil, "market data is not configured"))
+		return
+	}
+
+	vars := mux.Vars(r)
+	symbol := vars["symbol"]
+
+	quote, err := h.marketData.GetQuote(r.Context(), symbol)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, quote)
+}
+
+func (h *HTTPHandler) GetCandles(w http.ResponseWriter, r *http.Request) {
+	if h.marketData == nil {
+		respondError(w, domain.NewUnavailableError(nil, "market data is not configured"))
+		return
+	}
+
+	vars := mux.Vars(r)
+	query, err := parseCandleQuery(vars["symbol"], r)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	candles, err := h.marketData.GetCandles(r.Context(), query)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, candles)
+}
+
+func (h *HTTPHandler) ListInstruments(w http.ResponseWriter, r *http.Request) {
+	instruments, err := h.service.ListInstruments(r.Context())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, instruments)
+}
+
+func (h *HTTPHandler) UpsertInstrument(w http.ResponseWriter, r *http.Request) {
+	var req domain.UpsertInstrumentRequest
+	if err := decodeJSON(w, r, &req, false); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	instrument, err := h.service.UpsertInstrument(r.Context(), mux.Vars(r)["symbol"], req)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, instrument)
+}
+
+func (h *HTTPHandler) DeleteInstrument(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteInstrument(r.Context(), mux.Vars(r)["symbol"]); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, SuccessResponse{Message: "instrument deleted successfully"})
+}
+
+func (h *HTTPHandler) HaltSymbol(w http.ResponseWriter, r *http.Request) {
+	var req domain.HaltSymbolRequest
+	if err := decodeJSON(w, r, &req, true); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	instrument, err := h.service.HaltSymbol(r.Context(), mux.Vars(r)["symbol"], req)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, instrument)
+}
+
+func (h *HTTPHandler) ResumeSymbol(w http.ResponseWriter, r *http.Request) {
+	instrument, err := h.service.ResumeSymbol(r.Context(), mux.Vars(r)["symbol"])
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, instrument)
+}
+
+// parseOrderQuery reads the filter, sort and paging query parameters
+func parseOrderQuery(r *http.Request) (domain.OrderQuery, error) {
+	params := r.URL.Query()
+	query := domain.OrderQuery{
+		Symbol:    params.Get("symbol"),
+		OrderSide: domain.OrderSide(strings.ToUpper(params.Get("side"))),
+		OrderType: domain.OrderType(strings.ToUpper(params.Get("type"))),
+		Status:    domain.OrderStatus(strings.ToUpper(params.Get("status"))),
+		Sort:      domain.OrderSort(params.Get("sort")),
+		PageToken: params.Get("page_token"),
+	}
+
+	var err error
+	if value := params.Get("created_from"); value != "" {
+		if query.CreatedFrom, err = time.Parse(time.RFC3339, value); err != nil {
+			return query, domain.NewValidationError("created_from", "invalid created_from: %v", err)
+		}
+	}
+	if value := params.Get("created_to"); value != "" {
+		if query.CreatedTo, err = time.Parse(time.RFC3339, value); err != nil {
+			return query, domain.NewValidationError("created_to", "invalid created_to: %v", err)
+		}
+	}
+	if value := params.Get("page_size"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil {
+			return query, domain.NewValidationError("page_size", "invalid page_size: %v", err)
+		}
+	}
+
+	return query, nil
+}
+
+// parseCandleQuery reads the interval, from, to (RFC 3339) and limit query parameters
+func parseCandleQuery(symbol string, r *http.Request) (domain.CandleQuery, error) {
+	params := r.URL.Query()
+	query := domain.CandleQuery{
+		Symbol:   symbol,
+		Interval: domain.CandleInterval(params.Get("interval")),
+	}
+
+	var err error
+	if value := params.Get("from"); value != "" {
+		if query.From, err = time.Parse(time.RFC3339, value); err != nil {
+			return query, domain.NewValidationError("from", "invalid from: %v", err)
+		}
+	}
+	if value := params.Get("to"); value != "" {
+		if query.To, err = time.Parse(time.RFC3339, value); err != nil {
+			return query, domain.NewValidationError("to", "invalid to: %v", err)
+		}
+	}
+	if value := params.Get("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil {
+			return query, domain.NewValidationError("limit", "invalid limit: %v", err)
+		}
+	}
+
+	return query, nil
+}
+
 func (h *HTTPHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, map[string]string{"status": "healthy"})
 }
 
+// accountMiddleware attaches the account named in the X-Account-ID header.
+// Authenticated callers always act for their own account.
+func accountMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if domain.PrincipalFromContext(r.Context()) != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if accountID := r.Header.Get("X-Account-ID"); accountID != "" {
+			r = r.WithContext(domain.ContextWithAccountID(r.Context(), accountID))
+			setRequestCaller(r.Context())
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// decodeJSON decodes a request body holding a single JSON value into v. Unknown
+// fields, trailing data and bodies over MaxRequestBytes are rejected; an
+// empty body is only accepted when allowEmpty is set.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any, allowEmpty bool) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		if err == io.EOF && allowEmpty {
+			return nil
+		}
+		return requestBodyError(err)
+	}
+	if err := decoder.Decode(&json.RawMessage{}); err != io.EOF {
+		if err != nil {
+			return requestBodyError(err)
+		}
+		return domain.NewValidationError("", "request body must contain a single JSON value")
+	}
+	return nil
+}
+
+// requestBodyError describes why a request body could not be decoded
+func requestBodyError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return fmt.Errorf("request body exceeds %d bytes: %w", maxBytesErr.Limit, err)
+	case errors.As(err, &typeErr):
+		return domain.NewValidationError(typeErr.Field, "%s cannot be a JSON %s", typeErr.Field, typeErr.Value)
+	case err == io.EOF:
+		return domain.NewValidationError("", "request body is required")
+	}
+
+	// encoding/json has no error type for unknown fields
+	if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if unquoted, unquoteErr := strconv.Unquote(name); unquoteErr == nil {
+			name = unquoted
+		}
+		return domain.NewValidationError(name, "unknown field %q", name)
+	}
+	return domain.NewValidationError("", "invalid request body: %v", err)
+}
+
 func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(payload)
 }
-
-func respondError(w http.ResponseWriter, status int, message string) {
-	respondJSON(w, status, ErrorResponse{Error: message})
-}
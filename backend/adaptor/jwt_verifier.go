@@ -0,0 +1,139 @@
+package adaptor
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// jwtLeeway tolerates clock skew between the token issuer and this service
+const jwtLeeway = 30 * time.Second
+
+// JWTOptions configures the keys and claims accepted by the JWT verifier.
+// HMACSecret accepts HS256/384/512 tokens; PublicKeys accepts RSA, ECDSA and
+// Ed25519 signed tokens by their kid header, with "" used for tokens without
+// a kid. Issuer and Audience are checked when set.
+type JWTOptions struct {
+	HMACSecret []byte
+	PublicKeys map[string]crypto.PublicKey
+	Issuer     string
+	Audience   string
+}
+
+// jwtClaims are the claims read from a bearer token. The account defaults to
+// the subject and the tenant to the default tenant.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	AccountID string   `json:"account_id,omitempty"`
+	TenantID  string   `json:"tenant_id,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+type jwtVerifier struct {
+	opts   JWTOptions
+	parser *jwt.Parser
+}
+
+func NewJWTVerifier(opts JWTOptions) (port.TokenVerifier, error) {
+	methods := []string{}
+	if len(opts.HMACSecret) > 0 {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	for kid, key := range opts.PublicKeys {
+		switch key.(type) {
+		case *rsa.PublicKey:
+			methods = append(methods, "RS256", "RS384", "RS512", "PS256", "PS384", "PS512")
+		case *ecdsa.PublicKey:
+			methods = append(methods, "ES256", "ES384", "ES512")
+		case ed25519.PublicKey:
+			methods = append(methods, "EdDSA")
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T for kid %q", key, kid)
+		}
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no JWT verification keys configured")
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
+	return &jwtVerifier{opts: opts, parser: jwt.NewParser(parserOpts...)}, nil
+}
+
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	claims := &jwtClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, domain.NewUnauthenticatedError("invalid bearer token: %v", err)
+	}
+	if claims.Subject == "" {
+		return nil, domain.NewUnauthenticatedError("invalid bearer token: missing subject")
+	}
+
+	accountID := claims.AccountID
+	if accountID == "" {
+		accountID = claims.Subject
+	}
+	return &domain.Principal{
+		Subject:   claims.Subject,
+		AccountID: accountID,
+		TenantID:  claims.TenantID,
+		Roles:     claims.Roles,
+		Method:    domain.AuthMethodJWT,
+	}, nil
+}
+
+// key selects the verification key for a token by its algorithm and kid
+func (v *jwtVerifier) key(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		return v.opts.HMACSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := v.opts.PublicKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return key, nil
+}
+
+// LoadPublicKey reads a PEM encoded PKIX public key, as written by
+// openssl pkey -pubout
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", path)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,40 @@
+package adaptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+)
+
+// panickingStockOrderService fails every order it is given with a panic
+type panickingStockOrderService struct {
+	port.StockOrderService
+}
+
+func (panickingStockOrderService) CreateOrder(context.Context, domain.CreateOrderRequest) (*domain.StockOrder, error) {
+	panic("order entry failed")
+}
+
+func TestLoadSheddingReleasesSlotOnPanic(t *testing.T) {
+	limiter := NewAIMDLimiter(AIMDLimiterOptions{InitialLimit: 1, MaxLimit: 1, QueueTimeout: 10 * time.Millisecond})
+	orders := service.NewLoadSheddingStockOrderService(panickingStockOrderService{}, limiter)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("CreateOrder did not panic")
+			}
+		}()
+		orders.CreateOrder(context.Background(), domain.CreateOrderRequest{})
+	}()
+
+	release, err := limiter.Acquire(context.Background(), domain.PriorityHigh)
+	if err != nil {
+		t.Fatalf("slot of the panicked operation was not released: %v", err)
+	}
+	release(nil)
+}
@@ -0,0 +1,242 @@
+package adaptor
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader carries the request ID on HTTP requests and responses; gRPC
+// uses the x-request-id metadata
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the request IDs accepted from callers
+const maxRequestIDLength = 128
+
+// contextLogHandler adds the request ID, tenant, account, order ID and method
+// of the context to every record, so that the log lines of a request can be
+// correlated from the transport down to the repository
+type contextLogHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler returns a handler writing JSON records of at least level to
+// w, with the request context of each record
+func NewLogHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	return &contextLogHandler{Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})}
+}
+
+func (h *contextLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	// Attributes logged explicitly take precedence over the context
+	present := map[string]bool{}
+	record.Attrs(func(attr slog.Attr) bool {
+		present[attr.Key] = true
+		return true
+	})
+	add := func(key, value string) {
+		if value != "" && !present[key] {
+			record.AddAttrs(slog.String(key, value))
+		}
+	}
+
+	if requestID := domain.RequestIDFromContext(ctx); requestID != "" {
+		add("request_id", requestID)
+		add("method", domain.MethodFromContext(ctx))
+		add("tenant_id", domain.TenantIDFromContext(ctx))
+		add("account_id", domain.AccountIDFromContext(ctx))
+	}
+	add("order_id", domain.OrderIDFromContext(ctx))
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextLogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextLogHandler) WithGroup(name string) slog.Handler {
+	return &contextLogHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+type requestCallerKey struct{}
+
+// requestCaller lets the authentication and account layers name the tenant
+// and account of a request after LoggingMiddleware or the logging
+// interceptors have passed it on, so that its completion is logged with them
+type requestCaller struct {
+	tenantID  string
+	accountID string
+}
+
+// withRequestCaller adds an empty caller to ctx for setRequestCaller to fill
+func withRequestCaller(ctx context.Context) (context.Context, *requestCaller) {
+	caller := &requestCaller{}
+	return context.WithValue(ctx, requestCallerKey{}, caller), caller
+}
+
+// setRequestCaller records the tenant and account of ctx for the completion
+// log line of its request
+func setRequestCaller(ctx context.Context) {
+	if caller, ok := ctx.Value(requestCallerKey{}).(*requestCaller); ok {
+		caller.tenantID = domain.TenantIDFromContext(ctx)
+		caller.accountID = domain.AccountIDFromContext(ctx)
+	}
+}
+
+// completed returns ctx with the tenant and account recorded for its request
+func (c *requestCaller) completed(ctx context.Context) context.Context {
+	if c.tenantID != "" {
+		ctx = domain.ContextWithTenantID(ctx, c.tenantID)
+	}
+	if c.accountID != "" {
+		ctx = domain.ContextWithAccountID(ctx, c.accountID)
+	}
+	return ctx
+}
+
+// requestID returns the caller's request ID, or a new one when the caller
+// sent none or one that is too long to log
+func requestID(sent string) string {
+	if sent == "" || len(sent) > maxRequestIDLength {
+		return uuid.New().String()
+	}
+	return sent
+}
+
+// LoggingMiddleware assigns every HTTP request an ID, taken from the
+// X-Request-ID header when the caller sends one, echoes it in the response
+// and logs the request once it completes, with the tenant and account the
+// inner layers established
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := requestID(r.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, id)
+
+		// Prefix routes such as the REST gateway are logged by path
+		method := r.Method + " " + r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil && !strings.HasSuffix(template, "/") {
+				method = r.Method + " " + template
+			}
+		}
+		ctx := domain.ContextWithMethod(domain.ContextWithRequestID(r.Context(), id), method)
+		ctx = domain.ContextWithClientAddress(ctx, hostOf(r.RemoteAddr))
+		ctx, caller := withRequestCaller(ctx)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		slog.InfoContext(caller.completed(ctx), "HTTP request completed",
+			"component", "HTTP",
+			"path", r.URL.Path,
+			"status", recorder.status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"remote_addr", r.RemoteAddr,
+		)
+	})
+}
+
+// statusRecorder remembers the response status. It keeps the flushing and
+// hijacking of the wrapped writer available to streams and WebSockets.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *statusRecorder) Flush() {
+	http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(r.ResponseWriter).Hijack()
+	if err == nil {
+		// Upgraded connections answer with 101 Switching Protocols
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// LoggingUnaryInterceptor assigns every unary gRPC call an ID, taken from the
+// x-request-id metadata when the caller sends one, returns it in the response
+// header and logs the call once it completes
+func LoggingUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	ctx = grpcRequestContext(ctx, info.FullMethod)
+	ctx, caller := withRequestCaller(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", domain.RequestIDFromContext(ctx)))
+
+	resp, err := handler(ctx, req)
+	logGRPCCall(caller.completed(ctx), start, err)
+	return resp, err
+}
+
+// LoggingStreamInterceptor is LoggingUnaryInterceptor for streaming calls
+func LoggingStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, caller := withRequestCaller(grpcRequestContext(stream.Context(), info.FullMethod))
+	stream.SetHeader(metadata.Pairs("x-request-id", domain.RequestIDFromContext(ctx)))
+
+	err := handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	logGRPCCall(caller.completed(ctx), start, err)
+	return err
+}
+
+func grpcRequestContext(ctx context.Context, fullMethod string) context.Context {
+	id := requestID(metadataValue(ctx, "x-request-id"))
+	ctx = domain.ContextWithMethod(domain.ContextWithRequestID(ctx, id), fullMethod)
+	if p, ok := peer.FromContext(ctx); ok {
+		ctx = domain.ContextWithClientAddress(ctx, hostOf(p.Addr.String()))
+	}
+	return ctx
+}
+
+// hostOf strips the port from a network address
+func hostOf(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
+
+func logGRPCCall(ctx context.Context, start time.Time, err error) {
+	attrs := []any{
+		"component", "gRPC",
+		"code", status.Code(err).String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		attrs = append(attrs, "error", status.Convert(err).Message())
+	}
+	slog.InfoContext(ctx, "gRPC call completed", attrs...)
+}
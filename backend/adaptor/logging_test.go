@@ -0,0 +1,99 @@
+package adaptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// keyAuthService authenticates every API key as an account of desk-a
+type keyAuthService struct {
+	port.AuthService
+}
+
+func (keyAuthService) Authenticate(ctx context.Context, credentials domain.Credentials) (*domain.Principal, error) {
+	if credentials.APIKey == "" {
+		return nil, nil
+	}
+	return &domain.Principal{Subject: "key-1", AccountID: "acct-1", TenantID: "desk-a", Method: domain.AuthMethodAPIKey}, nil
+}
+
+// captureLogs sends the default logger to a buffer for the rest of the test
+// and returns the records logged with msg
+func captureLogs(t *testing.T) func(msg string) []map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(NewLogHandler(&buf, slog.LevelInfo)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return func(msg string) []map[string]any {
+		records := []map[string]any{}
+		decoder := json.NewDecoder(&buf)
+		for decoder.More() {
+			record := map[string]any{}
+			if err := decoder.Decode(&record); err != nil {
+				t.Fatalf("failed to decode log record: %v", err)
+			}
+			if record["msg"] == msg {
+				records = append(records, record)
+			}
+		}
+		return records
+	}
+}
+
+func TestCompletionLogsNameTheAuthenticatedCaller(t *testing.T) {
+	authenticator := NewAuthenticator(keyAuthService{}, false)
+
+	t.Run("HTTP", func(t *testing.T) {
+		logged := captureLogs(t)
+		handler := LoggingMiddleware(authenticator.Middleware(accountMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))))
+
+		for _, header := range []http.Header{{"X-Api-Key": {"sok_test"}}, {"X-Account-Id": {"acct-2"}}} {
+			r := httptest.NewRequest(http.MethodGet, "/api/orders", nil)
+			r.Header = header
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+		}
+
+		records := logged("HTTP request completed")
+		if len(records) != 2 {
+			t.Fatalf("logged %d completions, want 2", len(records))
+		}
+		if records[0]["tenant_id"] != "desk-a" || records[0]["account_id"] != "acct-1" {
+			t.Errorf("API key request logged tenant %v, account %v, want desk-a and acct-1", records[0]["tenant_id"], records[0]["account_id"])
+		}
+		if records[1]["account_id"] != "acct-2" {
+			t.Errorf("anonymous request logged account %v, want acct-2", records[1]["account_id"])
+		}
+	})
+
+	t.Run("gRPC", func(t *testing.T) {
+		logged := captureLogs(t)
+		info := &grpc.UnaryServerInfo{FullMethod: "/stockorder.v1.StockOrderService/ListOrders"}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return authenticator.UnaryInterceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+				return nil, nil
+			})
+		}
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "sok_test"))
+		if _, err := LoggingUnaryInterceptor(ctx, nil, info, handler); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+
+		records := logged("gRPC call completed")
+		if len(records) != 1 || records[0]["tenant_id"] != "desk-a" || records[0]["account_id"] != "acct-1" {
+			t.Errorf("completions = %v, want one naming desk-a and acct-1", records)
+		}
+	})
+}
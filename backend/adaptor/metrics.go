@@ -0,0 +1,314 @@
+package adaptor
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics collects the Prometheus metrics of the service: HTTP requests and
+// gRPC calls, orders created, cancelled and filled, SQLite queries and
+// connection pools, the phases of a graceful shutdown and the Go runtime.
+// Handler serves them in the Prometheus text format.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	grpcCalls    *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+
+	ordersCreated   *prometheus.CounterVec
+	ordersCancelled *prometheus.CounterVec
+	ordersFilled    *prometheus.CounterVec
+
+	queryDuration *prometheus.HistogramVec
+
+	shutdownInProgress *prometheus.GaugeVec
+	shutdownDuration   *prometheus.GaugeVec
+}
+
+func NewMetrics() *Metrics {
+	orderLabels := []string{"tenant", "symbol", "side"}
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests served, by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of HTTP requests, by method, route and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		grpcCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "gRPC calls completed, by method and status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Latency of gRPC calls, by method and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		ordersCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "stockorder_orders_created_total",
+			Help: "Orders accepted, by tenant, symbol and side.",
+		}, orderLabels),
+		ordersCancelled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "stockorder_orders_cancelled_total",
+			Help: "Orders cancelled by their owner or by the matching engine, by tenant, symbol and side.",
+		}, orderLabels),
+		ordersFilled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "stockorder_orders_filled_total",
+			Help: "Orders filled completely, by tenant, symbol and side.",
+		}, orderLabels),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "stockorder_sqlite_query_duration_seconds",
+			Help:    "Latency of SQLite queries, by database, repository and operation.",
+			Buckets: []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"database", "repository", "operation"}),
+		shutdownInProgress: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "stockorder_shutdown_phase_in_progress",
+			Help: "1 while a phase of the graceful shutdown runs.",
+		}, []string{"phase"}),
+		shutdownDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "stockorder_shutdown_phase_duration_seconds",
+			Help: "How long a completed phase of the graceful shutdown took.",
+		}, []string{"phase"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.grpcCalls, m.grpcDuration,
+		m.ordersCreated, m.ordersCancelled, m.ordersFilled,
+		m.queryDuration, m.shutdownInProgress, m.shutdownDuration,
+	)
+	return m
+}
+
+// Handler serves the metrics to Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+type routeLabelKey struct{}
+
+// routeLabel lets GatewayMiddleware name the route of a request after
+// HTTPMiddleware has passed it on
+type routeLabel struct {
+	route string
+}
+
+// HTTPMiddleware counts HTTP requests and records their latency. Requests
+// are labelled by route template rather than path, so that order IDs do not
+// create a series each; REST gateway requests are named by GatewayMiddleware.
+func (m *Metrics) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		label := &routeLabel{route: "unmatched"}
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				label.route = template
+			}
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), routeLabelKey{}, label)))
+
+		labels := prometheus.Labels{"method": methodLabel(r.Method), "route": label.route, "status": strconv.Itoa(recorder.status)}
+		m.httpRequests.With(labels).Inc()
+		m.httpDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+// httpMethods are the methods HTTP requests are labelled with; gateway routes
+// accept any method, so the rest share one label
+var httpMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodOptions: true,
+}
+
+func methodLabel(method string) string {
+	if httpMethods[method] {
+		return method
+	}
+	return "OTHER"
+}
+
+// GatewayMiddleware names REST gateway requests by their route, such as
+// "/api/v1/orders/{order_id}/cancel"
+func (m *Metrics) GatewayMiddleware(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		label, _ := r.Context().Value(routeLabelKey{}).(*routeLabel)
+		if pattern, ok := runtime.HTTPPattern(r.Context()); ok && label != nil {
+			label.route = strings.ReplaceAll(pattern.String(), "=*}", "}")
+		}
+		next(w, r, pathParams)
+	}
+}
+
+// UnaryInterceptor counts unary gRPC calls and records their latency
+func (m *Metrics) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observeGRPCCall(info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamInterceptor counts streaming gRPC calls and records how long they
+// stayed open
+func (m *Metrics) StreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	m.observeGRPCCall(info.FullMethod, start, err)
+	return err
+}
+
+func (m *Metrics) observeGRPCCall(method string, start time.Time, err error) {
+	labels := prometheus.Labels{"method": method, "code": status.Code(err).String()}
+	m.grpcCalls.With(labels).Inc()
+	m.grpcDuration.With(labels).Observe(time.Since(start).Seconds())
+}
+
+// otherSymbol labels the orders of symbols that are not registered instruments
+const otherSymbol = "other"
+
+// OrderMetrics counts the orders of one tenant. Orders are labelled with
+// their symbol only when it is registered in instruments, so that clients
+// cannot create a series per made-up symbol; the rest are labelled "other".
+func (m *Metrics) OrderMetrics(tenantID string, instruments port.InstrumentRepository) port.OrderMetrics {
+	tenant := prometheus.Labels{"tenant": tenantID}
+	return &orderMetrics{
+		instruments: instruments,
+		created:     m.ordersCreated.MustCurryWith(tenant),
+		cancelled:   m.ordersCancelled.MustCurryWith(tenant),
+		filled:      m.ordersFilled.MustCurryWith(tenant),
+	}
+}
+
+type orderMetrics struct {
+	instruments port.InstrumentRepository
+	// registered caches the symbols found in instruments; a series, once
+	// created, outlives its instrument anyway
+	registered sync.Map
+	created    *prometheus.CounterVec
+	cancelled  *prometheus.CounterVec
+	filled     *prometheus.CounterVec
+}
+
+func (o *orderMetrics) OrderCreated(order *domain.StockOrder) {
+	o.created.WithLabelValues(o.symbolLabel(order.Symbol), string(order.OrderSide)).Inc()
+}
+
+func (o *orderMetrics) OrderCancelled(order *domain.StockOrder) {
+	o.cancelled.WithLabelValues(o.symbolLabel(order.Symbol), string(order.OrderSide)).Inc()
+}
+
+func (o *orderMetrics) OrderFilled(order *domain.StockOrder) {
+	o.filled.WithLabelValues(o.symbolLabel(order.Symbol), string(order.OrderSide)).Inc()
+}
+
+// symbolLabel returns symbol when it is a registered instrument and
+// otherSymbol otherwise
+func (o *orderMetrics) symbolLabel(symbol string) string {
+	if _, ok := o.registered.Load(symbol); ok {
+		return symbol
+	}
+	if o.instruments == nil {
+		return otherSymbol
+	}
+
+	instrument, err := o.instruments.Get(context.Background(), symbol)
+	if err != nil || instrument == nil {
+		return otherSymbol
+	}
+	o.registered.Store(symbol, true)
+	return symbol
+}
+
+// ShutdownPhase marks phase of the graceful shutdown as running until the
+// returned function is called, which records and logs how long it took
+func (m *Metrics) ShutdownPhase(phase string) func() {
+	start := time.Now()
+	m.shutdownInProgress.WithLabelValues(phase).Set(1)
+	return func() {
+		duration := time.Since(start)
+		m.shutdownInProgress.WithLabelValues(phase).Set(0)
+		m.shutdownDuration.WithLabelValues(phase).Set(duration.Seconds())
+		slog.Info("Shutdown phase completed", "component", "Shutdown", "phase", phase, "duration_ms", duration.Milliseconds())
+	}
+}
+
+// sqliteOptions holds the optional settings of the SQLite repositories
+type sqliteOptions struct {
+	metrics  *Metrics
+	database string
+}
+
+// SQLiteOption configures a SQLite repository
+type SQLiteOption func(*sqliteOptions)
+
+// WithSQLiteMetrics records the latency of the repository's queries and the
+// statistics of its connection pool, labelled with database
+func WithSQLiteMetrics(metrics *Metrics, database string) SQLiteOption {
+	return func(o *sqliteOptions) {
+		o.metrics = metrics
+		o.database = database
+	}
+}
+
+// queryObserver records the query latency of one repository; the zero value
+// records nothing
+type queryObserver struct {
+	duration prometheus.ObserverVec
+}
+
+// newQueryObserver applies opts to the repository of db, registering the
+// statistics of its connection pool when metrics are enabled
+func newQueryObserver(db *sql.DB, repository string, opts []SQLiteOption) queryObserver {
+	options := sqliteOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.metrics == nil {
+		return queryObserver{}
+	}
+
+	// Every repository has a pool of its own
+	pool := collectors.NewDBStatsCollector(db, options.database+"/"+repository)
+	if err := options.metrics.registry.Register(pool); err != nil {
+		slog.Warn("Failed to register connection pool metrics",
+			"component", "SQLite", "database", options.database, "repository", repository, "error", err)
+	}
+	return queryObserver{duration: options.metrics.queryDuration.MustCurryWith(prometheus.Labels{
+		"database":   options.database,
+		"repository": repository,
+	})}
+}
+
+// observe starts timing operation; call the returned function once it is done
+func (o queryObserver) observe(operation string) func() {
+	if o.duration == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		o.duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,196 @@
+package adaptor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+)
+
+// scrapeMetrics returns the exposition of metrics as Prometheus scrapes it
+func scrapeMetrics(t *testing.T, metrics *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+// newMetricsTestService returns an order service of tenant whose orders and
+// queries are counted in metrics
+func newMetricsTestService(t *testing.T, metrics *Metrics, tenant string) port.StockOrderService {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), tenant+".db")
+	repo, err := NewSQLiteRepository(dbPath, WithSQLiteMetrics(metrics, tenant))
+	if err != nil {
+		t.Fatalf("failed to open order repository: %v", err)
+	}
+	instruments, err := NewSQLiteInstrumentRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open instrument repository: %v", err)
+	}
+
+	engine := service.NewMatchingEngine(repo, nil)
+	orders := service.NewStockOrderService(repo,
+		service.WithMatchingEngine(engine),
+		service.WithInstrumentRepository(instruments),
+		service.WithOrderMetrics(metrics.OrderMetrics(tenant, instruments)),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		engine.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		instruments.Close()
+		repo.Close()
+	})
+	return orders
+}
+
+func TestMetricsCountOrdersAndQueries(t *testing.T) {
+	metrics := NewMetrics()
+	orders := newMetricsTestService(t, metrics, "desk-a")
+	caller := domain.ContextWithAccountID(context.Background(), "acct-1")
+	if _, err := orders.UpsertInstrument(caller, "AAPL", domain.UpsertInstrumentRequest{LotSize: 1, TickSize: 0.01}); err != nil {
+		t.Fatalf("failed to register instrument: %v", err)
+	}
+	create := func(side domain.OrderSide, price float64) *domain.StockOrder {
+		t.Helper()
+		order, err := orders.CreateOrder(caller, domain.CreateOrderRequest{
+			Symbol:    "AAPL",
+			OrderType: domain.OrderTypeLimit,
+			OrderSide: side,
+			Quantity:  10,
+			Price:     price,
+		})
+		if err != nil {
+			t.Fatalf("failed to create %s order: %v", side, err)
+		}
+		return order
+	}
+
+	resting := create(domain.OrderSideBuy, 90)
+	if err := orders.CancelOrder(caller, resting.ID); err != nil {
+		t.Fatalf("failed to cancel order: %v", err)
+	}
+	create(domain.OrderSideBuy, 100)
+	create(domain.OrderSideSell, 100)
+
+	want := []string{
+		`stockorder_orders_created_total{side="BUY",symbol="AAPL",tenant="desk-a"} 2`,
+		`stockorder_orders_created_total{side="SELL",symbol="AAPL",tenant="desk-a"} 1`,
+		`stockorder_orders_cancelled_total{side="BUY",symbol="AAPL",tenant="desk-a"} 1`,
+		`stockorder_orders_filled_total{side="BUY",symbol="AAPL",tenant="desk-a"} 1`,
+		`stockorder_orders_filled_total{side="SELL",symbol="AAPL",tenant="desk-a"} 1`,
+		`stockorder_sqlite_query_duration_seconds_count{database="desk-a",operation="create",repository="orders"} 3`,
+		`go_sql_max_open_connections{db_name="desk-a/orders"}`,
+		`go_goroutines`,
+	}
+
+	// Fills are counted once the matching engine has executed the orders
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		exposition := scrapeMetrics(t, metrics)
+		missing := ""
+		for _, line := range want {
+			if !strings.Contains(exposition, line) {
+				missing = line
+				break
+			}
+		}
+		if missing == "" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("metrics are missing %s:\n%s", missing, exposition)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMetricsLabelHTTPRequestsByRoute(t *testing.T) {
+	metrics := NewMetrics()
+	router := mux.NewRouter()
+	router.Use(metrics.HTTPMiddleware)
+	router.HandleFunc("/orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+
+	for _, path := range []string{"/orders/1", "/orders/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	exposition := scrapeMetrics(t, metrics)
+	line := `http_requests_total{method="GET",route="/orders/{id}",status="404"} 2`
+	if !strings.Contains(exposition, line) {
+		t.Errorf("metrics are missing %s:\n%s", line, exposition)
+	}
+	if strings.Contains(exposition, "/orders/1") {
+		t.Errorf("metrics are labelled with the request path:\n%s", exposition)
+	}
+}
+
+func TestMetricsBucketUnregisteredSymbols(t *testing.T) {
+	metrics := NewMetrics()
+	orders := newMetricsTestService(t, metrics, "desk-a")
+	caller := domain.ContextWithAccountID(context.Background(), "acct-1")
+
+	// Until instruments exist any symbol is accepted, but none gets a series
+	for _, symbol := range []string{"AAPL", "X1", "X2"} {
+		if _, err := orders.CreateOrder(caller, domain.CreateOrderRequest{
+			Symbol: symbol, OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100,
+		}); err != nil {
+			t.Fatalf("failed to create %s order: %v", symbol, err)
+		}
+	}
+
+	exposition := scrapeMetrics(t, metrics)
+	line := `stockorder_orders_created_total{side="BUY",symbol="other",tenant="desk-a"} 3`
+	if !strings.Contains(exposition, line) {
+		t.Errorf("metrics are missing %s:\n%s", line, exposition)
+	}
+	for _, symbol := range []string{"AAPL", "X1", "X2"} {
+		if strings.Contains(exposition, `symbol="`+symbol+`"`) {
+			t.Errorf("metrics are labelled with unregistered symbol %s", symbol)
+		}
+	}
+}
+
+func TestMetricsBucketUnknownHTTPMethods(t *testing.T) {
+	metrics := NewMetrics()
+	router := mux.NewRouter()
+	router.Use(metrics.HTTPMiddleware)
+	router.PathPrefix("/api/v1/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotImplemented)
+	})
+
+	for _, method := range []string{"GET", "FOO", "BAR", "get"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/api/v1/orders", nil))
+	}
+
+	exposition := scrapeMetrics(t, metrics)
+	for _, line := range []string{
+		`http_requests_total{method="GET",route="/api/v1/",status="501"} 1`,
+		`http_requests_total{method="OTHER",route="/api/v1/",status="501"} 3`,
+	} {
+		if !strings.Contains(exposition, line) {
+			t.Errorf("metrics are missing %s:\n%s", line, exposition)
+		}
+	}
+}
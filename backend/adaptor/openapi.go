@@ -0,0 +1,31 @@
+package adaptor
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec documents the REST API served by HTTPHandler, the gateway routes
+// of the google.api.http rules and /metrics. It is maintained by hand;
+// TestOpenAPISpecMatchesRoutes fails when it and the routes drift.
+//
+//go:embed openapi/openapi.json
+var openAPISpec []byte
+
+// apiDocsPage renders openAPISpec. It loads no third-party assets, so the
+// page does not change with upstream releases and works offline.
+//
+//go:embed openapi/docs.html
+var apiDocsPage []byte
+
+// OpenAPISpec serves the OpenAPI 3 document of the REST API
+func (h *HTTPHandler) OpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
+
+// APIDocs serves an interactive documentation page for the REST API
+func (h *HTTPHandler) APIDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(apiDocsPage)
+}
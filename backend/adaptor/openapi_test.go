@@ -0,0 +1,299 @@
+package adaptor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+	pbv2 "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// gatewayFiles are the proto files whose google.api.http rules the REST
+// gateway serves
+var gatewayFiles = []protoreflect.FileDescriptor{
+	pb.File_proto_stockorder_v1_stock_order_proto,
+	pbv2.File_proto_stockorder_v2_stock_order_proto,
+}
+
+type openAPIDocument struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func loadOpenAPIDocument(t *testing.T) openAPIDocument {
+	t.Helper()
+	var doc openAPIDocument
+	if err := json.Unmarshal(openAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	return doc
+}
+
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	router := mux.NewRouter()
+	NewHTTPHandler(nil).RegisterRoutes(router)
+
+	routes := map[string]bool{}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			routes[method+" "+path] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+	for _, rule := range gatewayRules() {
+		routes[rule.method+" "+rule.path] = true
+	}
+	// Served by main next to the gateway
+	routes["GET /metrics"] = true
+
+	documented := map[string]bool{}
+	for path, operations := range loadOpenAPIDocument(t).Paths {
+		for method := range operations {
+			if method == "parameters" {
+				continue
+			}
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	for _, route := range sortedKeys(routes) {
+		if !documented[route] {
+			t.Errorf("route %s is missing from openapi.json", route)
+		}
+	}
+	for _, operation := range sortedKeys(documented) {
+		if !routes[operation] {
+			t.Errorf("openapi.json documents %s, which is not routed", operation)
+		}
+	}
+}
+
+func TestOpenAPISchemasMatchJSONShapes(t *testing.T) {
+	types := map[string]any{
+		"StockOrder":                domain.StockOrder{},
+		"CreateOrderRequest":        domain.CreateOrderRequest{},
+		"OrderPage":                 domain.OrderPage{},
+		"BatchCreateOrdersRequest":  domain.BatchCreateOrdersRequest{},
+		"BatchOrderResult":          domain.BatchOrderResult{},
+		"BatchCreateOrdersResponse": BatchCreateOrdersResponse{},
+		"MassCancelRequest":         domain.MassCancelRequest{},
+		"CancelResult":              domain.CancelResult{},
+		"MassCancelResponse":        MassCancelResponse{},
+		"MarketSession":             domain.MarketSession{},
+		"AuctionState":              domain.AuctionState{},
+		"PriceLevel":                domain.PriceLevel{},
+		"SymbolQuote":               domain.SymbolQuote{},
+		"Candle":                    domain.Candle{},
+		"OrderEvent":                domain.OrderEvent{},
+		"Instrument":                domain.Instrument{},
+		"UpsertInstrumentRequest":   domain.UpsertInstrumentRequest{},
+		"HaltSymbolRequest":         domain.HaltSymbolRequest{},
+		"SuccessResponse":           SuccessResponse{},
+		"FieldViolation":            domain.FieldViolation{},
+		"ErrorResponse":             ErrorResponse{},
+	}
+
+	schemas := loadOpenAPIDocument(t).Components.Schemas
+	for name, value := range types {
+		schema, ok := schemas[name]
+		if !ok {
+			t.Errorf("schema %s is missing from openapi.json", name)
+			continue
+		}
+
+		fields := jsonFieldNames(reflect.TypeOf(value))
+		for _, field := range sortedKeys(fields) {
+			if _, ok := schema.Properties[field]; !ok {
+				t.Errorf("schema %s is missing property %s", name, field)
+			}
+		}
+		for property := range schema.Properties {
+			if !fields[property] {
+				t.Errorf("schema %s documents property %s, which %T does not have", name, property, value)
+			}
+		}
+	}
+}
+
+func TestOpenAPISchemasMatchGatewayMessages(t *testing.T) {
+	schemas := loadOpenAPIDocument(t).Components.Schemas
+	messages := map[string]protoreflect.MessageDescriptor{}
+	for _, file := range gatewayFiles {
+		prefix := string(file.Package().Name()) + "."
+		for i := 0; i < file.Messages().Len(); i++ {
+			message := file.Messages().Get(i)
+			messages[prefix+string(message.Name())] = message
+		}
+	}
+
+	// Every message a gateway route sends or returns as a body is documented
+	for _, rule := range gatewayRules() {
+		names := []protoreflect.FullName{rule.output}
+		if rule.body != "" {
+			names = append(names, rule.input)
+		}
+		for _, name := range names {
+			if _, ok := schemas[gatewaySchemaName(name)]; !ok {
+				t.Errorf("schema %s of %s %s is missing from openapi.json", gatewaySchemaName(name), rule.method, rule.path)
+			}
+		}
+	}
+
+	for name, message := range messages {
+		schema, ok := schemas[name]
+		if !ok {
+			continue
+		}
+
+		// The gateway marshals fields by their proto names
+		fields := map[string]bool{}
+		for i := 0; i < message.Fields().Len(); i++ {
+			fields[string(message.Fields().Get(i).Name())] = true
+		}
+		for _, field := range sortedKeys(fields) {
+			if _, ok := schema.Properties[field]; !ok {
+				t.Errorf("schema %s is missing property %s", name, field)
+			}
+		}
+		for property := range schema.Properties {
+			if !fields[property] {
+				t.Errorf("schema %s documents property %s, which %s does not have", name, property, message.FullName())
+			}
+		}
+	}
+}
+
+func TestOpenAPISpecIsServed(t *testing.T) {
+	router := mux.NewRouter()
+	NewHTTPHandler(nil).RegisterRoutes(router)
+
+	for path, contentType := range map[string]string{
+		"/openapi.json": "application/json",
+		"/docs":         "text/html; charset=utf-8",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s returned %d", path, w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); got != contentType {
+			t.Errorf("GET %s returned Content-Type %q, want %q", path, got, contentType)
+		}
+	}
+}
+
+func TestAPIDocsPageIsSelfContained(t *testing.T) {
+	page := string(apiDocsPage)
+	for _, scheme := range []string{"http://", "https://", "//unpkg", "//cdn"} {
+		if strings.Contains(page, scheme) {
+			t.Errorf("docs.html loads %s assets; embed them instead", scheme)
+		}
+	}
+}
+
+// gatewayRule is a REST route the gateway serves for an RPC
+type gatewayRule struct {
+	method, path, body string
+	input, output      protoreflect.FullName
+}
+
+// gatewayRules returns the google.api.http rules of every RPC in gatewayFiles
+func gatewayRules() []gatewayRule {
+	var rules []gatewayRule
+	for _, file := range gatewayFiles {
+		for i := 0; i < file.Services().Len(); i++ {
+			methods := file.Services().Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := methods.Get(j)
+				rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+				if !ok || rule == nil {
+					continue
+				}
+
+				var verb, path string
+				switch pattern := rule.Pattern.(type) {
+				case *annotations.HttpRule_Get:
+					verb, path = http.MethodGet, pattern.Get
+				case *annotations.HttpRule_Post:
+					verb, path = http.MethodPost, pattern.Post
+				case *annotations.HttpRule_Put:
+					verb, path = http.MethodPut, pattern.Put
+				case *annotations.HttpRule_Delete:
+					verb, path = http.MethodDelete, pattern.Delete
+				case *annotations.HttpRule_Patch:
+					verb, path = http.MethodPatch, pattern.Patch
+				default:
+					continue
+				}
+				rules = append(rules, gatewayRule{
+					method: verb,
+					path:   path,
+					body:   rule.Body,
+					input:  method.Input().FullName(),
+					output: method.Output().FullName(),
+				})
+			}
+		}
+	}
+	return rules
+}
+
+// gatewaySchemaName returns the openapi.json schema of a proto message, such
+// as v1.StockOrder for stockorder.v1.StockOrder
+func gatewaySchemaName(name protoreflect.FullName) string {
+	return string(name.Parent().Name()) + "." + string(name.Name())
+}
+
+// jsonFieldNames returns the names a struct's fields are encoded with
+func jsonFieldNames(typ reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
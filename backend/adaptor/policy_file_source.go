@@ -0,0 +1,48 @@
+package adaptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+type filePolicySource struct {
+	path string
+}
+
+// NewFilePolicySource reads an authorization policy from a JSON file such as
+//
+//	{
+//	  "grants": {"CreateOrder": ["trader", "admin"], ...},
+//	  "all_accounts": ["ops", "admin"],
+//	  "anonymous": []
+//	}
+//
+// Every operation must be granted to at least an empty list of roles.
+func NewFilePolicySource(path string) port.PolicySource {
+	return &filePolicySource{path: path}
+}
+
+func (s *filePolicySource) LoadPolicy(ctx context.Context) (domain.Policy, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return domain.Policy{}, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var policy domain.Policy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return domain.Policy{}, fmt.Errorf("failed to parse policy file %s: %w", s.path, err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return domain.Policy{}, fmt.Errorf("invalid policy file %s: %w", s.path, err)
+	}
+	return policy, nil
+}
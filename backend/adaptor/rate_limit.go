@@ -0,0 +1,207 @@
+package adaptor
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// RequestRateLimiter limits the HTTP requests and gRPC calls of every caller
+// with token buckets. A caller is the API key or account it authenticated
+// as, else its IP address; the account an anonymous caller names is its own
+// choice and would give it a fresh bucket per name. Rejected HTTP
+// requests get 429 with Retry-After and rejected calls ResourceExhausted.
+// Public paths and methods, such as /health, are never limited.
+type RequestRateLimiter struct {
+	mu         sync.RWMutex
+	global     port.RateLimiter
+	perCaller  port.RateLimiter
+	operations map[string]port.RateLimiter
+}
+
+func NewRequestRateLimiter(policy domain.RateLimitPolicy) *RequestRateLimiter {
+	l := &RequestRateLimiter{
+		global:     NewTokenBucketLimiter(policy.Global),
+		perCaller:  NewTokenBucketLimiter(policy.PerCaller),
+		operations: map[string]port.RateLimiter{},
+	}
+	l.SetPolicy(policy)
+	return l
+}
+
+// SetPolicy applies a reloaded policy. Callers keep the tokens they have
+// left, capped at the new burst.
+func (l *RequestRateLimiter) SetPolicy(policy domain.RateLimitPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.global.SetLimit(policy.Global)
+	l.perCaller.SetLimit(policy.PerCaller)
+
+	for operation := range l.operations {
+		if _, ok := policy.Operations[operation]; !ok {
+			delete(l.operations, operation)
+		}
+	}
+	for operation, limit := range policy.Operations {
+		if limiter, ok := l.operations[operation]; ok {
+			limiter.SetLimit(limit)
+		} else {
+			l.operations[operation] = NewTokenBucketLimiter(limit)
+		}
+	}
+}
+
+// Middleware limits HTTP requests. Routes registered without a method, such
+// as the REST gateway prefix, are only limited per caller and globally here;
+// GatewayMiddleware adds their per-operation limit.
+func (l *RequestRateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if publicPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		caller := httpCaller(r)
+		operation := ""
+		if route := mux.CurrentRoute(r); route != nil {
+			if _, err := route.GetMethods(); err == nil {
+				template, _ := route.GetPathTemplate()
+				operation = r.Method + " " + template
+			}
+		}
+
+		if err := l.allow(r.Context(), caller, operation, true); err != nil {
+			respondError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GatewayMiddleware limits REST gateway requests per operation, named by
+// their route such as "POST /api/v1/orders/{order_id}/cancel"
+func (l *RequestRateLimiter) GatewayMiddleware(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+			operation := r.Method + " " + strings.ReplaceAll(pattern.String(), "=*}", "}")
+			if err := l.allow(r.Context(), httpCaller(r), operation, false); err != nil {
+				respondError(w, err)
+				return
+			}
+		}
+		next(w, r, pathParams)
+	}
+}
+
+// UnaryInterceptor limits unary gRPC calls
+func (l *RequestRateLimiter) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	if err := l.allow(ctx, grpcCaller(ctx), info.FullMethod, true); err != nil {
+		return nil, grpcError(err, "too many requests")
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor limits opening gRPC streams; messages on an open stream
+// are not limited here
+func (l *RequestRateLimiter) StreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, stream)
+	}
+	if err := l.allow(stream.Context(), grpcCaller(stream.Context()), info.FullMethod, true); err != nil {
+		return grpcError(err, "too many requests")
+	}
+	return handler(srv, stream)
+}
+
+// allow takes a token for caller from the limit of operation, if it has
+// one, and when shared is set from the per-caller and global limits. Tokens
+// are only taken when every limit allows the request.
+func (l *RequestRateLimiter) allow(ctx context.Context, caller, operation string, shared bool) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	type check struct {
+		name    string
+		limiter port.RateLimiter
+		key     string
+	}
+	checks := []check{}
+	if limiter, ok := l.operations[operation]; ok {
+		checks = append(checks, check{operation, limiter, caller})
+	}
+	if shared {
+		checks = append(checks, check{"caller", l.perCaller, caller}, check{"server", l.global, ""})
+	}
+
+	// A request rejected by one limit takes no tokens from the others
+	undos := make([]func(), 0, len(checks))
+	for _, c := range checks {
+		undo, ok, retryAfter := c.limiter.Reserve(c.key)
+		if !ok {
+			for _, undo := range undos {
+				undo()
+			}
+			slog.WarnContext(ctx, "Rate limit exceeded", "component", "RateLimit", "caller", caller, "limit", c.name, "retry_after", retryAfter.String())
+			return domain.NewRateLimitedError(retryAfter, "rate limit exceeded, retry in %s", retryAfter.Round(time.Millisecond))
+		}
+		undos = append(undos, undo)
+	}
+	return nil
+}
+
+// setRetryAfter sets the Retry-After header in whole seconds, rounded up
+func setRetryAfter(w http.ResponseWriter, retryAfter time.Duration) {
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+}
+
+func httpCaller(r *http.Request) string {
+	if principal := domain.PrincipalFromContext(r.Context()); principal != nil {
+		return principalCaller(r.Context(), principal)
+	}
+	return "ip:" + hostOf(r.RemoteAddr)
+}
+
+func grpcCaller(ctx context.Context) string {
+	if principal := domain.PrincipalFromContext(ctx); principal != nil {
+		return principalCaller(ctx, principal)
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		return "ip:" + hostOf(p.Addr.String())
+	}
+	return "unknown"
+}
+
+// principalCaller limits API keys individually and token holders per account
+func principalCaller(ctx context.Context, principal *domain.Principal) string {
+	if principal.Method == domain.AuthMethodAPIKey {
+		return "key:" + principal.Subject
+	}
+	return accountCaller(ctx, principal.AccountID)
+}
+
+// accountCaller names an account of the caller's tenant; tenants may use the
+// same account IDs
+func accountCaller(ctx context.Context, accountID string) string {
+	if tenantID := domain.TenantIDFromContext(ctx); tenantID != domain.DefaultTenantID {
+		return "account:" + tenantID + "/" + accountID
+	}
+	return "account:" + accountID
+}
@@ -0,0 +1,50 @@
+package adaptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+type fileRateLimitSource struct {
+	path string
+}
+
+// NewFileRateLimitSource reads a rate limit policy from a JSON file such as
+//
+//	{
+//	  "global": {"rate": 1000, "burst": 2000},
+//	  "per_caller": {"rate": 20, "burst": 40},
+//	  "operations": {"POST /api/orders": {"rate": 5}},
+//	  "orders": {"rate": 10, "burst": 20}
+//	}
+//
+// Rates are per second; omitted limits are unlimited. The file is read again
+// on every call, so the policy can be reloaded.
+func NewFileRateLimitSource(path string) port.RateLimitSource {
+	return &fileRateLimitSource{path: path}
+}
+
+func (s *fileRateLimitSource) LoadRateLimits(ctx context.Context) (domain.RateLimitPolicy, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return domain.RateLimitPolicy{}, fmt.Errorf("failed to read rate limit file: %w", err)
+	}
+
+	var policy domain.RateLimitPolicy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return domain.RateLimitPolicy{}, fmt.Errorf("failed to parse rate limit file %s: %w", s.path, err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return domain.RateLimitPolicy{}, fmt.Errorf("invalid rate limit file %s: %w", s.path, err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,101 @@
+package adaptor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+)
+
+func TestRateLimitRejectionTakesNoTokens(t *testing.T) {
+	// One request per caller and one for the whole server, refilled hourly
+	hourly := domain.RateLimit{Rate: 1.0 / 3600, Burst: 1}
+	limiter := NewRequestRateLimiter(domain.RateLimitPolicy{
+		Global:     hourly,
+		PerCaller:  hourly,
+		Operations: map[string]domain.RateLimit{"GET /api/orders": {Rate: 1.0 / 3600, Burst: 2}},
+	})
+	ctx := context.Background()
+
+	if err := limiter.allow(ctx, "ip:10.0.0.1", "GET /api/orders", true); err != nil {
+		t.Fatalf("first request rejected: %v", err)
+	}
+	// The server limit rejects the second caller
+	if err := limiter.allow(ctx, "ip:10.0.0.2", "GET /api/orders", true); !errors.Is(err, domain.ErrRateLimited) {
+		t.Fatalf("second caller error = %v, want rate limited", err)
+	}
+
+	if ok, _ := limiter.perCaller.Allow("ip:10.0.0.2"); !ok {
+		t.Error("rejected request took the caller's token")
+	}
+	if ok, _ := limiter.operations["GET /api/orders"].Allow("ip:10.0.0.2"); !ok {
+		t.Error("rejected request took the operation's token")
+	}
+}
+
+func TestOrderRateOfAnonymousCallersIsPerAddress(t *testing.T) {
+	repo, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatalf("failed to open order repository: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	orders := service.NewStockOrderService(repo,
+		service.WithOrderRateLimiter(NewTokenBucketLimiter(domain.RateLimit{Rate: 1.0 / 3600, Burst: 1})),
+	)
+	create := func(remoteAddr, accountID string) error {
+		var err error
+		handler := LoggingMiddleware(accountMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err = orders.CreateOrder(r.Context(), domain.CreateOrderRequest{
+				Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100,
+			})
+		})))
+		r := httptest.NewRequest(http.MethodPost, "/api/orders", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-Account-ID", accountID)
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		return err
+	}
+
+	if err := create("10.0.0.1:5000", ""); err != nil {
+		t.Fatalf("first anonymous order rejected: %v", err)
+	}
+	if err := create("10.0.0.2:5000", ""); err != nil {
+		t.Errorf("anonymous order from another address rejected: %v", err)
+	}
+	if err := create("10.0.0.1:5001", ""); !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("second anonymous order from one address error = %v, want rate limited", err)
+	}
+	// Naming a new account does not reset the limit
+	if err := create("10.0.0.2:5001", "acct-rotated"); !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("anonymous order naming a new account error = %v, want rate limited", err)
+	}
+}
+
+func TestAnonymousCallersAreLimitedPerAddress(t *testing.T) {
+	limiter := NewRequestRateLimiter(domain.RateLimitPolicy{
+		Global:    domain.RateLimit{Rate: 1000, Burst: 1000},
+		PerCaller: domain.RateLimit{Rate: 1.0 / 3600, Burst: 1},
+	})
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	request := func(accountID string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/orders", nil)
+		r.RemoteAddr = "10.0.0.1:5000"
+		r.Header.Set("X-Account-ID", accountID)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := request("acct-1"); code != http.StatusOK {
+		t.Fatalf("first request returned %d", code)
+	}
+	if code := request("acct-2"); code != http.StatusTooManyRequests {
+		t.Errorf("request naming another account returned %d, want 429", code)
+	}
+}
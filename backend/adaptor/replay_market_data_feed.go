@@ -0,0 +1,388 @@
+package adaptor
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// ReplayOptions controls how recorded ticks are played back
+type ReplayOptions struct {
+	// Speed scales the recorded gaps between ticks: 1 replays in real time,
+	// 10 replays ten times faster and 0 emits ticks as fast as they are read
+	Speed float64
+	// Rebase shifts tick timestamps so the replay starts at the current time
+	Rebase bool
+	// Loop restarts the file from the beginning when it is exhausted
+	Loop bool
+}
+
+type replayMarketDataFeed struct {
+	path   string
+	format string
+	opts   ReplayOptions
+
+	mu     sync.Mutex
+	closed bool
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// replayTick is one recorded quote or trade. CSV files use the JSON field
+// names as column headers.
+type replayTick struct {
+	Timestamp time.Time                  `json:"timestamp"`
+	Symbol    string                     `json:"symbol"`
+	Type      domain.MarketDataEventType `json:"type"`
+	Price     float64                    `json:"price"`
+	Quantity  int                        `json:"quantity"`
+	BidPrice  float64                    `json:"bid_price"`
+	BidSize   int                        `json:"bid_size"`
+	AskPrice  float64                    `json:"ask_price"`
+	AskSize   int                        `json:"ask_size"`
+}
+
+type tickReader interface {
+	// next returns the following tick or io.EOF at the end of the file
+	next() (*replayTick, error)
+}
+
+// NewReplayMarketDataFeed replays ticks recorded in a CSV (.csv) or JSON
+// lines (.jsonl, .ndjson) file. Every subscription reads the file
+// independently, so replays are deterministic.
+func NewReplayMarketDataFeed(path string, opts ReplayOptions) (port.MarketDataFeed, error) {
+	if opts.Speed < 0 {
+		return nil, fmt.Errorf("replay speed must not be negative")
+	}
+
+	var format string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		format = "csv"
+	case ".jsonl", ".ndjson":
+		format = "jsonl"
+	default:
+		return nil, fmt.Errorf("unsupported market data file format: %s", path)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open market data file: %w", err)
+	}
+
+	return &replayMarketDataFeed{
+		path:   path,
+		format: format,
+		opts:   opts,
+		done:   make(chan struct{}),
+	}, nil
+}
+
+func (f *replayMarketDataFeed) Subscribe(ctx context.Context, symbols ...string) (<-chan domain.MarketDataEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil, fmt.Errorf("market data feed is closed")
+	}
+
+	filter := map[string]bool{}
+	for _, symbol := range symbols {
+		filter[strings.ToUpper(symbol)] = true
+	}
+
+	events := make(chan domain.MarketDataEvent, 64)
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		defer close(events)
+
+		for {
+			emitted, err := f.replay(ctx, filter, events)
+			if err != nil {
+				if err != context.Canceled {
+					slog.Error("Replay failed", "component", "ReplayFeed", "path", f.path, "error", err)
+				}
+				return
+			}
+			if !f.opts.Loop {
+				return
+			}
+			// Looping a pass that emitted nothing would reread the file forever
+			if emitted == 0 {
+				slog.Warn("Replay emitted no ticks, not looping", "component", "ReplayFeed", "path", f.path, "symbols", symbols)
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// replay plays the file once, returning the number of ticks it emitted when
+// the file is exhausted
+func (f *replayMarketDataFeed) replay(ctx context.Context, filter map[string]bool, events chan<- domain.MarketDataEvent) (int, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open market data file: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := f.newReader(file)
+	if err != nil {
+		return 0, err
+	}
+
+	emitted := 0
+	var first, start time.Time
+	for {
+		tick, err := reader.next()
+		if err == io.EOF {
+			return emitted, nil
+		}
+		if err != nil {
+			return emitted, err
+		}
+
+		if first.IsZero() {
+			first, start = tick.Timestamp, time.Now()
+		}
+
+		// Pace against the start of the replay so that delays do not accumulate
+		offset := tick.Timestamp.Sub(first)
+		if f.opts.Speed > 0 {
+			offset = time.Duration(float64(offset) / f.opts.Speed)
+			if wait := time.Until(start.Add(offset)); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return emitted, context.Canceled
+				case <-f.done:
+					timer.Stop()
+					return emitted, context.Canceled
+				}
+			}
+		}
+
+		if len(filter) > 0 && !filter[tick.Symbol] {
+			continue
+		}
+
+		if f.opts.Rebase {
+			tick.Timestamp = start.Add(offset)
+			if f.opts.Speed == 0 {
+				tick.Timestamp = time.Now()
+			}
+		}
+
+		select {
+		case events <- tick.event():
+			emitted++
+		case <-ctx.Done():
+			return emitted, context.Canceled
+		case <-f.done:
+			return emitted, context.Canceled
+		}
+	}
+}
+
+func (f *replayMarketDataFeed) newReader(file io.Reader) (tickReader, error) {
+	if f.format == "jsonl" {
+		return &jsonlTickReader{scanner: bufio.NewScanner(file)}, nil
+	}
+	return newCSVTickReader(file)
+}
+
+func (f *replayMarketDataFeed) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	close(f.done)
+	f.mu.Unlock()
+
+	f.wg.Wait()
+	return nil
+}
+
+func (t *replayTick) validate() error {
+	if t.Symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if t.Timestamp.IsZero() {
+		return fmt.Errorf("timestamp is required")
+	}
+	switch t.Type {
+	case domain.MarketDataEventTrade:
+		if t.Price <= 0 || t.Quantity <= 0 {
+			return fmt.Errorf("trade must have a positive price and quantity")
+		}
+	case domain.MarketDataEventQuote:
+		if t.BidPrice < 0 || t.AskPrice < 0 {
+			return fmt.Errorf("quote prices must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown tick type: %q", t.Type)
+	}
+	return nil
+}
+
+func (t *replayTick) event() domain.MarketDataEvent {
+	event := domain.MarketDataEvent{
+		Type:      t.Type,
+		Symbol:    t.Symbol,
+		Timestamp: t.Timestamp,
+	}
+	if t.Type == domain.MarketDataEventTrade {
+		event.Trade = &domain.Trade{
+			Symbol:     t.Symbol,
+			Price:      t.Price,
+			Quantity:   t.Quantity,
+			ExecutedAt: t.Timestamp,
+		}
+		return event
+	}
+	event.Quote = &domain.Quote{
+		Symbol:    t.Symbol,
+		BidPrice:  t.BidPrice,
+		BidSize:   t.BidSize,
+		AskPrice:  t.AskPrice,
+		AskSize:   t.AskSize,
+		Timestamp: t.Timestamp,
+	}
+	return event
+}
+
+type jsonlTickReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *jsonlTickReader) next() (*replayTick, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		tick := &replayTick{}
+		if err := json.Unmarshal([]byte(line), tick); err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		tick.Symbol = strings.ToUpper(tick.Symbol)
+		if err := tick.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		return tick, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+type csvTickReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVTickReader(file io.Reader) (*csvTickReader, error) {
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"timestamp", "symbol", "type"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing column %q", required)
+		}
+	}
+
+	return &csvTickReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvTickReader) next() (*replayTick, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	tick := &replayTick{
+		Symbol: strings.ToUpper(r.field(record, "symbol")),
+		Type:   domain.MarketDataEventType(strings.ToUpper(r.field(record, "type"))),
+	}
+
+	if tick.Timestamp, err = time.Parse(time.RFC3339Nano, r.field(record, "timestamp")); err != nil {
+		return nil, fmt.Errorf("line %d: invalid timestamp: %w", line, err)
+	}
+
+	for _, field := range []struct {
+		name string
+		dst  *float64
+	}{
+		{"price", &tick.Price},
+		{"bid_price", &tick.BidPrice},
+		{"ask_price", &tick.AskPrice},
+	} {
+		if value := r.field(record, field.name); value != "" {
+			if *field.dst, err = strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %w", line, field.name, err)
+			}
+		}
+	}
+
+	for _, field := range []struct {
+		name string
+		dst  *int
+	}{
+		{"quantity", &tick.Quantity},
+		{"bid_size", &tick.BidSize},
+		{"ask_size", &tick.AskSize},
+	} {
+		if value := r.field(record, field.name); value != "" {
+			if *field.dst, err = strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %w", line, field.name, err)
+			}
+		}
+	}
+
+	if err := tick.validate(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", line, err)
+	}
+	return tick, nil
+}
+
+func (r *csvTickReader) field(record []string, name string) string {
+	i, ok := r.columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
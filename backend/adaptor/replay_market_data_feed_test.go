@@ -0,0 +1,247 @@
+package adaptor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+// writeTicks writes a market data file named name and returns its path
+func writeTicks(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// newReplayFeed opens path and closes the feed when the test ends
+func newReplayFeed(t *testing.T, path string, opts ReplayOptions) *replayMarketDataFeed {
+	t.Helper()
+	feed, err := NewReplayMarketDataFeed(path, opts)
+	if err != nil {
+		t.Fatalf("NewReplayMarketDataFeed failed: %v", err)
+	}
+	t.Cleanup(func() { feed.Close() })
+	return feed.(*replayMarketDataFeed)
+}
+
+// drainEvents returns the events of a subscription until it ends
+func drainEvents(t *testing.T, events <-chan domain.MarketDataEvent) []domain.MarketDataEvent {
+	t.Helper()
+	received := []domain.MarketDataEvent{}
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return received
+			}
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("subscription did not end, got %d events", len(received))
+		}
+	}
+}
+
+var (
+	replayStart = time.Date(2025, 3, 3, 9, 30, 0, 0, time.UTC)
+
+	replayEvents = []domain.MarketDataEvent{
+		{
+			Type: domain.MarketDataEventQuote, Symbol: "AAPL", Timestamp: replayStart,
+			Quote: &domain.Quote{Symbol: "AAPL", BidPrice: 189.5, BidSize: 100, AskPrice: 189.6, AskSize: 200, Timestamp: replayStart},
+		},
+		{
+			Type: domain.MarketDataEventTrade, Symbol: "MSFT", Timestamp: replayStart.Add(100 * time.Millisecond),
+			Trade: &domain.Trade{Symbol: "MSFT", Price: 410.25, Quantity: 50, ExecutedAt: replayStart.Add(100 * time.Millisecond)},
+		},
+		{
+			Type: domain.MarketDataEventTrade, Symbol: "AAPL", Timestamp: replayStart.Add(200 * time.Millisecond),
+			Trade: &domain.Trade{Symbol: "AAPL", Price: 189.55, Quantity: 10, ExecutedAt: replayStart.Add(200 * time.Millisecond)},
+		},
+	}
+
+	replayCSV = `# recorded ticks
+timestamp,symbol,type,price,quantity,bid_price,bid_size,ask_price,ask_size
+2025-03-03T09:30:00Z,aapl,quote,,,189.5,100,189.6,200
+2025-03-03T09:30:00.1Z,MSFT,TRADE,410.25,50,,,,
+2025-03-03T09:30:00.2Z,AAPL,trade,189.55,10,,,,
+`
+
+	replayJSONL = `{"timestamp":"2025-03-03T09:30:00Z","symbol":"aapl","type":"QUOTE","bid_price":189.5,"bid_size":100,"ask_price":189.6,"ask_size":200}
+
+{"timestamp":"2025-03-03T09:30:00.1Z","symbol":"MSFT","type":"TRADE","price":410.25,"quantity":50}
+{"timestamp":"2025-03-03T09:30:00.2Z","symbol":"AAPL","type":"TRADE","price":189.55,"quantity":10}
+`
+)
+
+func TestReplayFeedParsesFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		symbols []string
+		want    []domain.MarketDataEvent
+	}{
+		{"csv", "ticks.csv", replayCSV, nil, replayEvents},
+		{"jsonl", "ticks.jsonl", replayJSONL, nil, replayEvents},
+		{"ndjson", "ticks.ndjson", replayJSONL, nil, replayEvents},
+		{"symbol filter", "ticks.csv", replayCSV, []string{"msft"}, replayEvents[1:2]},
+		{"csv invalid row", "ticks.csv", "timestamp,symbol,type,price,quantity\n2025-03-03T09:30:00Z,AAPL,TRADE,abc,10\n", nil, []domain.MarketDataEvent{}},
+		{"csv missing column", "ticks.csv", "timestamp,type\n2025-03-03T09:30:00Z,TRADE\n", nil, []domain.MarketDataEvent{}},
+		{"jsonl invalid tick", "ticks.jsonl", `{"timestamp":"2025-03-03T09:30:00Z","symbol":"AAPL","type":"TRADE","price":0,"quantity":10}` + "\n", nil, []domain.MarketDataEvent{}},
+		{"jsonl unknown type", "ticks.jsonl", `{"timestamp":"2025-03-03T09:30:00Z","symbol":"AAPL","type":"BOOK"}` + "\n", nil, []domain.MarketDataEvent{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := newReplayFeed(t, writeTicks(t, tt.file, tt.content), ReplayOptions{})
+			events, err := feed.Subscribe(context.Background(), tt.symbols...)
+			if err != nil {
+				t.Fatalf("Subscribe failed: %v", err)
+			}
+			if got := drainEvents(t, events); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got events %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayFeedRejectsUnknownFiles(t *testing.T) {
+	if _, err := NewReplayMarketDataFeed(writeTicks(t, "ticks.txt", replayCSV), ReplayOptions{}); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+	if _, err := NewReplayMarketDataFeed(filepath.Join(t.TempDir(), "missing.csv"), ReplayOptions{}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if _, err := NewReplayMarketDataFeed(writeTicks(t, "ticks.csv", replayCSV), ReplayOptions{Speed: -1}); err == nil {
+		t.Error("expected an error for a negative speed")
+	}
+}
+
+func TestReplayFeedScalesSpeed(t *testing.T) {
+	// At ten times real time the 200ms recording takes 20ms, and rebased
+	// timestamps keep the scaled gaps exactly
+	feed := newReplayFeed(t, writeTicks(t, "ticks.csv", replayCSV), ReplayOptions{Speed: 10, Rebase: true})
+	started := time.Now()
+	events, err := feed.Subscribe(context.Background())
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	received := drainEvents(t, events)
+	elapsed := time.Since(started)
+
+	if len(received) != len(replayEvents) {
+		t.Fatalf("got %d events, want %d", len(received), len(replayEvents))
+	}
+	for i, event := range received {
+		if got, want := event.Timestamp.Sub(received[0].Timestamp), time.Duration(i)*10*time.Millisecond; got != want {
+			t.Errorf("event %d is %v after the first, want %v", i, got, want)
+		}
+	}
+	if elapsed < 20*time.Millisecond || elapsed >= 200*time.Millisecond {
+		t.Errorf("replay took %v, want about 20ms and less than the recorded 200ms", elapsed)
+	}
+	if first := received[0].Timestamp; first.Before(started) || first.After(started.Add(time.Second)) {
+		t.Errorf("rebased replay started at %v, want about %v", first, started)
+	}
+}
+
+func TestReplayFeedLoops(t *testing.T) {
+	feed := newReplayFeed(t, writeTicks(t, "ticks.jsonl", replayJSONL), ReplayOptions{Loop: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := feed.Subscribe(ctx, "AAPL")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	want := []domain.MarketDataEventType{
+		domain.MarketDataEventQuote, domain.MarketDataEventTrade,
+		domain.MarketDataEventQuote, domain.MarketDataEventTrade,
+		domain.MarketDataEventQuote,
+	}
+	for i, typ := range want {
+		select {
+		case event := <-events:
+			if event.Symbol != "AAPL" || event.Type != typ {
+				t.Fatalf("event %d is %s %s, want AAPL %s", i, event.Symbol, event.Type, typ)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("got %d events, want %d", i, len(want))
+		}
+	}
+}
+
+func TestReplayFeedStopsLoopingWithoutTicks(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		symbols []string
+	}{
+		{"empty csv", "ticks.csv", "", nil},
+		{"csv header only", "ticks.csv", "timestamp,symbol,type,price,quantity\n", nil},
+		{"empty jsonl", "ticks.jsonl", "\n\n", nil},
+		{"no subscribed symbol", "ticks.csv", replayCSV, []string{"TSLA"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := newReplayFeed(t, writeTicks(t, tt.file, tt.content), ReplayOptions{Loop: true})
+			events, err := feed.Subscribe(context.Background(), tt.symbols...)
+			if err != nil {
+				t.Fatalf("Subscribe failed: %v", err)
+			}
+			if got := drainEvents(t, events); len(got) != 0 {
+				t.Errorf("got %d events, want none", len(got))
+			}
+		})
+	}
+}
+
+func TestReplayFeedCancellation(t *testing.T) {
+	// The second tick is an hour after the first, so the replay is waiting
+	// for it when it is cancelled
+	content := strings.Join([]string{
+		"timestamp,symbol,type,price,quantity",
+		"2025-03-03T09:30:00Z,AAPL,TRADE,189.55,10",
+		"2025-03-03T10:30:00Z,AAPL,TRADE,189.60,10",
+	}, "\n")
+
+	t.Run("context", func(t *testing.T) {
+		feed := newReplayFeed(t, writeTicks(t, "ticks.csv", content), ReplayOptions{Speed: 1})
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := feed.Subscribe(ctx)
+		if err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+		<-events
+		cancel()
+		if got := drainEvents(t, events); len(got) != 0 {
+			t.Errorf("got %d events after cancellation, want none", len(got))
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		feed := newReplayFeed(t, writeTicks(t, "ticks.csv", content), ReplayOptions{Speed: 1})
+		events, err := feed.Subscribe(context.Background())
+		if err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+		<-events
+		feed.Close()
+		if got := drainEvents(t, events); len(got) != 0 {
+			t.Errorf("got %d events after Close, want none", len(got))
+		}
+		if _, err := feed.Subscribe(context.Background()); err == nil {
+			t.Error("expected Subscribe to fail on a closed feed")
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package adaptor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+type sqliteAPIKeyRepository struct {
+	db      *sql.DB
+	queries queryObserver
+}
+
+func NewSQLiteAPIKeyRepository(dbPath string, opts ...SQLiteOption) (port.APIKeyRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	repo := &sqliteAPIKeyRepository{db: db, queries: newQueryObserver(db, "api_keys", opts)}
+	if err := repo.initSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	return repo, nil
+}
+
+func (r *sqliteAPIKeyRepository) initSchema() error {
+	// key_hash is the SHA-256 of the secret; roles is a comma separated list
+	query := `
+	CREATE TABLE IF NOT EXISTS api_keys (
+		id TEXT PRIMARY KEY,
+		account_id TEXT NOT NULL,
+		name TEXT NOT NULL DEFAULT '',
+		roles TEXT NOT NULL DEFAULT '',
+		key_hash TEXT NOT NULL UNIQUE,
+		created_at DATETIME NOT NULL,
+		revoked_at DATETIME
+	);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return err
+	}
+	// Keys created before tenants existed belong to the default tenant
+	return addColumnIfMissing(r.db, "api_keys", "tenant_id", "TEXT NOT NULL DEFAULT ''")
+}
+
+func (r *sqliteAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	defer r.queries.observe("create")()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO api_keys (id, account_id, tenant_id, name, roles, key_hash, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+		key.ID,
+		key.AccountID,
+		key.TenantID,
+		key.Name,
+		strings.Join(key.Roles, ","),
+		key.Hash,
+		key.CreatedAt,
+	)
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to create API key")
+	}
+
+	return nil
+}
+
+func (r *sqliteAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	defer r.queries.observe("get_by_hash")()
+
+	row := r.db.QueryRowContext(ctx, `
+		SELECT id, account_id, tenant_id, name, roles, key_hash, created_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = ?
+	`, hash)
+
+	key, err := scanAPIKey(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.NewUnavailableError(err, "failed to get API key")
+	}
+
+	return key, nil
+}
+
+func (r *sqliteAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	defer r.queries.observe("list")()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, account_id, tenant_id, name, roles, key_hash, created_at, revoked_at
+		FROM api_keys
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return nil, domain.NewUnavailableError(err, "failed to list API keys")
+	}
+	defer rows.Close()
+
+	keys := []*domain.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, domain.NewUnavailableError(err, "failed to scan API key")
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, domain.NewUnavailableError(err, "error iterating API keys")
+	}
+
+	return keys, nil
+}
+
+func (r *sqliteAPIKeyRepository) Revoke(ctx context.Context, keyID string, revokedAt time.Time) error {
+	defer r.queries.observe("revoke")()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?)
+		WHERE id = ?
+	`, revokedAt, keyID)
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to revoke API key")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to get rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return domain.NewNotFoundError("api_key", keyID)
+	}
+
+	return nil
+}
+
+func (r *sqliteAPIKeyRepository) Close() error {
+	return r.db.Close()
+}
+
+// scanAPIKey reads a row selected with the columns of the api_keys table
+func scanAPIKey(row interface{ Scan(...any) error }) (*domain.APIKey, error) {
+	key := &domain.APIKey{}
+	var roles string
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&key.ID,
+		&key.AccountID,
+		&key.TenantID,
+		&key.Name,
+		&roles,
+		&key.Hash,
+		&key.CreatedAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if roles != "" {
+		key.Roles = strings.Split(roles, ",")
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return key, nil
+}
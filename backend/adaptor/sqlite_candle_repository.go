@@ -0,0 +1,163 @@
+package adaptor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+type sqliteCandleRepository struct {
+	db      *sql.DB
+	queries queryObserver
+}
+
+func NewSQLiteCandleRepository(dbPath string, opts ...SQLiteOption) (port.CandleRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	repo := &sqliteCandleRepository{db: db, queries: newQueryObserver(db, "candles", opts)}
+	if err := repo.initSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	return repo, nil
+}
+
+func (r *sqliteCandleRepository) initSchema() error {
+	// open_time is stored as unix seconds so range queries compare numerically
+	query := `
+	CREATE TABLE IF NOT EXISTS candles (
+		symbol TEXT NOT NULL,
+		candle_interval TEXT NOT NULL,
+		open_time INTEGER NOT NULL,
+		open REAL NOT NULL,
+		high REAL NOT NULL,
+		low REAL NOT NULL,
+		close REAL NOT NULL,
+		volume INTEGER NOT NULL,
+		trade_count INTEGER NOT NULL,
+		PRIMARY KEY (symbol, candle_interval, open_time)
+	);
+	`
+
+	_, err := r.db.Exec(query)
+	return err
+}
+
+func (r *sqliteCandleRepository) Upsert(ctx context.Context, candles []*domain.Candle) error {
+	defer r.queries.observe("upsert")()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO candles (symbol, candle_interval, open_time, open, high, low, close, volume, trade_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (symbol, candle_interval, open_time) DO UPDATE SET
+			open = excluded.open, high = excluded.high, low = excluded.low, close = excluded.close,
+			volume = excluded.volume, trade_count = excluded.trade_count
+	`)
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to prepare candle upsert")
+	}
+	defer stmt.Close()
+
+	for _, candle := range candles {
+		_, err := stmt.ExecContext(ctx,
+			candle.Symbol,
+			candle.Interval,
+			candle.OpenTime.Unix(),
+			candle.Open,
+			candle.High,
+			candle.Low,
+			candle.Close,
+			candle.Volume,
+			candle.TradeCount,
+		)
+		if err != nil {
+			return domain.NewUnavailableError(err, "failed to upsert candle")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.NewUnavailableError(err, "failed to commit candles")
+	}
+
+	return nil
+}
+
+// List returns the most recent candles matching the query in ascending time order
+func (r *sqliteCandleRepository) List(ctx context.Context, query domain.CandleQuery) ([]*domain.Candle, error) {
+	defer r.queries.observe("list")()
+
+	from, to := int64(0), int64(1<<62)
+	if !query.From.IsZero() {
+		from = query.From.Unix()
+	}
+	if !query.To.IsZero() {
+		to = query.To.Unix()
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT symbol, candle_interval, open_time, open, high, low, close, volume, trade_count
+		FROM candles
+		WHERE symbol = ? AND candle_interval = ? AND open_time >= ? AND open_time < ?
+		ORDER BY open_time DESC
+		LIMIT ?
+	`, query.Symbol, query.Interval, from, to, query.Limit)
+	if err != nil {
+		return nil, domain.NewUnavailableError(err, "failed to list candles")
+	}
+	defer rows.Close()
+
+	candles := []*domain.Candle{}
+	for rows.Next() {
+		candle := &domain.Candle{}
+		var openTime int64
+		err := rows.Scan(
+			&candle.Symbol,
+			&candle.Interval,
+			&openTime,
+			&candle.Open,
+			&candle.High,
+			&candle.Low,
+			&candle.Close,
+			&candle.Volume,
+			&candle.TradeCount,
+		)
+		if err != nil {
+			return nil, domain.NewUnavailableError(err, "failed to scan candle")
+		}
+		candle.OpenTime = time.Unix(openTime, 0).UTC()
+		candles = append(candles, candle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, domain.NewUnavailableError(err, "error iterating candles")
+	}
+
+	// Reverse into ascending order
+	for i, j := 0, len(candles)-1; i < j; i, j = i+1, j-1 {
+		candles[i], candles[j] = candles[j], candles[i]
+	}
+
+	return candles, nil
+}
+
+func (r *sqliteCandleRepository) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,167 @@
+package adaptor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+type sqliteInstrumentRepository struct {
+	db      *sql.DB
+	queries queryObserver
+}
+
+func NewSQLiteInstrumentRepository(dbPath string, opts ...SQLiteOption) (port.InstrumentRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	repo := &sqliteInstrumentRepository{db: db, queries: newQueryObserver(db, "instruments", opts)}
+	if err := repo.initSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	return repo, nil
+}
+
+func (r *sqliteInstrumentRepository) initSchema() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS instruments (
+		symbol TEXT PRIMARY KEY,
+		name TEXT NOT NULL DEFAULT '',
+		lot_size INTEGER NOT NULL DEFAULT 0,
+		tick_size REAL NOT NULL DEFAULT 0,
+		halted INTEGER NOT NULL DEFAULT 0,
+		halt_reason TEXT NOT NULL DEFAULT '',
+		updated_at DATETIME NOT NULL
+	);
+	`
+
+	_, err := r.db.Exec(query)
+	return err
+}
+
+func (r *sqliteInstrumentRepository) Upsert(ctx context.Context, instrument *domain.Instrument) error {
+	defer r.queries.observe("upsert")()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO instruments (symbol, name, lot_size, tick_size, halted, halt_reason, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (symbol) DO UPDATE SET
+			name = excluded.name, lot_size = excluded.lot_size, tick_size = excluded.tick_size,
+			halted = excluded.halted, halt_reason = excluded.halt_reason, updated_at = excluded.updated_at
+	`,
+		instrument.Symbol,
+		instrument.Name,
+		instrument.LotSize,
+		instrument.TickSize,
+		instrument.Halted,
+		instrument.HaltReason,
+		instrument.UpdatedAt,
+	)
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to upsert instrument")
+	}
+
+	return nil
+}
+
+func (r *sqliteInstrumentRepository) Get(ctx context.Context, symbol string) (*domain.Instrument, error) {
+	defer r.queries.observe("get")()
+
+	row := r.db.QueryRowContext(ctx, `
+		SELECT symbol, name, lot_size, tick_size, halted, halt_reason, updated_at
+		FROM instruments
+		WHERE symbol = ?
+	`, symbol)
+
+	instrument, err := scanInstrument(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.NewUnavailableError(err, "failed to get instrument")
+	}
+
+	return instrument, nil
+}
+
+func (r *sqliteInstrumentRepository) List(ctx context.Context) ([]*domain.Instrument, error) {
+	defer r.queries.observe("list")()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT symbol, name, lot_size, tick_size, halted, halt_reason, updated_at
+		FROM instruments
+		ORDER BY symbol ASC
+	`)
+	if err != nil {
+		return nil, domain.NewUnavailableError(err, "failed to list instruments")
+	}
+	defer rows.Close()
+
+	instruments := []*domain.Instrument{}
+	for rows.Next() {
+		instrument, err := scanInstrument(rows)
+		if err != nil {
+			return nil, domain.NewUnavailableError(err, "failed to scan instrument")
+		}
+		instruments = append(instruments, instrument)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, domain.NewUnavailableError(err, "error iterating instruments")
+	}
+
+	return instruments, nil
+}
+
+func (r *sqliteInstrumentRepository) Delete(ctx context.Context, symbol string) error {
+	defer r.queries.observe("delete")()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM instruments WHERE symbol = ?`, symbol)
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to delete instrument")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to get rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return domain.NewNotFoundError("instrument", symbol)
+	}
+
+	return nil
+}
+
+func (r *sqliteInstrumentRepository) Close() error {
+	return r.db.Close()
+}
+
+// scanInstrument reads a row selected with the columns of the instruments table
+func scanInstrument(row interface{ Scan(...any) error }) (*domain.Instrument, error) {
+	instrument := &domain.Instrument{}
+	err := row.Scan(
+		&instrument.Symbol,
+		&instrument.Name,
+		&instrument.LotSize,
+		&instrument.TickSize,
+		&instrument.Halted,
+		&instrument.HaltReason,
+		&instrument.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return instrument, nil
+}
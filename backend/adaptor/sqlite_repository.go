@@ -3,18 +3,25 @@ package adaptor
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
 )
 
 type sqliteRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	queries queryObserver
 }
 
-func NewSQLiteRepository(dbPath string) (port.StockOrderRepository, error) {
+func NewSQLiteRepository(dbPath string, opts ...SQLiteOption) (port.StockOrderRepository, error) {
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -24,7 +31,7 @@ func NewSQLiteRepository(dbPath string) (port.StockOrderRepository, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	repo := &sqliteRepository{db: db}
+	repo := &sqliteRepository{db: db, queries: newQueryObserver(db, "orders", opts)}
 	if err := repo.initSchema(); err != nil {
 		return nil, fmt.Errorf("failed to initialize schema: %w", err)
 	}
@@ -52,39 +59,144 @@ func (r *sqliteRepository) initSchema() error {
 	CREATE INDEX IF NOT EXISTS idx_created_at ON stock_orders(created_at DESC);
 	`
 
-	_, err := r.db.Exec(query)
+	if _, err := r.db.Exec(query); err != nil {
+		return err
+	}
+
+	// Columns added after the initial schema
+	columns := []struct {
+		name       string
+		definition string
+	}{
+		{"filled_quantity", "INTEGER NOT NULL DEFAULT 0"},
+		{"average_price", "REAL NOT NULL DEFAULT 0"},
+		{"account_id", "TEXT NOT NULL DEFAULT ''"},
+		{"client_order_id", "TEXT NOT NULL DEFAULT ''"},
+		{"request_fingerprint", "TEXT NOT NULL DEFAULT ''"},
+	}
+	for _, column := range columns {
+		if err := addColumnIfMissing(r.db, "stock_orders", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+
+	// Client order IDs are optional but unique per account
+	_, err := r.db.Exec(`
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_account_client_order_id
+		ON stock_orders(account_id, client_order_id) WHERE client_order_id != '';
+	`)
 	return err
 }
 
+// addColumnIfMissing migrates databases created before a column existed
+func addColumnIfMissing(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid          int
+			name         string
+			columnType   string
+			notNull      int
+			defaultValue sql.NullString
+			primaryKey   int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey); err != nil {
+			return fmt.Errorf("failed to scan column info: %w", err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating columns: %w", err)
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
 func (r *sqliteRepository) Create(ctx context.Context, order *domain.StockOrder) error {
+	defer r.queries.observe("create")()
+
+	return insertOrder(ctx, r.db, order)
+}
+
+// CreateBatch inserts every order in a single transaction
+func (r *sqliteRepository) CreateBatch(ctx context.Context, orders []*domain.StockOrder) error {
+	defer r.queries.observe("create_batch")()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	for _, order := range orders {
+		if err := insertOrder(ctx, tx, order); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.NewUnavailableError(err, "failed to commit orders")
+	}
+
+	return nil
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertOrder(ctx context.Context, db execer, order *domain.StockOrder) error {
 	query := `
-		INSERT INTO stock_orders (id, symbol, order_type, order_side, quantity, price, status, created_at, updated_at, description)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO stock_orders (id, account_id, client_order_id, symbol, order_type, order_side, quantity, price, status, filled_quantity, average_price, created_at, updated_at, description, request_fingerprint)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := db.ExecContext(ctx, query,
 		order.ID,
+		order.AccountID,
+		order.ClientOrderID,
 		order.Symbol,
 		order.OrderType,
 		order.OrderSide,
 		order.Quantity,
 		order.Price,
 		order.Status,
+		order.FilledQuantity,
+		order.AveragePrice,
 		order.CreatedAt,
 		order.UpdatedAt,
 		order.Description,
+		order.RequestFingerprint,
 	)
 
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return fmt.Errorf("%w: %s", domain.ErrDuplicateClientOrderID, order.ClientOrderID)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create order: %w", err)
+		return domain.NewUnavailableError(err, "failed to create order")
 	}
 
+	slog.DebugContext(ctx, "Order stored", "component", "SQLiteRepository", "order_id", order.ID, "status", order.Status)
 	return nil
 }
 
 func (r *sqliteRepository) GetByID(ctx context.Context, orderID string) (*domain.StockOrder, error) {
+	defer r.queries.observe("get_by_id")()
+
 	query := `
-		SELECT id, symbol, order_type, order_side, quantity, price, status, created_at, updated_at, description
+		SELECT id, account_id, client_order_id, symbol, order_type, order_side, quantity, price, status, filled_quantity, average_price, created_at, updated_at, description
 		FROM stock_orders
 		WHERE id = ?
 	`
@@ -92,37 +204,141 @@ func (r *sqliteRepository) GetByID(ctx context.Context, orderID string) (*domain
 	order := &domain.StockOrder{}
 	err := r.db.QueryRowContext(ctx, query, orderID).Scan(
 		&order.ID,
+		&order.AccountID,
+		&order.ClientOrderID,
 		&order.Symbol,
 		&order.OrderType,
 		&order.OrderSide,
 		&order.Quantity,
 		&order.Price,
 		&order.Status,
+		&order.FilledQuantity,
+		&order.AveragePrice,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 		&order.Description,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("order not found: %s", orderID)
+		return nil, domain.NewNotFoundError("order", orderID)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get order: %w", err)
+		return nil, domain.NewUnavailableError(err, "failed to get order")
 	}
 
 	return order, nil
 }
 
-func (r *sqliteRepository) List(ctx context.Context) ([]*domain.StockOrder, error) {
+func (r *sqliteRepository) GetByClientOrderID(ctx context.Context, accountID, clientOrderID string) (*domain.StockOrder, error) {
+	defer r.queries.observe("get_by_client_order_id")()
+
 	query := `
-		SELECT id, symbol, order_type, order_side, quantity, price, status, created_at, updated_at, description
+		SELECT id, account_id, client_order_id, symbol, order_type, order_side, quantity, price, status, filled_quantity, average_price, created_at, updated_at, description, request_fingerprint
 		FROM stock_orders
-		ORDER BY created_at DESC
+		WHERE account_id = ? AND client_order_id = ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	order := &domain.StockOrder{}
+	err := r.db.QueryRowContext(ctx, query, accountID, clientOrderID).Scan(
+		&order.ID,
+		&order.AccountID,
+		&order.ClientOrderID,
+		&order.Symbol,
+		&order.OrderType,
+		&order.OrderSide,
+		&order.Quantity,
+		&order.Price,
+		&order.Status,
+		&order.FilledQuantity,
+		&order.AveragePrice,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.Description,
+		&order.RequestFingerprint,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list orders: %w", err)
+		return nil, domain.NewUnavailableError(err, "failed to get order by client order ID")
+	}
+
+	return order, nil
+}
+
+// List returns one page of orders using keyset pagination on (created_at, id).
+// The symbol, status and created_at filters are served by their indexes.
+func (r *sqliteRepository) List(ctx context.Context, query domain.OrderQuery) (*domain.OrderPage, error) {
+	defer r.queries.observe("list")()
+
+	conditions := []string{}
+	args := []any{}
+
+	if query.AccountID != "" {
+		conditions = append(conditions, "account_id = ?")
+		args = append(args, query.AccountID)
+	}
+	if query.Symbol != "" {
+		conditions = append(conditions, "symbol = ?")
+		args = append(args, query.Symbol)
+	}
+	if query.OrderSide != "" {
+		conditions = append(conditions, "order_side = ?")
+		args = append(args, query.OrderSide)
+	}
+	if query.OrderType != "" {
+		conditions = append(conditions, "order_type = ?")
+		args = append(args, query.OrderType)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	// Timestamps are stored as text in the server's zone, so bounds are
+	// converted to it to compare correctly
+	if !query.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, query.CreatedFrom.In(time.Local))
+	}
+	if !query.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, query.CreatedTo.In(time.Local))
+	}
+
+	direction, comparison := "DESC", "<"
+	if query.Sort == domain.OrderSortCreatedAtAsc {
+		direction, comparison = "ASC", ">"
+	}
+
+	if query.PageToken != "" {
+		cursor, err := decodeOrderCursor(query.PageToken, query.Sort)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, fmt.Sprintf("(created_at %s ? OR (created_at = ? AND id %s ?))", comparison, comparison))
+		createdAt := cursor.CreatedAt.In(time.Local)
+		args = append(args, createdAt, createdAt, cursor.ID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// One extra row tells whether there is another page
+	sqlQuery := fmt.Sprintf(`
+		SELECT id, account_id, client_order_id, symbol, order_type, order_side, quantity, price, status, filled_quantity, average_price, created_at, updated_at, description
+		FROM stock_orders
+		%s
+		ORDER BY created_at %s, id %s
+		LIMIT ?
+	`, where, direction, direction)
+	args = append(args, query.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, domain.NewUnavailableError(err, "failed to list orders")
 	}
 	defer rows.Close()
 
@@ -131,34 +347,71 @@ func (r *sqliteRepository) List(ctx context.Context) ([]*domain.StockOrder, erro
 		order := &domain.StockOrder{}
 		err := rows.Scan(
 			&order.ID,
+			&order.AccountID,
+			&order.ClientOrderID,
 			&order.Symbol,
 			&order.OrderType,
 			&order.OrderSide,
 			&order.Quantity,
 			&order.Price,
 			&order.Status,
+			&order.FilledQuantity,
+			&order.AveragePrice,
 			&order.CreatedAt,
 			&order.UpdatedAt,
 			&order.Description,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan order: %w", err)
+			return nil, domain.NewUnavailableError(err, "failed to scan order")
 		}
 		orders = append(orders, order)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating orders: %w", err)
+		return nil, domain.NewUnavailableError(err, "error iterating orders")
 	}
 
-	return orders, nil
+	page := &domain.OrderPage{Orders: orders}
+	if len(orders) > query.Limit {
+		page.Orders = orders[:query.Limit]
+		last := page.Orders[len(page.Orders)-1]
+		page.NextPageToken = encodeOrderCursor(orderCursor{Sort: query.Sort, CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+// orderCursor is the position after the last order of a page
+type orderCursor struct {
+	Sort      domain.OrderSort `json:"s"`
+	CreatedAt time.Time        `json:"t"`
+	ID        string           `json:"id"`
+}
+
+func encodeOrderCursor(cursor orderCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeOrderCursor(token string, sort domain.OrderSort) (orderCursor, error) {
+	var cursor orderCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || json.Unmarshal(data, &cursor) != nil || cursor.ID == "" {
+		return cursor, domain.NewValidationError("page_token", "invalid page token")
+	}
+	if cursor.Sort != sort {
+		return cursor, domain.NewValidationError("page_token", "page token was issued for sort order %s", cursor.Sort)
+	}
+	return cursor, nil
 }
 
 func (r *sqliteRepository) Update(ctx context.Context, order *domain.StockOrder) error {
+	defer r.queries.observe("update")()
+
 	query := `
 		UPDATE stock_orders
 		SET symbol = ?, order_type = ?, order_side = ?, quantity = ?, price = ?,
-		    status = ?, updated_at = ?, description = ?
+		    status = ?, filled_quantity = ?, average_price = ?, updated_at = ?, description = ?
 		WHERE id = ?
 	`
 
@@ -169,24 +422,28 @@ func (r *sqliteRepository) Update(ctx context.Context, order *domain.StockOrder)
 		order.Quantity,
 		order.Price,
 		order.Status,
+		order.FilledQuantity,
+		order.AveragePrice,
 		order.UpdatedAt,
 		order.Description,
 		order.ID,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update order: %w", err)
+		return domain.NewUnavailableError(err, "failed to update order")
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return domain.NewUnavailableError(err, "failed to get rows affected")
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("order not found: %s", order.ID)
+		return domain.NewNotFoundError("order", order.ID)
 	}
 
+	slog.DebugContext(ctx, "Order updated", "component", "SQLiteRepository",
+		"order_id", order.ID, "status", order.Status, "filled_quantity", order.FilledQuantity)
 	return nil
 }
 
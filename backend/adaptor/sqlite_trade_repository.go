@@ -0,0 +1,177 @@
+package adaptor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+type sqliteTradeRepository struct {
+	db      *sql.DB
+	queries queryObserver
+}
+
+func NewSQLiteTradeRepository(dbPath string, opts ...SQLiteOption) (port.TradeRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	repo := &sqliteTradeRepository{db: db, queries: newQueryObserver(db, "trades", opts)}
+	if err := repo.initSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	return repo, nil
+}
+
+func (r *sqliteTradeRepository) initSchema() error {
+	// executed_at is stored as unix nanoseconds so fills sort in execution order
+	query := `
+	CREATE TABLE IF NOT EXISTS trades (
+		id TEXT PRIMARY KEY,
+		symbol TEXT NOT NULL,
+		price REAL NOT NULL,
+		quantity INTEGER NOT NULL,
+		buy_order_id TEXT NOT NULL,
+		sell_order_id TEXT NOT NULL,
+		executed_at INTEGER NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_trades_buy_order_id ON trades(buy_order_id);
+	CREATE INDEX IF NOT EXISTS idx_trades_sell_order_id ON trades(sell_order_id);
+	`
+
+	if _, err := r.db.Exec(query); err != nil {
+		return err
+	}
+	return addColumnIfMissing(r.db, "trades", "fee", "REAL NOT NULL DEFAULT 0")
+}
+
+func (r *sqliteTradeRepository) Save(ctx context.Context, trade domain.Trade) error {
+	defer r.queries.observe("save")()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO trades (id, symbol, price, quantity, buy_order_id, sell_order_id, fee, executed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		trade.ID,
+		trade.Symbol,
+		trade.Price,
+		trade.Quantity,
+		trade.BuyOrderID,
+		trade.SellOrderID,
+		trade.Fee,
+		trade.ExecutedAt.UnixNano(),
+	)
+	if err != nil {
+		return domain.NewUnavailableError(err, "failed to save trade")
+	}
+
+	return nil
+}
+
+func (r *sqliteTradeRepository) ListByOrderID(ctx context.Context, orderID string) ([]domain.Trade, error) {
+	defer r.queries.observe("list_by_order_id")()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, symbol, price, quantity, buy_order_id, sell_order_id, fee, executed_at
+		FROM trades
+		WHERE buy_order_id = ? OR sell_order_id = ?
+		ORDER BY executed_at ASC
+	`, orderID, orderID)
+	if err != nil {
+		return nil, domain.NewUnavailableError(err, "failed to list trades")
+	}
+	return scanTrades(rows)
+}
+
+func (r *sqliteTradeRepository) ListByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]domain.Trade, error) {
+	byOrder := map[string][]domain.Trade{}
+	if len(orderIDs) == 0 {
+		return byOrder, nil
+	}
+	defer r.queries.observe("list_by_order_ids")()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(orderIDs)), ", ")
+	args := make([]any, 0, 2*len(orderIDs))
+	for _, orderID := range orderIDs {
+		args = append(args, orderID)
+	}
+	args = append(args, args...)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, symbol, price, quantity, buy_order_id, sell_order_id, fee, executed_at
+		FROM trades
+		WHERE buy_order_id IN (`+placeholders+`) OR sell_order_id IN (`+placeholders+`)
+		ORDER BY executed_at ASC
+	`, args...)
+	if err != nil {
+		return nil, domain.NewUnavailableError(err, "failed to list trades")
+	}
+	trades, err := scanTrades(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both sides of a trade may be on the page
+	requested := make(map[string]bool, len(orderIDs))
+	for _, orderID := range orderIDs {
+		requested[orderID] = true
+	}
+	for _, trade := range trades {
+		if requested[trade.BuyOrderID] {
+			byOrder[trade.BuyOrderID] = append(byOrder[trade.BuyOrderID], trade)
+		}
+		if requested[trade.SellOrderID] {
+			byOrder[trade.SellOrderID] = append(byOrder[trade.SellOrderID], trade)
+		}
+	}
+	return byOrder, nil
+}
+
+// scanTrades reads and closes rows of trades
+func scanTrades(rows *sql.Rows) ([]domain.Trade, error) {
+	defer rows.Close()
+
+	trades := []domain.Trade{}
+	for rows.Next() {
+		var trade domain.Trade
+		var executedAt int64
+		err := rows.Scan(
+			&trade.ID,
+			&trade.Symbol,
+			&trade.Price,
+			&trade.Quantity,
+			&trade.BuyOrderID,
+			&trade.SellOrderID,
+			&trade.Fee,
+			&executedAt,
+		)
+		if err != nil {
+			return nil, domain.NewUnavailableError(err, "failed to scan trade")
+		}
+		trade.ExecutedAt = time.Unix(0, executedAt).UTC()
+		trades = append(trades, trade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, domain.NewUnavailableError(err, "error iterating trades")
+	}
+
+	return trades, nil
+}
+
+func (r *sqliteTradeRepository) Close() error {
+	return r.db.Close()
+}
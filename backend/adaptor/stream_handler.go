@@ -0,0 +1,414 @@
+package adaptor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+const (
+	streamHeartbeatPeriod = 15 * time.Second
+	streamWriteTimeout    = 10 * time.Second
+	wsPongTimeout         = 45 * time.Second
+	wsSendBufferSize      = 256
+	wsMaxCommandSize      = 4096
+)
+
+// checkOrigin accepts WebSocket handshakes from clients that send no Origin,
+// which are not browsers, from pages of the server's own host and from the
+// allowed origins. Browsers cannot set API key headers on a WebSocket, so
+// any page could otherwise stream orders with the rights of anonymous callers.
+func (h *HTTPHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if h.allowedOrigins["*"] || h.allowedOrigins[origin] {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// streamRegistry lets long-lived stream connections end when the server shuts down
+type streamRegistry struct {
+	shutdown  chan struct{}
+	closeOnce sync.Once
+}
+
+func newStreamRegistry() *streamRegistry {
+	return &streamRegistry{shutdown: make(chan struct{})}
+}
+
+// CloseStreams asks every SSE and WebSocket connection to finish. Register it
+// with http.Server.RegisterOnShutdown, because Shutdown neither waits for
+// hijacked connections nor interrupts streaming responses.
+func (h *HTTPHandler) CloseStreams() {
+	h.streams.closeOnce.Do(func() {
+		slog.Info("Closing SSE and WebSocket streams...")
+		close(h.streams.shutdown)
+	})
+}
+
+// StreamOrders serves order events and quotes as Server-Sent Events.
+// Query parameters: symbol filters orders, quotes is a comma separated list
+// of symbols to stream quotes for and from_sequence resumes the order stream.
+// A Last-Event-ID header takes precedence over from_sequence.
+func (h *HTTPHandler) StreamOrders(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	filter := domain.OrderEventFilter{Symbol: params.Get("symbol")}
+
+	if value := params.Get("from_sequence"); value != "" {
+		sequence, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			respondError(w, domain.NewValidationError("from_sequence", "invalid from_sequence"))
+			return
+		}
+		filter.FromSequence = sequence
+	}
+	if value := r.Header.Get("Last-Event-ID"); value != "" {
+		sequence, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			respondError(w, domain.NewValidationError("Last-Event-ID", "invalid Last-Event-ID"))
+			return
+		}
+		filter.FromSequence = sequence + 1
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	sub, err := h.service.WatchOrders(ctx, filter)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	var quotes <-chan domain.SymbolQuote
+	if symbols := splitSymbols(params.Get("quotes")); len(symbols) > 0 {
+		if h.marketData == nil {
+			respondError(w, domain.NewUnavailableError(nil, "market data is not configured"))
+			return
+		}
+		if quotes, err = h.marketData.SubscribeQuotes(ctx, symbols); err != nil {
+			respondError(w, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// A client that stops reading blocks writes; the deadline drops it
+	rc := http.NewResponseController(w)
+	send := func(event, id string, payload any) bool {
+		rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if err := writeSSE(w, event, id, payload); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatPeriod)
+	defer heartbeat.Stop()
+
+	events := sub.Events()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if err := sub.Err(); err != nil {
+					send("error", "", newErrorResponse(err))
+				} else if ctx.Err() == nil {
+					send("shutdown", "", SuccessResponse{Message: "server shutting down"})
+				}
+				return
+			}
+			if !send("order", strconv.FormatUint(event.Sequence, 10), event) {
+				return
+			}
+		case quote, ok := <-quotes:
+			if !ok {
+				quotes = nil
+				continue
+			}
+			if !send("quote", "", quote) {
+				return
+			}
+		case <-heartbeat.C:
+			rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+		case <-h.streams.shutdown:
+			send("shutdown", "", SuccessResponse{Message: "server shutting down"})
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, event, id string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+func splitSymbols(value string) []string {
+	symbols := []string{}
+	for _, symbol := range strings.Split(value, ",") {
+		if symbol = strings.TrimSpace(symbol); symbol != "" {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// wsCommand is sent by WebSocket clients to manage their subscriptions, e.g.
+// {"action": "subscribe", "channel": "orders", "symbol": "AAPL", "from_sequence": 42}
+// {"action": "subscribe", "channel": "quotes", "symbols": ["AAPL", "MSFT"]}
+// {"action": "unsubscribe", "channel": "quotes"}
+type wsCommand struct {
+	Action       string   `json:"action"`
+	Channel      string   `json:"channel"`
+	Symbol       string   `json:"symbol,omitempty"`
+	Symbols      []string `json:"symbols,omitempty"`
+	FromSequence uint64   `json:"from_sequence,omitempty"`
+}
+
+type wsMessage struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel,omitempty"`
+	Data    any    `json:"data,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+type wsClient struct {
+	handler *HTTPHandler
+	conn    *websocket.Conn
+	ctx     context.Context
+	cancel  context.CancelFunc
+	send    chan wsMessage
+
+	mu            sync.Mutex
+	subscriptions map[string]context.CancelFunc
+	closeCode     int
+	closeReason   string
+}
+
+// ServeWebSocket upgrades the connection and streams order events and quotes
+// according to the subscriptions requested by the client
+func (h *HTTPHandler) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     h.checkOrigin,
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already written an error response
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	client := &wsClient{
+		handler:       h,
+		conn:          conn,
+		ctx:           ctx,
+		cancel:        cancel,
+		send:          make(chan wsMessage, wsSendBufferSize),
+		subscriptions: map[string]context.CancelFunc{},
+	}
+
+	go client.readLoop()
+	client.writeLoop()
+}
+
+// readLoop handles client commands until the connection fails
+func (c *wsClient) readLoop() {
+	defer c.cancel()
+
+	c.conn.SetReadLimit(wsMaxCommandSize)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		var cmd wsCommand
+		if err := c.conn.ReadJSON(&cmd); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				c.enqueue(wsMessage{Type: "error", Error: "invalid command", Code: classifyError(domain.ErrValidation).code})
+				continue
+			}
+			return
+		}
+
+		if err := c.handle(cmd); err != nil {
+			c.enqueue(wsMessage{Type: "error", Channel: cmd.Channel, Error: err.Error(), Code: classifyError(err).code})
+		}
+	}
+}
+
+func (c *wsClient) handle(cmd wsCommand) error {
+	switch cmd.Action {
+	case "subscribe":
+		subCtx, cancel := context.WithCancel(c.ctx)
+		if err := c.subscribe(subCtx, cmd); err != nil {
+			cancel()
+			return err
+		}
+		c.replaceSubscription(cmd.Channel, cancel)
+		c.enqueue(wsMessage{Type: "subscribed", Channel: cmd.Channel})
+	case "unsubscribe":
+		c.replaceSubscription(cmd.Channel, nil)
+		c.enqueue(wsMessage{Type: "unsubscribed", Channel: cmd.Channel})
+	default:
+		return domain.NewValidationError("action", "unknown action: %q", cmd.Action)
+	}
+	return nil
+}
+
+func (c *wsClient) subscribe(ctx context.Context, cmd wsCommand) error {
+	switch cmd.Channel {
+	case "orders":
+		sub, err := c.handler.service.WatchOrders(ctx, domain.OrderEventFilter{
+			Symbol:       cmd.Symbol,
+			FromSequence: cmd.FromSequence,
+		})
+		if err != nil {
+			return err
+		}
+		go c.forwardOrders(sub)
+	case "quotes":
+		if c.handler.marketData == nil {
+			return domain.NewUnavailableError(nil, "market data is not configured")
+		}
+		quotes, err := c.handler.marketData.SubscribeQuotes(ctx, cmd.Symbols)
+		if err != nil {
+			return err
+		}
+		go c.forwardQuotes(quotes)
+	default:
+		return domain.NewValidationError("channel", "unknown channel: %q", cmd.Channel)
+	}
+	return nil
+}
+
+// replaceSubscription cancels any existing subscription of the channel
+func (c *wsClient) replaceSubscription(channel string, cancel context.CancelFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if previous, ok := c.subscriptions[channel]; ok {
+		previous()
+		delete(c.subscriptions, channel)
+	}
+	if cancel != nil {
+		c.subscriptions[channel] = cancel
+	}
+}
+
+func (c *wsClient) forwardOrders(sub port.OrderEventSubscription) {
+	for event := range sub.Events() {
+		c.enqueue(wsMessage{Type: "order", Channel: "orders", Data: event})
+	}
+	if err := sub.Err(); err != nil {
+		c.enqueue(wsMessage{Type: "error", Channel: "orders", Error: err.Error(), Code: classifyError(err).code})
+	}
+}
+
+func (c *wsClient) forwardQuotes(quotes <-chan domain.SymbolQuote) {
+	for quote := range quotes {
+		c.enqueue(wsMessage{Type: "quote", Channel: "quotes", Data: quote})
+	}
+}
+
+// enqueue never blocks; a client whose send buffer is full is disconnected
+func (c *wsClient) enqueue(msg wsMessage) {
+	select {
+	case c.send <- msg:
+	case <-c.ctx.Done():
+	default:
+		c.closeWith(websocket.CloseTryAgainLater, "client too slow")
+	}
+}
+
+func (c *wsClient) closeWith(code int, reason string) {
+	c.mu.Lock()
+	if c.closeCode == 0 {
+		c.closeCode, c.closeReason = code, reason
+	}
+	c.mu.Unlock()
+	c.cancel()
+}
+
+// writeLoop owns all writes to the connection
+func (c *wsClient) writeLoop() {
+	ping := time.NewTicker(streamHeartbeatPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+				return
+			}
+		case <-c.handler.streams.shutdown:
+			c.writeClose(websocket.CloseGoingAway, "server shutting down")
+			return
+		case <-c.ctx.Done():
+			c.mu.Lock()
+			code, reason := c.closeCode, c.closeReason
+			c.mu.Unlock()
+			if code != 0 {
+				c.writeClose(code, reason)
+			}
+			return
+		}
+	}
+}
+
+func (c *wsClient) writeClose(code int, reason string) {
+	message := websocket.FormatCloseMessage(code, reason)
+	c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(streamWriteTimeout))
+}
@@ -0,0 +1,45 @@
+package adaptor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWebSocketChecksOrigin(t *testing.T) {
+	handler := NewHTTPHandler(nil, WithAllowedOrigins("https://dashboard.example.com"))
+	server := httptest.NewServer(http.HandlerFunc(handler.ServeWebSocket))
+	t.Cleanup(func() {
+		handler.CloseStreams()
+		server.Close()
+	})
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	for _, tc := range []struct {
+		origin string
+		want   int
+	}{
+		{"", http.StatusSwitchingProtocols},
+		{server.URL, http.StatusSwitchingProtocols},
+		{"https://dashboard.example.com", http.StatusSwitchingProtocols},
+		{"https://evil.example.com", http.StatusForbidden},
+	} {
+		header := http.Header{}
+		if tc.origin != "" {
+			header.Set("Origin", tc.origin)
+		}
+		conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+		if conn != nil {
+			conn.Close()
+		}
+		if resp == nil {
+			t.Fatalf("origin %q: handshake failed: %v", tc.origin, err)
+		}
+		if resp.StatusCode != tc.want {
+			t.Errorf("origin %q: status %d, want %d", tc.origin, resp.StatusCode, tc.want)
+		}
+	}
+}
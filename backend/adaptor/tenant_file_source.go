@@ -0,0 +1,61 @@
+package adaptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+type fileTenantSource struct {
+	path string
+}
+
+// NewFileTenantSource reads the tenants from a JSON file such as
+//
+//	{
+//	  "tenants": [
+//	    {
+//	      "id": "desk-a",
+//	      "name": "Equities Desk A",
+//	      "instruments": [{"symbol": "AAPL", "lot_size": 1, "tick_size": 0.01}],
+//	      "fees": {"rate_bps": 2.5, "minimum": 1},
+//	      "order_rate": {"rate": 5, "burst": 10}
+//	    }
+//	  ]
+//	}
+func NewFileTenantSource(path string) port.TenantSource {
+	return &fileTenantSource{path: path}
+}
+
+func (s *fileTenantSource) LoadTenants(ctx context.Context) ([]domain.Tenant, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants file: %w", err)
+	}
+
+	var file struct {
+		Tenants []domain.Tenant `json:"tenants"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse tenants file %s: %w", s.path, err)
+	}
+
+	seen := map[string]bool{}
+	for _, tenant := range file.Tenants {
+		if err := tenant.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid tenants file %s: %w", s.path, err)
+		}
+		if seen[tenant.ID] {
+			return nil, fmt.Errorf("invalid tenants file %s: tenant %s is listed twice", s.path, tenant.ID)
+		}
+		seen[tenant.ID] = true
+	}
+	return file.Tenants, nil
+}
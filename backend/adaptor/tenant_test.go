@@ -0,0 +1,358 @@
+package adaptor
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+	pbv2 "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// tenantTestServer serves desk-a and desk-b, each from a SQLite database of
+// its own, behind the real authentication and authorization layers
+type tenantTestServer struct {
+	router http.Handler
+	conn   *grpc.ClientConn
+	// keys holds the API key secrets by caller name
+	keys map[string]string
+}
+
+// tenantTestCallers are the API keys issued by the test server
+var tenantTestCallers = []struct {
+	name    string
+	tenant  string
+	account string
+	role    domain.Role
+}{
+	{"a-trader", "desk-a", "acct-1", domain.RoleTrader},
+	{"a-ops", "desk-a", "ops-1", domain.RoleOps},
+	{"b-trader", "desk-b", "acct-1", domain.RoleTrader},
+	{"b-trader-2", "desk-b", "acct-2", domain.RoleTrader},
+	{"b-ops", "desk-b", "ops-1", domain.RoleOps},
+	{"ghost", "ghost", "acct-1", domain.RoleTrader},
+}
+
+func newTenantTestServer(t *testing.T) *tenantTestServer {
+	t.Helper()
+	dir := t.TempDir()
+
+	tenants := map[string]port.StockOrderService{
+		"desk-a": newTenantTestService(t, filepath.Join(dir, "desk-a.db"), domain.FeeSchedule{}),
+		"desk-b": newTenantTestService(t, filepath.Join(dir, "desk-b.db"), domain.FeeSchedule{RateBps: 10, Minimum: 0.5}),
+	}
+	orders, err := service.NewAuthorizedStockOrderService(service.NewTenantStockOrderService(tenants), service.DefaultPolicy())
+	if err != nil {
+		t.Fatalf("failed to authorize order service: %v", err)
+	}
+
+	keyRepo, err := NewSQLiteAPIKeyRepository(filepath.Join(dir, "keys.db"))
+	if err != nil {
+		t.Fatalf("failed to open API key repository: %v", err)
+	}
+	t.Cleanup(func() { keyRepo.Close() })
+	auth := service.NewAuthService(keyRepo)
+
+	keys := map[string]string{}
+	for _, caller := range tenantTestCallers {
+		_, secret, err := auth.CreateAPIKey(context.Background(), domain.CreateAPIKeyRequest{
+			TenantID:  caller.tenant,
+			AccountID: caller.account,
+			Name:      caller.name,
+			Roles:     []string{string(caller.role)},
+		})
+		if err != nil {
+			t.Fatalf("failed to create API key %s: %v", caller.name, err)
+		}
+		keys[caller.name] = secret
+	}
+	authenticator := NewAuthenticator(auth, true)
+
+	router := mux.NewRouter()
+	router.Use(authenticator.Middleware)
+	NewHTTPHandler(orders).RegisterRoutes(router)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authenticator.UnaryInterceptor, AccountUnaryInterceptor),
+		grpc.ChainStreamInterceptor(authenticator.StreamInterceptor, AccountStreamInterceptor),
+	)
+	pb.RegisterStockOrderServiceServer(server, NewGRPCHandler(orders))
+	pbv2.RegisterStockOrderServiceServer(server, NewGRPCHandlerV2(orders))
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &tenantTestServer{router: router, conn: conn, keys: keys}
+}
+
+// newTenantTestService builds the order service of one tenant the way the
+// server does, without a session calendar so the market is always open
+func newTenantTestService(t *testing.T, dbPath string, fees domain.FeeSchedule) port.StockOrderService {
+	t.Helper()
+	repo, err := NewSQLiteRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open order repository: %v", err)
+	}
+	trades, err := NewSQLiteTradeRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open trade repository: %v", err)
+	}
+	instruments, err := NewSQLiteInstrumentRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open instrument repository: %v", err)
+	}
+
+	engine := service.NewMatchingEngine(repo, nil)
+	events := service.NewOrderEventHub(100)
+	orders := service.NewStockOrderService(repo,
+		service.WithMatchingEngine(engine),
+		service.WithOrderEventHub(events),
+		service.WithTradeRepository(trades),
+		service.WithInstrumentRepository(instruments),
+		service.WithFeeSchedule(fees),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		engine.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		events.Close()
+		cancel()
+		<-done
+		instruments.Close()
+		trades.Close()
+		repo.Close()
+	})
+	return orders
+}
+
+// do sends an HTTP request as caller, naming tenant in X-Tenant-ID when set
+func (s *tenantTestServer) do(t *testing.T, caller, tenant, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("X-API-Key", s.keys[caller])
+	if tenant != "" {
+		req.Header.Set("X-Tenant-ID", tenant)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// createOrder places a limit order of 10 AAPL at 100 over HTTP
+func (s *tenantTestServer) createOrder(t *testing.T, caller, side string) string {
+	t.Helper()
+	w := s.do(t, caller, "", "POST", "/api/orders", `{"symbol":"AAPL","order_type":"LIMIT","order_side":"`+side+`","quantity":10,"price":100}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("%s failed to create order: %d %s", caller, w.Code, w.Body.String())
+	}
+	var order domain.StockOrder
+	if err := json.Unmarshal(w.Body.Bytes(), &order); err != nil {
+		t.Fatalf("failed to decode order: %v", err)
+	}
+	return order.ID
+}
+
+// grpcContext authenticates gRPC calls as caller
+func (s *tenantTestServer) grpcContext(caller string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", s.keys[caller])
+}
+
+func TestTenantsCannotReachOtherTenantsOrders(t *testing.T) {
+	s := newTenantTestServer(t)
+	orderID := s.createOrder(t, "a-trader", "BUY")
+
+	// The same account ID and the all-accounts access of ops stop at the tenant
+	for _, caller := range []string{"b-trader", "b-ops"} {
+		for _, tt := range []struct{ method, target string }{
+			{"GET", "/api/orders/" + orderID},
+			{"POST", "/api/orders/" + orderID + "/cancel"},
+		} {
+			if w := s.do(t, caller, "", tt.method, tt.target, ""); w.Code != http.StatusNotFound {
+				t.Errorf("%s %s %s: got status %d, want 404: %s", caller, tt.method, tt.target, w.Code, w.Body.String())
+			}
+		}
+
+		w := s.do(t, caller, "", "GET", "/api/orders", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s failed to list orders: %d %s", caller, w.Code, w.Body.String())
+		}
+		if strings.Contains(w.Body.String(), orderID) {
+			t.Errorf("%s lists an order of desk-a: %s", caller, w.Body.String())
+		}
+
+		ctx := s.grpcContext(caller)
+		if _, err := pb.NewStockOrderServiceClient(s.conn).GetOrder(ctx, &pb.GetOrderRequest{OrderId: orderID}); status.Code(err) != codes.NotFound {
+			t.Errorf("%s v1 GetOrder: got %v, want NotFound", caller, err)
+		}
+		if _, err := pbv2.NewStockOrderServiceClient(s.conn).GetOrder(ctx, &pbv2.GetOrderRequest{OrderId: orderID}); status.Code(err) != codes.NotFound {
+			t.Errorf("%s v2 GetOrder: got %v, want NotFound", caller, err)
+		}
+		if _, err := pbv2.NewStockOrderServiceClient(s.conn).CancelOrder(ctx, &pbv2.CancelOrderRequest{OrderId: orderID}); status.Code(err) != codes.NotFound {
+			t.Errorf("%s v2 CancelOrder: got %v, want NotFound", caller, err)
+		}
+	}
+
+	// A mass cancel by desk-b ops leaves desk-a alone
+	if w := s.do(t, "b-ops", "", "POST", "/api/orders/cancel-all", ""); w.Code != http.StatusOK {
+		t.Fatalf("b-ops failed to mass cancel: %d %s", w.Code, w.Body.String())
+	}
+	w := s.do(t, "a-trader", "", "GET", "/api/orders/"+orderID, "")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), `"CANCELLED"`) {
+		t.Errorf("desk-a order after desk-b mass cancel: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestTenantHeaderCannotSwitchTenant(t *testing.T) {
+	s := newTenantTestServer(t)
+	orderID := s.createOrder(t, "a-trader", "BUY")
+
+	if w := s.do(t, "b-trader", "desk-a", "GET", "/api/orders/"+orderID, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("X-Tenant-ID of another tenant: got status %d, want 401: %s", w.Code, w.Body.String())
+	}
+	if w := s.do(t, "a-trader", "desk-a", "GET", "/api/orders/"+orderID, ""); w.Code != http.StatusOK {
+		t.Errorf("X-Tenant-ID of the own tenant: got status %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	ctx := metadata.AppendToOutgoingContext(s.grpcContext("b-trader"), "x-tenant-id", "desk-a")
+	if _, err := pb.NewStockOrderServiceClient(s.conn).GetOrder(ctx, &pb.GetOrderRequest{OrderId: orderID}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("x-tenant-id of another tenant: got %v, want Unauthenticated", err)
+	}
+
+	// Keys of a tenant the server does not serve reach nothing
+	if w := s.do(t, "ghost", "", "GET", "/api/orders", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown tenant: got status %d, want 404: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestTenantsDoNotMatchAcrossTenants(t *testing.T) {
+	s := newTenantTestServer(t)
+
+	// b-trader uses the same account ID as the desk-a watcher
+	watchCtx, cancel := context.WithTimeout(s.grpcContext("a-trader"), 10*time.Second)
+	defer cancel()
+	stream, err := pb.NewStockOrderServiceClient(s.conn).WatchOrders(watchCtx, &pb.WatchOrdersRequest{})
+	if err != nil {
+		t.Fatalf("failed to watch orders: %v", err)
+	}
+	watched := make(chan string, 100)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				close(watched)
+				return
+			}
+			watched <- event.GetOrder().GetId()
+		}
+	}()
+	// Let the subscription register before placing orders
+	time.Sleep(100 * time.Millisecond)
+
+	buyA := s.createOrder(t, "a-trader", "BUY")
+	sellB := s.createOrder(t, "b-trader-2", "SELL")
+	buyB := s.createOrder(t, "b-trader", "BUY")
+
+	// desk-b matches within itself
+	client := pbv2.NewStockOrderServiceClient(s.conn)
+	var filled *pbv2.Order
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		order, err := client.GetOrder(s.grpcContext("b-trader"), &pbv2.GetOrderRequest{OrderId: buyB})
+		if err != nil {
+			t.Fatalf("failed to get desk-b order: %v", err)
+		}
+		if order.GetStatus() == pbv2.OrderStatus_ORDER_STATUS_FILLED {
+			filled = order
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if filled == nil {
+		t.Fatal("desk-b orders did not match each other")
+	}
+	if fills := filled.GetFills(); len(fills) != 1 || fills[0].GetFee() != "1" {
+		t.Errorf("desk-b fills: got %v, want one fill with fee 1", fills)
+	}
+
+	// The desk-a bid at the same price was not crossed by the desk-b offer
+	order, err := client.GetOrder(s.grpcContext("a-trader"), &pbv2.GetOrderRequest{OrderId: buyA})
+	if err != nil {
+		t.Fatalf("failed to get desk-a order: %v", err)
+	}
+	if order.GetFilledQuantity() != 0 || len(order.GetFills()) != 0 {
+		t.Errorf("desk-a order was filled by another tenant: %v", order)
+	}
+
+	// Events of desk-b orders never reach desk-a watchers
+	cancel()
+	seen := []string{}
+	for id := range watched {
+		seen = append(seen, id)
+	}
+	if !slices.Contains(seen, buyA) {
+		t.Errorf("desk-a watcher missed its own order %s, saw %v", buyA, seen)
+	}
+	if slices.Contains(seen, sellB) || slices.Contains(seen, buyB) {
+		t.Errorf("desk-a watcher saw desk-b orders: %v", seen)
+	}
+}
+
+func TestTenantsHaveSeparateInstruments(t *testing.T) {
+	s := newTenantTestServer(t)
+
+	if w := s.do(t, "a-ops", "", "POST", "/api/admin/symbols/AAPL/halt", `{"reason":"news pending"}`); w.Code != http.StatusOK {
+		t.Fatalf("failed to halt AAPL for desk-a: %d %s", w.Code, w.Body.String())
+	}
+	if w := s.do(t, "a-ops", "", "PUT", "/api/admin/instruments/MSFT", `{"name":"Microsoft","lot_size":1,"tick_size":0.01}`); w.Code != http.StatusOK {
+		t.Fatalf("failed to register MSFT for desk-a: %d %s", w.Code, w.Body.String())
+	}
+
+	w := s.do(t, "a-trader", "", "POST", "/api/orders", `{"symbol":"AAPL","order_type":"LIMIT","order_side":"BUY","quantity":10,"price":100}`)
+	if w.Code != http.StatusConflict {
+		t.Errorf("order on halted desk-a AAPL: got status %d, want 409: %s", w.Code, w.Body.String())
+	}
+	s.createOrder(t, "b-trader", "BUY")
+
+	w = s.do(t, "b-trader", "", "GET", "/api/instruments", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("failed to list desk-b instruments: %d %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "MSFT") || strings.Contains(w.Body.String(), "AAPL") {
+		t.Errorf("desk-b lists desk-a instruments: %s", w.Body.String())
+	}
+}
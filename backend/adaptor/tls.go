@@ -0,0 +1,276 @@
+package adaptor
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultReloadInterval is how often certificate files are checked for
+// changes unless configured otherwise
+const defaultReloadInterval = 10 * time.Second
+
+// TLSOptions configures the TLS of a server. The certificate, key and client
+// CA files are checked for changes every ReloadInterval and swapped in when
+// they change, so rotated certificates take effect without a restart. With
+// ClientCAFile set, client certificates are verified against it according
+// to ClientAuth.
+type TLSOptions struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	ClientAuth   tls.ClientAuthType
+	// MinVersion defaults to TLS 1.2
+	MinVersion uint16
+	// ReloadInterval defaults to 10 seconds
+	ReloadInterval time.Duration
+}
+
+// ClientTLSOptions configures the TLS of a client. CAFile replaces the system
+// roots for verifying the server; CertFile and KeyFile present a client
+// certificate for mutual TLS and are reloaded like the server's.
+type ClientTLSOptions struct {
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+	// MinVersion defaults to TLS 1.2
+	MinVersion uint16
+	// ReloadInterval defaults to 10 seconds
+	ReloadInterval time.Duration
+}
+
+// NewServerTLSConfig returns a TLS config serving the certificate of opts.
+// It works for both the HTTP and the gRPC server.
+func NewServerTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, errors.New("TLS requires a certificate and a key file")
+	}
+	if opts.ClientCAFile == "" && opts.ClientAuth >= tls.VerifyClientCertIfGiven {
+		return nil, errors.New("verifying client certificates requires a client CA file")
+	}
+
+	files := newCertificateFiles(opts.CertFile, opts.KeyFile, opts.ClientCAFile, opts.ReloadInterval)
+	if _, _, err := files.load(); err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion: opts.MinVersion,
+		ClientAuth: opts.ClientAuth,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _, err := files.load()
+			return cert, err
+		},
+	}
+	if config.MinVersion == 0 {
+		config.MinVersion = tls.VersionTLS12
+	}
+
+	// ClientCAs cannot change once the config is in use, so client
+	// certificates are verified against the current CAs after the handshake
+	switch opts.ClientAuth {
+	case tls.VerifyClientCertIfGiven:
+		config.ClientAuth = tls.RequestClientCert
+		config.VerifyConnection = files.verifyClient
+	case tls.RequireAndVerifyClientCert:
+		config.ClientAuth = tls.RequireAnyClientCert
+		config.VerifyConnection = files.verifyClient
+	}
+	return config, nil
+}
+
+// NewClientTLSConfig returns a TLS config for connecting to a server set up
+// with NewServerTLSConfig
+func NewClientTLSConfig(opts ClientTLSOptions) (*tls.Config, error) {
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return nil, errors.New("a client certificate requires both a certificate and a key file")
+	}
+
+	config := &tls.Config{
+		MinVersion: opts.MinVersion,
+		ServerName: opts.ServerName,
+	}
+	if config.MinVersion == 0 {
+		config.MinVersion = tls.VersionTLS12
+	}
+
+	if opts.CAFile != "" {
+		pool, err := loadCertPool(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	if opts.CertFile != "" {
+		files := newCertificateFiles(opts.CertFile, opts.KeyFile, "", opts.ReloadInterval)
+		if _, _, err := files.load(); err != nil {
+			return nil, err
+		}
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _, err := files.load()
+			return cert, err
+		}
+	}
+	return config, nil
+}
+
+// ParseTLSVersion converts "1.2" or "1.3" to a TLS version; empty means TLS 1.2
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q, must be 1.2 or 1.3", version)
+	}
+}
+
+// certificateFiles caches a key pair and an optional CA pool loaded from
+// files. At most once per interval the files are checked for a new
+// modification time or size and reloaded when they changed. A change that
+// fails to load, such as a certificate written before its key, keeps the
+// previous certificate in use until the files change again.
+type certificateFiles struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	interval time.Duration
+
+	mu      sync.Mutex
+	checked time.Time
+	stamps  []fileStamp
+	failure string
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+// fileStamp tells whether a file changed since it was last loaded
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func newCertificateFiles(certFile, keyFile, caFile string, interval time.Duration) *certificateFiles {
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+	return &certificateFiles{certFile: certFile, keyFile: keyFile, caFile: caFile, interval: interval}
+}
+
+func (f *certificateFiles) load() (*tls.Certificate, *x509.CertPool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cert != nil && time.Since(f.checked) < f.interval {
+		return f.cert, f.pool, nil
+	}
+	f.checked = time.Now()
+
+	stamps, err := f.stat()
+	if err == nil && f.cert != nil && slices.Equal(stamps, f.stamps) {
+		return f.cert, f.pool, nil
+	}
+	reload := f.cert != nil
+	if err == nil {
+		err = f.parse()
+	}
+	if err != nil {
+		if f.cert == nil {
+			return nil, nil, err
+		}
+		// Files that stay broken are retried once they change, and the
+		// failure is only logged when it is new
+		f.stamps = stamps
+		if err.Error() != f.failure {
+			f.failure = err.Error()
+			slog.Warn("Keeping the current certificate, reload failed", "component", "TLS", "error", err)
+		}
+		return f.cert, f.pool, nil
+	}
+
+	if reload {
+		slog.Info("Reloaded certificate", "component", "TLS", "path", f.certFile)
+	}
+	f.stamps = stamps
+	f.failure = ""
+	return f.cert, f.pool, nil
+}
+
+func (f *certificateFiles) stat() ([]fileStamp, error) {
+	stamps := []fileStamp{}
+	for _, file := range []string{f.certFile, f.keyFile, f.caFile} {
+		if file == "" {
+			stamps = append(stamps, fileStamp{})
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		stamps = append(stamps, fileStamp{modTime: info.ModTime(), size: info.Size()})
+	}
+	return stamps, nil
+}
+
+// verifyClient verifies the client certificate of a connection against the
+// current client CAs
+func (f *certificateFiles) verifyClient(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		// ClientAuth has already rejected connections that need one
+		return nil
+	}
+	_, pool, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range state.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err = state.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+func (f *certificateFiles) parse() error {
+	cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load key pair %s, %s: %w", f.certFile, f.keyFile, err)
+	}
+
+	var pool *x509.CertPool
+	if f.caFile != "" {
+		if pool, err = loadCertPool(f.caFile); err != nil {
+			return err
+		}
+	}
+
+	f.cert = &cert
+	f.pool = pool
+	return nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates in %s", file)
+	}
+	return pool, nil
+}
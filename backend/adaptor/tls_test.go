@@ -0,0 +1,326 @@
+package adaptor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testCA issues certificates for the TLS tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T, dir, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+
+	file := filepath.Join(dir, name+".pem")
+	writePEM(t, file, "CERTIFICATE", der)
+	return &testCA{cert: cert, key: key, file: file}
+}
+
+// issue writes a certificate for localhost and its key to dir, returning
+// their paths
+func (ca *testCA) issue(t *testing.T, dir, name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", file, err)
+	}
+}
+
+// serveHTTPS serves an empty handler with config and returns its address
+func serveHTTPS(t *testing.T, config *tls.Config) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &http.Server{
+		Handler:   http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		TLSConfig: config,
+	}
+	go server.ServeTLS(lis, "", "")
+	t.Cleanup(func() { server.Close() })
+	return lis.Addr().String()
+}
+
+// peerSerial connects with a new connection and returns the serial number of
+// the server's certificate
+func peerSerial(t *testing.T, addr string, config *tls.Config) int64 {
+	t.Helper()
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: config, DisableKeepAlives: true}}
+	resp, err := client.Get("https://" + addr)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	return resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+}
+
+func TestTLSMutualAuthenticationForGRPC(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	otherCA := newTestCA(t, dir, "other-ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", 10, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, dir, "client", 11, x509.ExtKeyUsageClientAuth)
+	strangerCert, strangerKey := otherCA.issue(t, dir, "stranger", 12, x509.ExtKeyUsageClientAuth)
+
+	serverConfig, err := NewServerTLSConfig(TLSOptions{
+		CertFile:     serverCert,
+		KeyFile:      serverKey,
+		ClientCAFile: ca.file,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	if err != nil {
+		t.Fatalf("failed to create server TLS config: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverConfig)))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	defer server.Stop()
+
+	tests := []struct {
+		name    string
+		opts    ClientTLSOptions
+		wantErr bool
+	}{
+		{"client certificate", ClientTLSOptions{CAFile: ca.file, CertFile: clientCert, KeyFile: clientKey}, false},
+		{"no client certificate", ClientTLSOptions{CAFile: ca.file}, true},
+		{"certificate of another CA", ClientTLSOptions{CAFile: ca.file, CertFile: strangerCert, KeyFile: strangerKey}, true},
+		{"server not trusted", ClientTLSOptions{CAFile: otherCA.file, CertFile: clientCert, KeyFile: clientKey}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := NewClientTLSConfig(tt.opts)
+			if err != nil {
+				t.Fatalf("failed to create client TLS config: %v", err)
+			}
+			conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(config)))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check returned %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSServesHTTPS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", 10, x509.ExtKeyUsageServerAuth)
+
+	tests := []struct {
+		name          string
+		minVersion    uint16
+		clientVersion uint16
+		wantErr       bool
+	}{
+		{"TLS 1.2", tls.VersionTLS12, tls.VersionTLS12, false},
+		{"TLS 1.3", tls.VersionTLS13, tls.VersionTLS13, false},
+		{"below minimum version", tls.VersionTLS13, tls.VersionTLS12, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverConfig, err := NewServerTLSConfig(TLSOptions{CertFile: serverCert, KeyFile: serverKey, MinVersion: tt.minVersion})
+			if err != nil {
+				t.Fatalf("failed to create server TLS config: %v", err)
+			}
+			addr := serveHTTPS(t, serverConfig)
+
+			clientConfig, err := NewClientTLSConfig(ClientTLSOptions{CAFile: ca.file})
+			if err != nil {
+				t.Fatalf("failed to create client TLS config: %v", err)
+			}
+			clientConfig.MaxVersion = tt.clientVersion
+
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig, ForceAttemptHTTP2: true}}
+			resp, err := client.Get("https://" + addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GET returned %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			resp.Body.Close()
+			if resp.TLS.Version != tt.clientVersion {
+				t.Errorf("negotiated version %x, want %x", resp.TLS.Version, tt.clientVersion)
+			}
+			if resp.ProtoMajor != 2 {
+				t.Errorf("negotiated %s, want HTTP/2", resp.Proto)
+			}
+		})
+	}
+}
+
+func TestTLSReloadsRotatedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", 10, x509.ExtKeyUsageServerAuth)
+
+	// Every handshake checks the files
+	serverConfig, err := NewServerTLSConfig(TLSOptions{CertFile: serverCert, KeyFile: serverKey, ReloadInterval: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("failed to create server TLS config: %v", err)
+	}
+	addr := serveHTTPS(t, serverConfig)
+	clientConfig, err := NewClientTLSConfig(ClientTLSOptions{CAFile: ca.file})
+	if err != nil {
+		t.Fatalf("failed to create client TLS config: %v", err)
+	}
+
+	if serial := peerSerial(t, addr, clientConfig); serial != 10 {
+		t.Fatalf("served certificate %d, want 10", serial)
+	}
+
+	// A half-written rotation keeps the current certificate
+	if err := os.WriteFile(serverKey, []byte("not a key"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	if serial := peerSerial(t, addr, clientConfig); serial != 10 {
+		t.Errorf("served certificate %d after a broken rotation, want 10", serial)
+	}
+
+	ca.issue(t, dir, "server", 20, x509.ExtKeyUsageServerAuth)
+	if serial := peerSerial(t, addr, clientConfig); serial != 20 {
+		t.Errorf("served certificate %d after rotation, want 20", serial)
+	}
+}
+
+func TestTLSResumesSessions(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", 10, x509.ExtKeyUsageServerAuth)
+
+	serverConfig, err := NewServerTLSConfig(TLSOptions{CertFile: serverCert, KeyFile: serverKey})
+	if err != nil {
+		t.Fatalf("failed to create server TLS config: %v", err)
+	}
+	addr := serveHTTPS(t, serverConfig)
+	clientConfig, err := NewClientTLSConfig(ClientTLSOptions{CAFile: ca.file})
+	if err != nil {
+		t.Fatalf("failed to create client TLS config: %v", err)
+	}
+	clientConfig.ClientSessionCache = tls.NewLRUClientSessionCache(1)
+
+	// Session tickets are only accepted while the server keeps its ticket keys
+	for i, want := range []bool{false, true} {
+		conn, err := tls.Dial("tcp", addr, clientConfig)
+		if err != nil {
+			t.Fatalf("connection %d failed: %v", i, err)
+		}
+		// TLS 1.3 tickets arrive after the handshake
+		conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		conn.Read(make([]byte, 1))
+		resumed := conn.ConnectionState().DidResume
+		conn.Close()
+		if resumed != want {
+			t.Errorf("connection %d resumed %v, want %v", i, resumed, want)
+		}
+	}
+}
+
+func TestTLSChecksFilesOncePerInterval(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", 10, x509.ExtKeyUsageServerAuth)
+
+	serverConfig, err := NewServerTLSConfig(TLSOptions{CertFile: serverCert, KeyFile: serverKey, ReloadInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("failed to create server TLS config: %v", err)
+	}
+	addr := serveHTTPS(t, serverConfig)
+	clientConfig, err := NewClientTLSConfig(ClientTLSOptions{CAFile: ca.file})
+	if err != nil {
+		t.Fatalf("failed to create client TLS config: %v", err)
+	}
+
+	ca.issue(t, dir, "server", 20, x509.ExtKeyUsageServerAuth)
+	if serial := peerSerial(t, addr, clientConfig); serial != 10 {
+		t.Errorf("served certificate %d before the next check, want 10", serial)
+	}
+}
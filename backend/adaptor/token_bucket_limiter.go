@@ -0,0 +1,93 @@
+package adaptor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	"golang.org/x/time/rate"
+)
+
+// bucketPruneInterval is how often full buckets are dropped. A full bucket
+// behaves like a new one, so dropping it only frees memory.
+const bucketPruneInterval = time.Minute
+
+type tokenBucketLimiter struct {
+	mu        sync.Mutex
+	limit     domain.RateLimit
+	buckets   map[string]*rate.Limiter
+	lastPrune time.Time
+}
+
+// NewTokenBucketLimiter returns an in-memory rate limiter with one token
+// bucket per key
+func NewTokenBucketLimiter(limit domain.RateLimit) port.RateLimiter {
+	return &tokenBucketLimiter{
+		limit:     limit,
+		buckets:   map[string]*rate.Limiter{},
+		lastPrune: time.Now(),
+	}
+}
+
+func (l *tokenBucketLimiter) Allow(key string) (bool, time.Duration) {
+	_, ok, retryAfter := l.Reserve(key)
+	return ok, retryAfter
+}
+
+func (l *tokenBucketLimiter) Reserve(key string) (func(), bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit.Unlimited() {
+		return func() {}, true, 0
+	}
+
+	now := time.Now()
+	l.prune(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(l.limit.Rate), l.limit.BurstSize())
+		l.buckets[key] = bucket
+	}
+
+	reservation := bucket.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// Rejected requests do not use up tokens
+		reservation.CancelAt(now)
+		return nil, false, delay
+	}
+	// Cancelling as of now returns the token even once it has been used
+	return func() { reservation.CancelAt(now) }, true, 0
+}
+
+func (l *tokenBucketLimiter) SetLimit(limit domain.RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = limit
+	if limit.Unlimited() {
+		clear(l.buckets)
+		return
+	}
+
+	now := time.Now()
+	for _, bucket := range l.buckets {
+		bucket.SetLimitAt(now, rate.Limit(limit.Rate))
+		bucket.SetBurstAt(now, limit.BurstSize())
+	}
+}
+
+func (l *tokenBucketLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < bucketPruneInterval {
+		return
+	}
+	l.lastPrune = now
+
+	for key, bucket := range l.buckets {
+		if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+			delete(l.buckets, key)
+		}
+	}
+}
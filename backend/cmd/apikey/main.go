@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/adaptor"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+)
+
+const usage = `Usage: apikey <command> [flags]
+
+Commands:
+  create  -account ID [-tenant ID] [-name NAME] [-roles r1,r2]
+          create an API key and print its secret
+  list    list API keys
+  revoke  -id KEY_ID                                revoke an API key
+  token   -sub SUBJECT [-tenant ID] [-account ID] [-roles r1,r2] [-ttl 1h]
+          print an HS256 bearer token signed with JWT_HS256_SECRET
+
+Keys and tokens without -tenant belong to the default tenant.
+
+Every command accepts -db PATH (default ./stock_orders.db).
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	dbPath := flags.String("db", "./stock_orders.db", "SQLite database holding the API keys")
+	account := flags.String("account", "", "account the key or token acts for")
+	tenant := flags.String("tenant", "", "tenant the key or token belongs to")
+	name := flags.String("name", "", "description of the key")
+	roles := flags.String("roles", "", "comma separated roles")
+	id := flags.String("id", "", "API key ID")
+	subject := flags.String("sub", "", "token subject")
+	ttl := flags.Duration("ttl", time.Hour, "token lifetime")
+	flags.Parse(args)
+
+	if command == "token" {
+		printToken(*subject, *tenant, *account, splitRoles(*roles), *ttl)
+		return
+	}
+
+	keys, err := adaptor.NewSQLiteAPIKeyRepository(*dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open API key repository: %v", err)
+	}
+	defer keys.Close()
+
+	auth := service.NewAuthService(keys)
+	ctx := context.Background()
+
+	switch command {
+	case "create":
+		key, secret, err := auth.CreateAPIKey(ctx, domain.CreateAPIKeyRequest{
+			TenantID:  *tenant,
+			AccountID: *account,
+			Name:      *name,
+			Roles:     splitRoles(*roles),
+		})
+		if err != nil {
+			log.Fatalf("Failed to create API key: %v", err)
+		}
+		fmt.Printf("ID:      %s\nTenant:  %s\nAccount: %s\nSecret:  %s\n\nThe secret is not stored and cannot be shown again.\n", key.ID, key.TenantID, key.AccountID, secret)
+
+	case "list":
+		list, err := auth.ListAPIKeys(ctx)
+		if err != nil {
+			log.Fatalf("Failed to list API keys: %v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTENANT\tACCOUNT\tNAME\tROLES\tCREATED\tREVOKED")
+		for _, key := range list {
+			revoked := "-"
+			if key.RevokedAt != nil {
+				revoked = key.RevokedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", key.ID, key.TenantID, key.AccountID, key.Name,
+				strings.Join(key.Roles, ","), key.CreatedAt.Format(time.RFC3339), revoked)
+		}
+		w.Flush()
+
+	case "revoke":
+		if err := auth.RevokeAPIKey(ctx, *id); err != nil {
+			log.Fatalf("Failed to revoke API key: %v", err)
+		}
+		fmt.Printf("Revoked %s\n", *id)
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// printToken signs a token for local testing with the same secret the
+// server reads from JWT_HS256_SECRET
+func printToken(subject, tenant, account string, roles []string, ttl time.Duration) {
+	secret := os.Getenv("JWT_HS256_SECRET")
+	if secret == "" {
+		log.Fatal("JWT_HS256_SECRET must be set")
+	}
+	if subject == "" {
+		log.Fatal("-sub is required")
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": subject,
+		"iat": now.Unix(),
+		"exp": now.Add(ttl).Unix(),
+	}
+	if tenant != "" {
+		claims["tenant_id"] = tenant
+	}
+	if account != "" {
+		claims["account_id"] = account
+	}
+	if len(roles) > 0 {
+		claims["roles"] = roles
+	}
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		claims["iss"] = issuer
+	}
+	if audience := os.Getenv("JWT_AUDIENCE"); audience != "" {
+		claims["aud"] = audience
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+	fmt.Println(token)
+}
+
+func splitRoles(roles string) []string {
+	if roles == "" {
+		return nil
+	}
+	return strings.Split(roles, ",")
+}
@@ -3,12 +3,16 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/adaptor"
 )
 
 func main() {
@@ -19,6 +23,18 @@ func main() {
 		Timeout: 10 * time.Second,
 	}
 
+	// Use HTTPS when the server's CA or a client certificate is configured
+	baseURL := "http://localhost:8082"
+	tlsConfig, err := clientTLSConfig()
+	if err != nil {
+		fmt.Printf("Failed to load TLS configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if tlsConfig != nil {
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+		baseURL = "https://localhost:8082"
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
@@ -29,8 +45,17 @@ func main() {
 		cancel()
 	}()
 
+	// Credentials are only needed when the server requires authentication
+	apiKey := os.Getenv("API_KEY")
+	bearerToken := os.Getenv("BEARER_TOKEN")
+	tenantID := os.Getenv("TENANT_ID")
+
+	// Retries reuse the key so an order is never placed twice; a new key is
+	// only drawn once the server has answered
+	idempotencyKey := uuid.New().String()
+
 	for {
-		request, err := http.NewRequest("POST", "http://localhost:8082/api/orders", bytes.NewBuffer([]byte(`{
+		request, err := http.NewRequest("POST", baseURL+"/api/orders", bytes.NewBuffer([]byte(`{
 			"symbol": "AAPL",
 			"order_type": "MARKET",
 			"order_side": "BUY",
@@ -41,6 +66,16 @@ func main() {
 			continue
 		}
 		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Idempotency-Key", idempotencyKey)
+		if apiKey != "" {
+			request.Header.Set("X-API-Key", apiKey)
+		}
+		if bearerToken != "" {
+			request.Header.Set("Authorization", "Bearer "+bearerToken)
+		}
+		if tenantID != "" {
+			request.Header.Set("X-Tenant-ID", tenantID)
+		}
 
 		// Attach context to request so it can be canceled
 		request = request.WithContext(ctx)
@@ -58,6 +93,7 @@ func main() {
 
 		fmt.Printf("Response: %v\n", response)
 		response.Body.Close()
+		idempotencyKey = uuid.New().String()
 
 		select {
 		case <-time.After(1 * time.Second):
@@ -68,3 +104,19 @@ func main() {
 		}
 	}
 }
+
+// clientTLSConfig reads TLS_CA_FILE, TLS_CERT_FILE, TLS_KEY_FILE and
+// TLS_SERVER_NAME; it returns nil when neither a CA nor a client certificate
+// is configured
+func clientTLSConfig() (*tls.Config, error) {
+	opts := adaptor.ClientTLSOptions{
+		CAFile:     os.Getenv("TLS_CA_FILE"),
+		CertFile:   os.Getenv("TLS_CERT_FILE"),
+		KeyFile:    os.Getenv("TLS_KEY_FILE"),
+		ServerName: os.Getenv("TLS_SERVER_NAME"),
+	}
+	if opts.CAFile == "" && opts.CertFile == "" {
+		return nil, nil
+	}
+	return adaptor.NewClientTLSConfig(opts)
+}
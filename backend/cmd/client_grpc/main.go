@@ -2,22 +2,35 @@ package main
 
 import (
 	"context"
+	"io"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto"
+	"github.com/google/uuid"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/adaptor"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Use TLS, and mutual TLS with a client certificate, when configured
+	creds, err := transportCredentials()
+	if err != nil {
+		log.Fatalf("Failed to load TLS configuration: %v", err)
+	}
+
+	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatalf("Failed to connect to server: %v", err)
 	}
@@ -25,6 +38,25 @@ func main() {
 
 	client := pb.NewStockOrderServiceClient(conn)
 
+	// Identify the account so the server only streams our own orders
+	accountID := os.Getenv("ACCOUNT_ID")
+	if accountID == "" {
+		accountID = "demo-account"
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-account-id", accountID)
+
+	// Credentials are only needed when the server requires authentication;
+	// an authenticated caller acts for the account of its key or token
+	if apiKey := os.Getenv("API_KEY"); apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", apiKey)
+	}
+	if bearerToken := os.Getenv("BEARER_TOKEN"); bearerToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+bearerToken)
+	}
+	if tenantID := os.Getenv("TENANT_ID"); tenantID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-tenant-id", tenantID)
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
@@ -34,17 +66,24 @@ func main() {
 		cancel()
 	}()
 
+	go watchOrders(ctx, client)
+	go watchOrderBook(ctx, client, "AAPL")
+
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
+	// Retries reuse the client order ID so an order is never placed twice
+	clientOrderID := uuid.New().String()
+
 	for {
 		select {
 		case <-ticker.C:
 			request := &pb.CreateOrderRequest{
-				Symbol:    "AAPL",
-				OrderType: pb.OrderType_MARKET,
-				OrderSide: pb.OrderSide_BUY,
-				Quantity:  100,
+				Symbol:        "AAPL",
+				OrderType:     pb.OrderType_MARKET,
+				OrderSide:     pb.OrderSide_BUY,
+				Quantity:      100,
+				ClientOrderId: clientOrderID,
 			}
 
 			response, err := client.CreateOrder(ctx, request)
@@ -58,6 +97,7 @@ func main() {
 			}
 
 			log.Printf("Response: %v\n", response)
+			clientOrderID = uuid.New().String()
 
 		case <-ctx.Done():
 			log.Println("Shutting down gracefully...")
@@ -65,3 +105,112 @@ func main() {
 		}
 	}
 }
+
+// watchOrders logs status changes of our orders, resuming after the last
+// received sequence whenever the stream is interrupted
+// transportCredentials reads TLS_CA_FILE, TLS_CERT_FILE, TLS_KEY_FILE and
+// TLS_SERVER_NAME; without a CA or client certificate it connects in plaintext
+func transportCredentials() (credentials.TransportCredentials, error) {
+	opts := adaptor.ClientTLSOptions{
+		CAFile:     os.Getenv("TLS_CA_FILE"),
+		CertFile:   os.Getenv("TLS_CERT_FILE"),
+		KeyFile:    os.Getenv("TLS_KEY_FILE"),
+		ServerName: os.Getenv("TLS_SERVER_NAME"),
+	}
+	if opts.CAFile == "" && opts.CertFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	config, err := adaptor.NewClientTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(config), nil
+}
+
+func watchOrders(ctx context.Context, client pb.StockOrderServiceClient) {
+	var next uint64
+	for ctx.Err() == nil {
+		stream, err := client.WatchOrders(ctx, &pb.WatchOrdersRequest{FromSequence: next})
+		if err != nil {
+			log.Printf("Failed to watch orders: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for {
+			event, err := stream.Recv()
+			if err == io.EOF {
+				log.Println("Order stream closed by server")
+				return
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Order stream interrupted: %v", err)
+				}
+				// The server no longer has our position, start over with new events
+				if status.Code(err) == codes.FailedPrecondition {
+					next = 0
+				}
+				break
+			}
+
+			next = event.Sequence + 1
+			order := event.Order
+			log.Printf("Order %s is %s (%d/%d filled)", order.Id, order.Status, order.FilledQuantity, order.Quantity)
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// watchOrderBook maintains a local copy of the top of the book, requesting a
+// new snapshot whenever an update is missed
+func watchOrderBook(ctx context.Context, client pb.StockOrderServiceClient, symbol string) {
+	for ctx.Err() == nil {
+		streamCtx, cancelStream := context.WithCancel(ctx)
+		stream, err := client.StreamOrderBook(streamCtx, &pb.StreamOrderBookRequest{Symbol: symbol, Depth: 5})
+		if err != nil {
+			cancelStream()
+			log.Printf("Failed to stream order book: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		bids, asks := map[float64]int32{}, map[float64]int32{}
+		for {
+			update, err := stream.Recv()
+			if err == io.EOF {
+				cancelStream()
+				log.Println("Order book stream closed by server")
+				return
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Order book stream interrupted: %v", err)
+				}
+				break
+			}
+
+			if update.Snapshot {
+				bids, asks = map[float64]int32{}, map[float64]int32{}
+			}
+			applyLevels(bids, update.Bids)
+			applyLevels(asks, update.Asks)
+			log.Printf("%s book at sequence %d: %d bid levels, %d ask levels", symbol, update.Sequence, len(bids), len(asks))
+		}
+
+		cancelStream()
+		time.Sleep(time.Second)
+	}
+}
+
+func applyLevels(book map[float64]int32, levels []*pb.PriceLevel) {
+	for _, level := range levels {
+		if level.Quantity == 0 {
+			delete(book, level.Price)
+			continue
+		}
+		book[level.Price] = level.Quantity
+	}
+}
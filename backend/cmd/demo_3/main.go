@@ -2,42 +2,161 @@ package main
 
 import (
 	"context"
+	"crypto"
+	"crypto/tls"
+	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/gorilla/mux"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/adaptor"
-	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	pb "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1"
+	pbv2 "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2"
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// Initialize SQLite repository
-	repo, err := adaptor.NewSQLiteRepository("./stock_orders.db")
+	// Log JSON records carrying the request ID, tenant, account and order
+	logHandler, err := newLogHandler()
 	if err != nil {
-		log.Fatalf("Failed to initialize repository: %v", err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
+	slog.SetDefault(slog.New(logHandler))
 
-	// Initialize service
-	stockService := service.NewStockOrderService(repo)
+	// Collect Prometheus metrics of every layer, served on /metrics
+	metrics := adaptor.NewMetrics()
+
+	// Initialize trading session calendar
+	calendar, err := newSessionCalendar(os.Getenv("HOLIDAYS_FILE"))
+	if err != nil {
+		fatal("Failed to initialize session calendar", err)
+	}
+
+	// Rate limit requests per caller and orders per account
+	rateLimitFile := os.Getenv("RATE_LIMIT_FILE")
+	rateLimits, err := newRateLimitPolicy(rateLimitFile)
+	if err != nil {
+		fatal("Failed to load rate limits", err)
+	}
+	requestRateLimiter := adaptor.NewRequestRateLimiter(rateLimits)
+
+	feed, err := newMarketDataFeed(os.Getenv("MARKET_DATA_FILE"), os.Getenv("MARKET_DATA_SPEED"))
+	if err != nil {
+		fatal("Failed to initialize market data feed", err)
+	}
+
+	// Every tenant gets its own database, order books and market data, and
+	// sheds load on its own when its database slows down
+	tenants, err := loadTenants(os.Getenv("TENANTS_FILE"))
+	if err != nil {
+		fatal("Failed to load tenants", err)
+	}
+	loadShedding, err := newConcurrencyLimiterOptions()
+	if err != nil {
+		fatal("Failed to initialize load shedding", err)
+	}
+	stacks := []*tenantStack{}
+	tenantOrders := map[string]port.StockOrderService{}
+	tenantMarketData := map[string]port.MarketDataService{}
+	for _, tenant := range tenants {
+		stack, err := newTenantStack(tenant, calendar, feed, rateLimits, loadShedding, metrics)
+		if err != nil {
+			fatal("Failed to initialize tenant", err, "tenant_id", tenant.ID)
+		}
+		stacks = append(stacks, stack)
+		tenantOrders[tenant.ID] = stack.orders
+		tenantMarketData[tenant.ID] = stack.marketData
+	}
+	stockService := service.NewTenantStockOrderService(tenantOrders)
+	marketData := service.NewTenantMarketDataService(tenantMarketData)
+
+	// Initialize authentication with API keys stored next to the orders and
+	// optionally JWT bearer tokens
+	apiKeyRepo, err := adaptor.NewSQLiteAPIKeyRepository("./stock_orders.db",
+		adaptor.WithSQLiteMetrics(metrics, domain.DefaultTenantID))
+	if err != nil {
+		fatal("Failed to initialize API key repository", err)
+	}
 
-	// Initialize HTTP handler
-	httpHandler := adaptor.NewHTTPHandler(stockService)
+	authOpts := []service.AuthOption{}
+	tokenVerifier, err := newTokenVerifier()
+	if err != nil {
+		fatal("Failed to initialize JWT verifier", err)
+	}
+	if tokenVerifier != nil {
+		authOpts = append(authOpts, service.WithTokenVerifier(tokenVerifier))
+	}
+
+	authRequired := os.Getenv("AUTH_REQUIRED") == "true"
+	authenticator := adaptor.NewAuthenticator(service.NewAuthService(apiKeyRepo, authOpts...), authRequired)
+	if authRequired {
+		slog.Info("Authentication required for all API calls")
+	}
+
+	// Authorize every operation against the role policy
+	policy, err := newPolicy(os.Getenv("POLICY_FILE"), authRequired)
+	if err != nil {
+		fatal("Failed to load authorization policy", err)
+	}
+	orderService, err := service.NewAuthorizedStockOrderService(stockService, policy)
+	if err != nil {
+		fatal("Failed to initialize authorization", err)
+	}
+	marketDataService, err := service.NewAuthorizedMarketDataService(marketData, policy)
+	if err != nil {
+		fatal("Failed to initialize authorization", err)
+	}
+
+	// Initialize HTTP handler; browser pages of other origins may only open
+	// WebSockets when listed in WS_ALLOWED_ORIGINS
+	httpOpts := []adaptor.HandlerOption{adaptor.WithMarketDataService(marketDataService)}
+	if origins := os.Getenv("WS_ALLOWED_ORIGINS"); origins != "" {
+		httpOpts = append(httpOpts, adaptor.WithAllowedOrigins(strings.Split(origins, ",")...))
+	}
+	httpHandler := adaptor.NewHTTPHandler(orderService, httpOpts...)
+
+	// Initialize gRPC handlers; v2 translates to the same service as v1
+	grpcHandler := adaptor.NewGRPCHandler(orderService, adaptor.WithMarketDataService(marketDataService))
+	grpcAdminHandler := adaptor.NewGRPCAdminHandler(orderService)
+	grpcHandlerV2 := adaptor.NewGRPCHandlerV2(orderService)
 
 	// Setup HTTP router
 	router := mux.NewRouter()
 	httpHandler.RegisterRoutes(router)
 
-	// Add logging middleware
-	router.Use(loggingMiddleware)
+	// The versioned REST APIs are generated from the protos and served by the
+	// gRPC handlers; the unversioned hand-written routes remain as aliases
+	gateway, err := adaptor.NewGatewayHandler(context.Background(), grpcHandler, grpcAdminHandler, grpcHandlerV2,
+		runtime.WithMiddlewares(metrics.GatewayMiddleware, requestRateLimiter.GatewayMiddleware),
+	)
+	if err != nil {
+		fatal("Failed to initialize REST gateway", err)
+	}
+	router.PathPrefix("/api/v1/").Handler(gateway)
+	router.PathPrefix("/api/v2/").Handler(gateway)
+	router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+
+	// Add logging, metrics, authentication and rate limiting middleware;
+	// logging comes first so that every later log line carries the request ID
+	router.Use(adaptor.LoggingMiddleware, metrics.HTTPMiddleware, authenticator.Middleware, requestRateLimiter.Middleware)
 
 	// Start HTTP server
 	httpPort := os.Getenv("PORT")
@@ -45,22 +164,33 @@ func main() {
 		httpPort = "8082"
 	}
 
+	// Serve HTTPS and gRPC over TLS when a certificate is configured
+	httpTLS, grpcTLS, err := newTLSConfigs()
+	if err != nil {
+		fatal("Failed to load TLS configuration", err)
+	}
+
 	httpServer := &http.Server{
-		Addr:    ":" + httpPort,
-		Handler: router,
+		Addr:      ":" + httpPort,
+		Handler:   router,
+		TLSConfig: httpTLS,
 	}
+	// Streaming connections are not drained by Shutdown, end them explicitly
+	httpServer.RegisterOnShutdown(httpHandler.CloseStreams)
 
 	// HTTP Server startup
 	go func() {
-		log.Printf("---Starting HTTP server on port %s---", httpPort)
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("HTTP Server failed: %v", err)
+		slog.Info("---Starting HTTP server---", "port", httpPort)
+		serve := httpServer.ListenAndServe
+		if httpTLS != nil {
+			// The certificate comes from TLSConfig
+			serve = func() error { return httpServer.ListenAndServeTLS("", "") }
+		}
+		if err := serve(); err != nil && err != http.ErrServerClosed {
+			fatal("HTTP Server failed", err)
 		}
 	}()
 
-	// Initialize gRPC handler
-	grpcHandler := adaptor.NewGRPCHandler(stockService)
-
 	// Setup gRPC server
 	grpcPort := os.Getenv("GRPC_PORT")
 	if grpcPort == "" {
@@ -69,50 +199,109 @@ func main() {
 
 	lis, err := net.Listen("tcp", ":"+grpcPort)
 	if err != nil {
-		log.Fatalf("Failed to listen on gRPC port: %v", err)
+		fatal("Failed to listen on gRPC port", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcOpts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(adaptor.MaxRequestBytes),
+		grpc.ChainUnaryInterceptor(adaptor.LoggingUnaryInterceptor, metrics.UnaryInterceptor, authenticator.UnaryInterceptor, adaptor.AccountUnaryInterceptor, requestRateLimiter.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(adaptor.LoggingStreamInterceptor, metrics.StreamInterceptor, authenticator.StreamInterceptor, adaptor.AccountStreamInterceptor, requestRateLimiter.StreamInterceptor),
+	}
+	if grpcTLS != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(grpcTLS)))
+	}
+	grpcServer := grpc.NewServer(grpcOpts...)
 	pb.RegisterStockOrderServiceServer(grpcServer, grpcHandler)
+	pb.RegisterAdminServiceServer(grpcServer, grpcAdminHandler)
+	pbv2.RegisterStockOrderServiceServer(grpcServer, grpcHandlerV2)
+	adaptor.RegisterLegacyService(grpcServer, grpcHandler)
+
+	// Reflection lets tools such as grpcurl discover the API without the
+	// proto files; it is off unless enabled explicitly
+	if os.Getenv("GRPC_REFLECTION") == "true" {
+		reflection.Register(grpcServer)
+		slog.Info("gRPC server reflection enabled")
+	}
 
 	// gRPC Server startup
 	go func() {
-		log.Printf("---Starting gRPC server on port %s---", grpcPort)
+		slog.Info("---Starting gRPC server---", "port", grpcPort)
 		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("gRPC Server failed: %v", err)
+			fatal("gRPC Server failed", err)
 		}
 	}()
 
+	// A dedicated metrics listener keeps serving until the process exits, so
+	// the shutdown phases can be watched while the API servers drain
+	var metricsServer *http.Server
+	if metricsPort := os.Getenv("METRICS_PORT"); metricsPort != "" {
+		metricsRouter := http.NewServeMux()
+		metricsRouter.Handle("GET /metrics", metrics.Handler())
+		metricsServer = &http.Server{Addr: ":" + metricsPort, Handler: metricsRouter}
+		go func() {
+			slog.Info("---Starting metrics server---", "port", metricsPort)
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				fatal("Metrics server failed", err)
+			}
+		}()
+	}
+
+	// SIGHUP reloads the rate limits without dropping connections
+	if rateLimitFile != "" {
+		go reloadRateLimitsOnHangup(rateLimitFile, requestRateLimiter, stacks)
+	}
+
 	// Graceful shutdown implementation
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("---Start Graceful shutdown---")
+	slog.Info("---Start Graceful shutdown---")
+	shutdownDone := metrics.ShutdownPhase("total")
 
 	// Create context with timeout for graceful shutdown operations
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
+	// End open order and market data streams first, otherwise GracefulStop waits for them forever
+	slog.Info("Closing order event and market data streams...")
+	streamsDone := metrics.ShutdownPhase("streams")
+	for _, stack := range stacks {
+		stack.events.Close()
+		stack.marketData.CloseSubscriptions()
+	}
+	streamsDone()
+
+	serversDone := metrics.ShutdownPhase("servers")
 	wg := sync.WaitGroup{}
 	wg.Add(2)
 	go func() {
-		log.Println("Shutting down HTTP server...")
+		slog.Info("Shutting down HTTP server...")
 		httpServer.Shutdown(shutdownCtx)
-		log.Println("HTTP server stopped")
+		slog.Info("HTTP server stopped")
 		wg.Done()
 	}()
 
 	// gRPC Server - use graceful stop
 	go func() {
-		log.Println("Shutting down gRPC server...")
+		slog.Info("Shutting down gRPC server...")
 		grpcServer.GracefulStop()
-		log.Println("gRPC server stopped")
+		slog.Info("gRPC server stopped")
 		wg.Done()
 	}()
 	wg.Wait()
-	log.Println("All servers closed successfully")
+	serversDone()
+	slog.Info("All servers closed successfully")
+
+	// Stop the matching engines before closing the repositories they write
+	// to, and market data before closing the feed and flushing the final candles
+	slog.Info("Stopping matching engines and market data caches...")
+	enginesDone := metrics.ShutdownPhase("engines")
+	for _, stack := range stacks {
+		stack.stop()
+	}
+	enginesDone()
 
 	//--------------------------------
 
@@ -121,16 +310,32 @@ func main() {
 
 	// Repository
 	repositoryMap := map[string]interface{}{}
-	repositoryMap["sqlite"] = repo
+	repositoryMap["sqlite_api_keys"] = apiKeyRepo
+	for _, stack := range stacks {
+		for name, repository := range stack.repositories {
+			repositoryMap[name] = repository
+		}
+	}
 
 	shutDownList = append(shutDownList, repositoryMap)
 
+	// Market data feed
+	if feed != nil {
+		shutDownList = append(shutDownList, map[string]interface{}{"market_data_feed": feed})
+	}
+
 	// Shutdown other dependencies
-	log.Println("Shutting down other dependencies...")
+	slog.Info("Shutting down other dependencies...")
+	dependenciesDone := metrics.ShutdownPhase("dependencies")
 	<-shutdownService(&shutDownList)
-	log.Println("All Dependencies closed successfully")
+	dependenciesDone()
+	slog.Info("All Dependencies closed successfully")
 
-	log.Println("---Graceful shutdown completed---")
+	shutdownDone()
+	if metricsServer != nil {
+		metricsServer.Shutdown(shutdownCtx)
+	}
+	slog.Info("---Graceful shutdown completed---")
 	os.Exit(0)
 }
 
@@ -147,9 +352,9 @@ func shutdownService(shutDownList *[]interface{}) <-chan struct{} {
 				time.Sleep(1 * time.Second)
 				if c, ok := closer.(io.Closer); ok {
 					if err := c.Close(); err != nil {
-						log.Printf("Error closing service %s: %v", key, err)
+						slog.Error("Error closing service", "service", key, "error", err)
 					}
-					log.Printf("%s closed successfully", key)
+					slog.Info("Service closed successfully", "service", key)
 				}
 			}(k, v)
 		}
@@ -160,9 +365,348 @@ func shutdownService(shutDownList *[]interface{}) <-chan struct{} {
 	return blockExitChannel
 }
 
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("%s %s %s", r.Method, r.RequestURI, r.RemoteAddr)
-		next.ServeHTTP(w, r)
-	})
+// newSessionCalendar builds the default market calendar, loading holidays from
+// holidaysFile when it is set
+func newSessionCalendar(holidaysFile string) (port.SessionCalendar, error) {
+	holidays := []domain.Holiday{}
+	if holidaysFile != "" {
+		loaded, err := adaptor.NewFileHolidaySource(holidaysFile).LoadHolidays(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		holidays = loaded
+		slog.Info("Loaded holidays", "holidays", len(holidays), "path", holidaysFile)
+	}
+
+	return service.NewSessionCalendar(service.DefaultMarketSchedules(), holidays)
+}
+
+// newTokenVerifier accepts JWT bearer tokens signed with JWT_HS256_SECRET or
+// with the key in JWT_PUBLIC_KEY_FILE. Without either, bearer tokens are rejected.
+func newTokenVerifier() (port.TokenVerifier, error) {
+	opts := adaptor.JWTOptions{
+		HMACSecret: []byte(os.Getenv("JWT_HS256_SECRET")),
+		Issuer:     os.Getenv("JWT_ISSUER"),
+		Audience:   os.Getenv("JWT_AUDIENCE"),
+	}
+	if file := os.Getenv("JWT_PUBLIC_KEY_FILE"); file != "" {
+		key, err := adaptor.LoadPublicKey(file)
+		if err != nil {
+			return nil, err
+		}
+		opts.PublicKeys = map[string]crypto.PublicKey{os.Getenv("JWT_KEY_ID"): key}
+	}
+	if len(opts.HMACSecret) == 0 && len(opts.PublicKeys) == 0 {
+		return nil, nil
+	}
+
+	return adaptor.NewJWTVerifier(opts)
+}
+
+// newRateLimitPolicy loads the rate limits from file, or uses the default
+// limits when file is empty
+func newRateLimitPolicy(file string) (domain.RateLimitPolicy, error) {
+	if file == "" {
+		return service.DefaultRateLimitPolicy(), nil
+	}
+	policy, err := adaptor.NewFileRateLimitSource(file).LoadRateLimits(context.Background())
+	if err != nil {
+		return domain.RateLimitPolicy{}, err
+	}
+	slog.Info("Loaded rate limits", "path", file)
+	return policy, nil
+}
+
+// reloadRateLimitsOnHangup applies the rate limits in file whenever the
+// process receives SIGHUP. An invalid file keeps the current limits; tenants
+// with an order rate of their own keep it.
+func reloadRateLimitsOnHangup(file string, requests *adaptor.RequestRateLimiter, stacks []*tenantStack) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	for range hangup {
+		policy, err := adaptor.NewFileRateLimitSource(file).LoadRateLimits(context.Background())
+		if err != nil {
+			slog.Warn("Failed to reload rate limits, keeping the current ones", "path", file, "error", err)
+			continue
+		}
+		requests.SetPolicy(policy)
+		for _, stack := range stacks {
+			if stack.tenant.OrderRate == nil {
+				stack.orderRate.SetLimit(policy.Orders)
+			}
+		}
+		slog.Info("Reloaded rate limits", "path", file)
+	}
+}
+
+// newConcurrencyLimiterOptions limits the operations in flight to at most
+// MAX_CONCURRENCY, lowering the limit while operations take longer than
+// LATENCY_TARGET. Requests wait up to QUEUE_TIMEOUT for a slot.
+func newConcurrencyLimiterOptions() (adaptor.AIMDLimiterOptions, error) {
+	opts := adaptor.AIMDLimiterOptions{}
+	if value := os.Getenv("MAX_CONCURRENCY"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return opts, fmt.Errorf("invalid MAX_CONCURRENCY %q", value)
+		}
+		opts.MaxLimit = limit
+	}
+	for name, target := range map[string]*time.Duration{"LATENCY_TARGET": &opts.LatencyTarget, "QUEUE_TIMEOUT": &opts.QueueTimeout} {
+		if value := os.Getenv(name); value != "" {
+			duration, err := time.ParseDuration(value)
+			if err != nil || duration <= 0 {
+				return opts, fmt.Errorf("invalid %s %q", name, value)
+			}
+			*target = duration
+		}
+	}
+	return opts, nil
+}
+
+// loadTenants loads the tenants from file. The default tenant is always
+// served, with the default settings unless file configures it.
+func loadTenants(file string) ([]domain.Tenant, error) {
+	tenants := []domain.Tenant{}
+	if file != "" {
+		loaded, err := adaptor.NewFileTenantSource(file).LoadTenants(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		tenants = loaded
+		slog.Info("Loaded tenants", "tenants", len(tenants), "path", file)
+	}
+
+	if !slices.ContainsFunc(tenants, func(t domain.Tenant) bool { return t.ID == domain.DefaultTenantID }) {
+		tenants = append([]domain.Tenant{{ID: domain.DefaultTenantID}}, tenants...)
+	}
+	return tenants, nil
+}
+
+// tenantStack is the order service and market data of one tenant, stored in
+// a database of its own
+type tenantStack struct {
+	tenant       domain.Tenant
+	orders       port.StockOrderService
+	events       *service.OrderEventHub
+	marketData   *service.MarketDataCache
+	orderRate    port.RateLimiter
+	repositories map[string]interface{}
+	stop         func()
+}
+
+// tenantDatabase keeps the default tenant in the database used before
+// tenants existed
+func tenantDatabase(tenantID string) string {
+	if tenantID == domain.DefaultTenantID {
+		return "./stock_orders.db"
+	}
+	return "./stock_orders_" + tenantID + ".db"
+}
+
+func newTenantStack(tenant domain.Tenant, calendar port.SessionCalendar, feed port.MarketDataFeed, rateLimits domain.RateLimitPolicy, loadShedding adaptor.AIMDLimiterOptions, metrics *adaptor.Metrics) (*tenantStack, error) {
+	dbPath := tenantDatabase(tenant.ID)
+	sqliteMetrics := adaptor.WithSQLiteMetrics(metrics, tenant.ID)
+
+	// Initialize SQLite repository
+	repo, err := adaptor.NewSQLiteRepository(dbPath, sqliteMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize repository: %w", err)
+	}
+
+	// Initialize matching engine and restore resting orders
+	engine := service.NewMatchingEngine(repo, calendar)
+	if err := engine.Restore(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to restore order books: %w", err)
+	}
+
+	// Initialize order event hub for streaming clients
+	orderEvents := service.NewOrderEventHub(10000)
+
+	// Initialize trade repository, recording fills next to the orders
+	tradeRepo, err := adaptor.NewSQLiteTradeRepository(dbPath, sqliteMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize trade repository: %w", err)
+	}
+
+	// Initialize instrument reference data and symbol halts
+	instrumentRepo, err := adaptor.NewSQLiteInstrumentRepository(dbPath, sqliteMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize instrument repository: %w", err)
+	}
+
+	orderRate := rateLimits.Orders
+	if tenant.OrderRate != nil {
+		orderRate = *tenant.OrderRate
+	}
+	orderRateLimiter := adaptor.NewTokenBucketLimiter(orderRate)
+
+	// Initialize service
+	stockService := service.NewStockOrderService(repo,
+		service.WithSessionCalendar(calendar),
+		service.WithMatchingEngine(engine),
+		service.WithOrderEventHub(orderEvents),
+		service.WithTradeRepository(tradeRepo),
+		service.WithInstrumentRepository(instrumentRepo),
+		service.WithOrderRateLimiter(orderRateLimiter),
+		service.WithFeeSchedule(tenant.Fees),
+		service.WithOrderMetrics(metrics.OrderMetrics(tenant.ID, instrumentRepo)),
+	)
+
+	// Register the tenant's configured instruments
+	for _, instrument := range tenant.Instruments {
+		if _, err := stockService.UpsertInstrument(context.Background(), instrument.Symbol, instrument.UpsertInstrumentRequest); err != nil {
+			return nil, fmt.Errorf("failed to register instrument %s: %w", instrument.Symbol, err)
+		}
+	}
+
+	// A slow tenant database only sheds the tenant's own requests, keeping
+	// cancels going longest
+	stockService = service.NewLoadSheddingStockOrderService(stockService, adaptor.NewAIMDLimiter(loadShedding))
+
+	// Initialize market data cache, persisting candles next to the orders
+	candleRepo, err := adaptor.NewSQLiteCandleRepository(dbPath, sqliteMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize candle repository: %w", err)
+	}
+	marketData := service.NewMarketDataCache(engine, candleRepo)
+
+	engineCtx, stopEngine := context.WithCancel(context.Background())
+	engineDone := make(chan struct{})
+	go func() {
+		engine.Run(engineCtx)
+		close(engineDone)
+	}()
+
+	marketDataCtx, stopMarketData := context.WithCancel(context.Background())
+	marketDataDone := make(chan struct{})
+	go func() {
+		marketData.Run(marketDataCtx, feed)
+		close(marketDataDone)
+	}()
+
+	// Repositories of other tenants are named after the tenant
+	prefix := ""
+	if tenant.ID != domain.DefaultTenantID {
+		prefix = tenant.ID + "/"
+	}
+	slog.Info("Tenant serving", "tenant_id", tenant.ID, "path", dbPath)
+
+	return &tenantStack{
+		tenant:     tenant,
+		orders:     stockService,
+		events:     orderEvents,
+		marketData: marketData,
+		orderRate:  orderRateLimiter,
+		repositories: map[string]interface{}{
+			prefix + "sqlite":             repo,
+			prefix + "sqlite_candles":     candleRepo,
+			prefix + "sqlite_trades":      tradeRepo,
+			prefix + "sqlite_instruments": instrumentRepo,
+		},
+		stop: func() {
+			stopEngine()
+			<-engineDone
+			stopMarketData()
+			<-marketDataDone
+		},
+	}, nil
+}
+
+// newTLSConfigs returns the TLS configs of the HTTP and gRPC servers, or nil
+// configs when TLS_CERT_FILE is not set. With TLS_CLIENT_CA_FILE set, gRPC
+// requires client certificates signed by that CA (mutual TLS) while HTTPS
+// verifies them only when a client presents one, so browsers and API key
+// callers can still connect.
+func newTLSConfigs() (*tls.Config, *tls.Config, error) {
+	certFile := os.Getenv("TLS_CERT_FILE")
+	if certFile == "" {
+		return nil, nil, nil
+	}
+
+	minVersion, err := adaptor.ParseTLSVersion(os.Getenv("TLS_MIN_VERSION"))
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := adaptor.TLSOptions{
+		CertFile:     certFile,
+		KeyFile:      os.Getenv("TLS_KEY_FILE"),
+		ClientCAFile: os.Getenv("TLS_CLIENT_CA_FILE"),
+		MinVersion:   minVersion,
+	}
+
+	httpOpts, grpcOpts := opts, opts
+	if opts.ClientCAFile != "" {
+		httpOpts.ClientAuth = tls.VerifyClientCertIfGiven
+		grpcOpts.ClientAuth = tls.RequireAndVerifyClientCert
+		slog.Info("Mutual TLS required for gRPC")
+	}
+
+	httpTLS, err := adaptor.NewServerTLSConfig(httpOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	grpcTLS, err := adaptor.NewServerTLSConfig(grpcOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	slog.Info("TLS enabled", "path", certFile)
+	return httpTLS, grpcTLS, nil
+}
+
+// newPolicy loads the authorization policy from file, or uses the default
+// policy when file is empty. Unless authentication is required, anonymous
+// callers trade for the account in X-Account-ID, but never act on other
+// accounts or manage the market.
+func newPolicy(file string, authRequired bool) (domain.Policy, error) {
+	policy := service.DefaultPolicy()
+	if file != "" {
+		loaded, err := adaptor.NewFilePolicySource(file).LoadPolicy(context.Background())
+		if err != nil {
+			return domain.Policy{}, err
+		}
+		policy = loaded
+		slog.Info("Loaded authorization policy", "path", file)
+	}
+
+	if !authRequired && len(policy.Anonymous) == 0 {
+		policy.Anonymous = []domain.Role{domain.RoleTrader}
+	}
+	return policy, nil
+}
+
+// newMarketDataFeed replays recorded ticks from file when it is set.
+// speed defaults to real time.
+func newMarketDataFeed(file, speed string) (port.MarketDataFeed, error) {
+	if file == "" {
+		return nil, nil
+	}
+
+	opts := adaptor.ReplayOptions{Speed: 1, Rebase: true, Loop: true}
+	if speed != "" {
+		parsed, err := strconv.ParseFloat(speed, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MARKET_DATA_SPEED: %w", err)
+		}
+		opts.Speed = parsed
+	}
+
+	slog.Info("Replaying market data", "path", file, "speed", opts.Speed)
+	return adaptor.NewReplayMarketDataFeed(file, opts)
+}
+
+// fatal logs msg with err and exits
+func fatal(msg string, err error, attrs ...any) {
+	slog.Error(msg, append(attrs, "error", err)...)
+	os.Exit(1)
+}
+
+// newLogHandler writes JSON logs at LOG_LEVEL, info unless set
+func newLogHandler() (slog.Handler, error) {
+	var level slog.Level
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		if err := level.UnmarshalText([]byte(value)); err != nil {
+			return nil, fmt.Errorf("invalid LOG_LEVEL %q: %w", value, err)
+		}
+	}
+	return adaptor.NewLogHandler(os.Stderr, level), nil
 }
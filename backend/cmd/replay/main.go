@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/adaptor"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+func main() {
+	file := flag.String("file", "./market_data.csv", "CSV or JSONL file with recorded ticks")
+	speed := flag.Float64("speed", 1, "replay speed multiplier, 0 replays as fast as possible")
+	loop := flag.Bool("loop", false, "restart the replay when the file is exhausted")
+	flag.Parse()
+
+	feed, err := adaptor.NewReplayMarketDataFeed(*file, adaptor.ReplayOptions{Speed: *speed, Loop: *loop})
+	if err != nil {
+		log.Fatalf("Failed to open market data feed: %v", err)
+	}
+	defer feed.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	events, err := feed.Subscribe(ctx, flag.Args()...)
+	if err != nil {
+		log.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	for event := range events {
+		switch event.Type {
+		case domain.MarketDataEventQuote:
+			q := event.Quote
+			log.Printf("QUOTE %-6s %d @ %.2f / %d @ %.2f", q.Symbol, q.BidSize, q.BidPrice, q.AskSize, q.AskPrice)
+		case domain.MarketDataEventTrade:
+			t := event.Trade
+			log.Printf("TRADE %-6s %d @ %.2f", t.Symbol, t.Quantity, t.Price)
+		}
+	}
+
+	log.Println("Replay finished")
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+type accountIDKey struct{}
+
+// ContextWithAccountID attaches the calling account to ctx
+func ContextWithAccountID(ctx context.Context, accountID string) context.Context {
+	return context.WithValue(ctx, accountIDKey{}, accountID)
+}
+
+// AccountIDFromContext returns the calling account, or "" when the caller is anonymous
+func AccountIDFromContext(ctx context.Context) string {
+	accountID, _ := ctx.Value(accountIDKey{}).(string)
+	return accountID
+}
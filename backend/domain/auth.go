@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AuthMethod names how a principal proved its identity
+type AuthMethod string
+
+const (
+	AuthMethodAPIKey AuthMethod = "api_key"
+	AuthMethodJWT    AuthMethod = "jwt"
+)
+
+// Principal is an authenticated caller. Subject is the API key ID or the
+// token subject; the principal acts for AccountID of TenantID, where an
+// empty tenant is the default tenant.
+type Principal struct {
+	Subject   string     `json:"subject"`
+	AccountID string     `json:"account_id"`
+	TenantID  string     `json:"tenant_id,omitempty"`
+	Roles     []string   `json:"roles,omitempty"`
+	Method    AuthMethod `json:"method"`
+}
+
+// Credentials are the secrets a caller presented; both may be empty for an
+// anonymous caller
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+}
+
+// APIKey is a stored API key. Only a hash of the secret is kept; the secret
+// itself is shown once when the key is created.
+type APIKey struct {
+	ID        string     `json:"id"`
+	AccountID string     `json:"account_id"`
+	TenantID  string     `json:"tenant_id,omitempty"`
+	Name      string     `json:"name"`
+	Roles     []string   `json:"roles,omitempty"`
+	Hash      string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+type CreateAPIKeyRequest struct {
+	AccountID string   `json:"account_id" validate:"required,max=64"`
+	TenantID  string   `json:"tenant_id" validate:"max=63"`
+	Name      string   `json:"name" validate:"max=128"`
+	Roles     []string `json:"roles"`
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal attaches an authenticated caller to ctx. The caller's
+// account and tenant become the account and tenant of ctx.
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+	ctx = ContextWithTenantID(ctx, principal.TenantID)
+	return ContextWithAccountID(ctx, principal.AccountID)
+}
+
+// PrincipalFromContext returns the authenticated caller, or nil when the
+// caller is anonymous
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Role is a set of permissions granted to a principal
+type Role string
+
+const (
+	// RoleViewer may read orders, instruments and market data
+	RoleViewer Role = "viewer"
+	// RoleTrader may also create, amend and cancel its own orders
+	RoleTrader Role = "trader"
+	// RoleOps may also cancel any account's orders and halt symbols
+	RoleOps Role = "ops"
+	// RoleAdmin may do everything
+	RoleAdmin Role = "admin"
+)
+
+var Roles = []Role{RoleViewer, RoleTrader, RoleOps, RoleAdmin}
+
+func (r Role) IsValid() bool {
+	return slices.Contains(Roles, r)
+}
+
+// Operation names one operation of the order or market data service for
+// authorization
+type Operation string
+
+const (
+	OperationCreateOrder       Operation = "CreateOrder"
+	OperationGetOrder          Operation = "GetOrder"
+	OperationListOrders        Operation = "ListOrders"
+	OperationListOrderFills    Operation = "ListOrderFills"
+	OperationCancelOrder       Operation = "CancelOrder"
+	OperationBatchCreateOrders Operation = "BatchCreateOrders"
+	OperationMassCancel        Operation = "MassCancel"
+	OperationAmendOrder        Operation = "AmendOrder"
+	OperationGetMarketSession  Operation = "GetMarketSession"
+	OperationGetAuctionState   Operation = "GetAuctionState"
+	OperationWatchOrders       Operation = "WatchOrders"
+	OperationListInstruments   Operation = "ListInstruments"
+	OperationUpsertInstrument  Operation = "UpsertInstrument"
+	OperationDeleteInstrument  Operation = "DeleteInstrument"
+	OperationHaltSymbol        Operation = "HaltSymbol"
+	OperationResumeSymbol      Operation = "ResumeSymbol"
+	OperationGetQuote          Operation = "GetQuote"
+	OperationGetCandles        Operation = "GetCandles"
+	OperationSubscribeQuotes   Operation = "SubscribeQuotes"
+	OperationStreamOrderBook   Operation = "StreamOrderBook"
+)
+
+var Operations = []Operation{
+	OperationCreateOrder, OperationGetOrder, OperationListOrders, OperationListOrderFills,
+	OperationCancelOrder, OperationBatchCreateOrders, OperationMassCancel, OperationAmendOrder,
+	OperationGetMarketSession, OperationGetAuctionState, OperationWatchOrders,
+	OperationListInstruments, OperationUpsertInstrument, OperationDeleteInstrument,
+	OperationHaltSymbol, OperationResumeSymbol,
+	OperationGetQuote, OperationGetCandles, OperationSubscribeQuotes, OperationStreamOrderBook,
+}
+
+// Policy declares which roles may perform each operation. Callers holding
+// an AllAccounts role may read and act on the orders of every account;
+// everyone else only sees their own. Anonymous callers hold the Anonymous
+// roles.
+type Policy struct {
+	Grants      map[Operation][]Role `json:"grants"`
+	AllAccounts []Role               `json:"all_accounts"`
+	Anonymous   []Role               `json:"anonymous"`
+}
+
+// Validate rejects policies naming unknown roles or operations, leaving an
+// operation without a grant, or letting anonymous callers access every account
+func (p *Policy) Validate() error {
+	for operation, roles := range p.Grants {
+		if !slices.Contains(Operations, operation) {
+			return fmt.Errorf("policy grants unknown operation %q", operation)
+		}
+		if err := validateRoles(roles); err != nil {
+			return fmt.Errorf("policy grant for %s: %w", operation, err)
+		}
+	}
+	for _, operation := range Operations {
+		if _, ok := p.Grants[operation]; !ok {
+			return fmt.Errorf("policy has no grant for %s", operation)
+		}
+	}
+	if err := validateRoles(p.AllAccounts); err != nil {
+		return fmt.Errorf("policy all_accounts: %w", err)
+	}
+	if err := validateRoles(p.Anonymous); err != nil {
+		return fmt.Errorf("policy anonymous: %w", err)
+	}
+	for _, role := range p.Anonymous {
+		if slices.Contains(p.AllAccounts, role) {
+			return fmt.Errorf("policy anonymous: role %q may access all accounts", role)
+		}
+	}
+	return nil
+}
+
+// Authorize returns a permission denied error unless the caller of ctx holds
+// a role granted operation
+func (p *Policy) Authorize(ctx context.Context, operation Operation) error {
+	for _, role := range p.callerRoles(ctx) {
+		if slices.Contains(p.Grants[operation], role) {
+			return nil
+		}
+	}
+	return NewPermissionDeniedError(operation)
+}
+
+// CanAccessAllAccounts reports whether the caller of ctx may act on orders
+// of accounts other than its own
+func (p *Policy) CanAccessAllAccounts(ctx context.Context) bool {
+	for _, role := range p.callerRoles(ctx) {
+		if slices.Contains(p.AllAccounts, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) callerRoles(ctx context.Context) []Role {
+	principal := PrincipalFromContext(ctx)
+	if principal == nil {
+		return p.Anonymous
+	}
+
+	roles := make([]Role, 0, len(principal.Roles))
+	for _, role := range principal.Roles {
+		roles = append(roles, Role(role))
+	}
+	return roles
+}
+
+func validateRoles(roles []Role) error {
+	for _, role := range roles {
+		if !role.IsValid() {
+			return fmt.Errorf("unknown role %q", role)
+		}
+	}
+	return nil
+}
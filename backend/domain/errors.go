@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Error kinds. Errors returned by services wrap one of them so that adaptors
+// can choose a status with errors.Is; anything else is an internal error.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidState = errors.New("invalid state")
+	ErrValidation   = errors.New("validation failed")
+	ErrConflict     = errors.New("conflict")
+	ErrUnavailable  = errors.New("unavailable")
+
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrRateLimited      = errors.New("rate limited")
+)
+
+// Error is a failure of a given kind. Field names the offending input of a
+// validation error and Resource the kind of entity that was not found.
+// Violations lists every invalid field when a request fails validation.
+// RetryAfter tells a rate limited or shed caller when to try again.
+type Error struct {
+	Kind       error
+	Message    string
+	Field      string
+	Resource   string
+	Violations []FieldViolation
+	RetryAfter time.Duration
+	Err        error
+}
+
+// FieldViolation describes one invalid field of a request
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+func NewNotFoundError(resource, id string) error {
+	return &Error{Kind: ErrNotFound, Message: fmt.Sprintf("%s not found: %s", resource, id), Resource: resource}
+}
+
+// NewValidationError reports invalid input; field may be empty when the
+// problem is not tied to a single field
+func NewValidationError(field, format string, args ...any) error {
+	err := &Error{Kind: ErrValidation, Message: fmt.Sprintf(format, args...), Field: field}
+	if field != "" {
+		err.Violations = []FieldViolation{{Field: field, Message: err.Message}}
+	}
+	return err
+}
+
+// NewFieldValidationError reports every invalid field of a request at once
+func NewFieldValidationError(violations []FieldViolation) error {
+	message := "invalid request"
+	for i, violation := range violations {
+		if i == 0 {
+			message += ": "
+		} else {
+			message += "; "
+		}
+		message += violation.Field + " " + violation.Message
+	}
+
+	err := &Error{Kind: ErrValidation, Message: message, Violations: violations}
+	if len(violations) == 1 {
+		err.Field = violations[0].Field
+	}
+	return err
+}
+
+// NewInvalidStateError reports a request that is valid but cannot be
+// applied in the current state, such as cancelling a filled order
+func NewInvalidStateError(format string, args ...any) error {
+	return &Error{Kind: ErrInvalidState, Message: fmt.Sprintf(format, args...)}
+}
+
+func NewConflictError(format string, args ...any) error {
+	return &Error{Kind: ErrConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewUnavailableError reports a failure of a dependency, such as the
+// database, that may succeed when retried
+func NewUnavailableError(err error, format string, args ...any) error {
+	return &Error{Kind: ErrUnavailable, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// NewUnauthenticatedError reports a missing, invalid or expired credential
+func NewUnauthenticatedError(format string, args ...any) error {
+	return &Error{Kind: ErrUnauthenticated, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewPermissionDeniedError reports an authenticated caller whose roles do
+// not allow the operation
+func NewPermissionDeniedError(operation Operation) error {
+	return &Error{Kind: ErrPermissionDenied, Message: fmt.Sprintf("%s is not permitted for the caller's roles", operation)}
+}
+
+// NewRateLimitedError reports a caller that exceeded a rate limit and may
+// retry after retryAfter
+func NewRateLimitedError(retryAfter time.Duration, format string, args ...any) error {
+	return &Error{Kind: ErrRateLimited, Message: fmt.Sprintf(format, args...), RetryAfter: retryAfter}
+}
+
+// NewOverloadedError reports a request shed because the service is at
+// capacity; it may be retried after retryAfter
+func NewOverloadedError(retryAfter time.Duration, format string, args ...any) error {
+	return &Error{Kind: ErrUnavailable, Message: fmt.Sprintf(format, args...), RetryAfter: retryAfter}
+}
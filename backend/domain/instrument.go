@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Instrument is a tradable symbol. A halted instrument accepts cancels but
+// no new or amended orders. Orders must be a multiple of LotSize shares and
+// limit prices a multiple of TickSize; zero disables either check.
+type Instrument struct {
+	Symbol     string    `json:"symbol"`
+	Name       string    `json:"name"`
+	LotSize    int       `json:"lot_size" validate:"gte=0"`
+	TickSize   float64   `json:"tick_size" validate:"gte=0"`
+	Halted     bool      `json:"halted"`
+	HaltReason string    `json:"halt_reason,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// UpsertInstrumentRequest creates or replaces the reference data of an
+// instrument; its halt state is changed with HaltSymbol and ResumeSymbol
+type UpsertInstrumentRequest struct {
+	Name     string  `json:"name" validate:"max=128"`
+	LotSize  int     `json:"lot_size" validate:"gte=0"`
+	TickSize float64 `json:"tick_size" validate:"gte=0"`
+}
+
+type HaltSymbolRequest struct {
+	Reason string `json:"reason" validate:"max=256"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+// Priority orders operations competing for capacity when the service is
+// overloaded. Higher priorities are admitted first and shed last, so that
+// cancelling orders keeps working while new orders are turned away.
+type Priority int
+
+const (
+	// PriorityLow is for new orders
+	PriorityLow Priority = iota
+	// PriorityNormal is for reads, amendments and administration
+	PriorityNormal
+	// PriorityHigh is for cancels and trading halts
+	PriorityHigh
+)
+
+var Priorities = []Priority{PriorityLow, PriorityNormal, PriorityHigh}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import "time"
+
+type MarketDataEventType string
+
+const (
+	MarketDataEventQuote MarketDataEventType = "QUOTE"
+	MarketDataEventTrade MarketDataEventType = "TRADE"
+)
+
+type Quote struct {
+	Symbol    string    `json:"symbol"`
+	BidPrice  float64   `json:"bid_price"`
+	BidSize   int       `json:"bid_size"`
+	AskPrice  float64   `json:"ask_price"`
+	AskSize   int       `json:"ask_size"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MarketDataEvent carries either a quote or a trade for one symbol
+type MarketDataEvent struct {
+	Type      MarketDataEventType `json:"type"`
+	Symbol    string              `json:"symbol"`
+	Quote     *Quote              `json:"quote,omitempty"`
+	Trade     *Trade              `json:"trade,omitempty"`
+	Timestamp time.Time           `json:"timestamp"`
+}
+
+type PriceLevel struct {
+	Price      float64 `json:"price"`
+	Quantity   int     `json:"quantity"`
+	OrderCount int     `json:"order_count"`
+}
+
+// SymbolQuote is the cached market state of a symbol. Top of book is the best
+// price across the internal order book and the external feed; depth comes
+// from the internal order book only.
+type SymbolQuote struct {
+	Symbol       string       `json:"symbol"`
+	LastPrice    float64      `json:"last_price,omitempty"`
+	LastQuantity int          `json:"last_quantity,omitempty"`
+	LastTradeAt  *time.Time   `json:"last_trade_at,omitempty"`
+	BidPrice     float64      `json:"bid_price,omitempty"`
+	BidSize      int          `json:"bid_size,omitempty"`
+	AskPrice     float64      `json:"ask_price,omitempty"`
+	AskSize      int          `json:"ask_size,omitempty"`
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// OrderBookUpdate describes the aggregated price levels of one symbol's order
+// book. A snapshot carries every level within the requested depth; an
+// incremental update carries only the levels that changed since the previous
+// message, where a zero quantity removes the level. Updates are conflated: the
+// changes made while a client was slow arrive together in one update, so the
+// sequence may skip but no change is ever lost.
+type OrderBookUpdate struct {
+	Symbol    string       `json:"symbol"`
+	Sequence  uint64       `json:"sequence"`
+	Snapshot  bool         `json:"snapshot"`
+	Bids      []PriceLevel `json:"bids"`
+	Asks      []PriceLevel `json:"asks"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type CandleInterval string
+
+const (
+	CandleInterval1m CandleInterval = "1m"
+	CandleInterval5m CandleInterval = "5m"
+	CandleInterval1h CandleInterval = "1h"
+	CandleInterval1d CandleInterval = "1d"
+)
+
+// CandleIntervals lists every interval that is aggregated
+var CandleIntervals = []CandleInterval{CandleInterval1m, CandleInterval5m, CandleInterval1h, CandleInterval1d}
+
+// Duration returns the length of the interval, or zero if it is unknown
+func (i CandleInterval) Duration() time.Duration {
+	switch i {
+	case CandleInterval1m:
+		return time.Minute
+	case CandleInterval5m:
+		return 5 * time.Minute
+	case CandleInterval1h:
+		return time.Hour
+	case CandleInterval1d:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// Candle is an OHLCV bar. OpenTime is aligned to the interval in UTC.
+type Candle struct {
+	Symbol     string         `json:"symbol"`
+	Interval   CandleInterval `json:"interval"`
+	OpenTime   time.Time      `json:"open_time"`
+	Open       float64        `json:"open"`
+	High       float64        `json:"high"`
+	Low        float64        `json:"low"`
+	Close      float64        `json:"close"`
+	Volume     int64          `json:"volume"`
+	TradeCount int            `json:"trade_count"`
+}
+
+type CandleQuery struct {
+	Symbol   string
+	Interval CandleInterval
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
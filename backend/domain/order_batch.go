@@ -0,0 +1,32 @@
+package domain
+
+// BatchCreateOrdersRequest places several orders at once. In atomic mode
+// either every order is created or none is; otherwise each order succeeds or
+// fails on its own.
+type BatchCreateOrdersRequest struct {
+	Orders []CreateOrderRequest `json:"orders"`
+	Atomic bool                 `json:"atomic,omitempty"`
+}
+
+// BatchOrderResult is the outcome of one order of a batch, in request order
+type BatchOrderResult struct {
+	Index int         `json:"index"`
+	Order *StockOrder `json:"order,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// MassCancelRequest selects the open orders to cancel; empty fields match
+// every order. AccountID defaults to the caller's account, and AllAccounts
+// cancels the orders of every account instead.
+type MassCancelRequest struct {
+	AccountID   string    `json:"account_id,omitempty" validate:"max=64"`
+	AllAccounts bool      `json:"all_accounts,omitempty"`
+	Symbol      string    `json:"symbol,omitempty"`
+	OrderSide   OrderSide `json:"order_side,omitempty" validate:"omitempty,enum"`
+}
+
+type CancelResult struct {
+	OrderID   string `json:"order_id"`
+	Cancelled bool   `json:"cancelled"`
+	Error     string `json:"error,omitempty"`
+}
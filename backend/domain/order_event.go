@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// OrderEvent records a change of an order. Sequence numbers are per account
+// and increase by one for every event of the account, so a watcher of all of
+// an account's symbols sees a gap only when events were missed; a symbol
+// filter also skips the numbers of the account's other symbols.
+type OrderEvent struct {
+	Sequence   uint64     `json:"sequence"`
+	Order      StockOrder `json:"order"`
+	OccurredAt time.Time  `json:"occurred_at"`
+}
+
+type OrderEventFilter struct {
+	// AccountID limits events to one account; empty matches every account
+	AccountID string
+	// Symbol limits events to one symbol; empty matches every symbol
+	Symbol string
+	// FromSequence replays the account's retained events starting at this
+	// sequence; it requires AccountID
+	FromSequence uint64
+}
+
+// Matches reports whether the event passes the account and symbol filters
+func (f OrderEventFilter) Matches(event OrderEvent) bool {
+	if f.AccountID != "" && event.Order.AccountID != f.AccountID {
+		return false
+	}
+	if f.Symbol != "" && event.Order.Symbol != f.Symbol {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+type OrderSort string
+
+const (
+	OrderSortCreatedAtDesc OrderSort = "created_at_desc"
+	OrderSortCreatedAtAsc  OrderSort = "created_at_asc"
+)
+
+// OrderQuery filters and pages through orders. Zero fields do not filter;
+// CreatedFrom is inclusive and CreatedTo exclusive. PageToken continues a
+// previous query and must be used with the same sort order. IncludeFills
+// loads the executions of the page's orders with them.
+type OrderQuery struct {
+	AccountID    string
+	Symbol       string
+	OrderSide    OrderSide
+	OrderType    OrderType
+	Status       OrderStatus
+	CreatedFrom  time.Time
+	CreatedTo    time.Time
+	Sort         OrderSort
+	Limit        int
+	PageToken    string
+	IncludeFills bool
+}
+
+// OrderPage is one page of orders. NextPageToken is empty on the last page.
+// Fills holds the executions of each order, oldest first, when the query
+// included them; orders without executions are absent.
+type OrderPage struct {
+	Orders        []*StockOrder      `json:"orders"`
+	NextPageToken string             `json:"next_page_token,omitempty"`
+	Fills         map[string][]Trade `json:"-"`
+}
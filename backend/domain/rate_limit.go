@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// RateLimit is a token bucket refilled with Rate tokens per second and
+// holding at most Burst tokens. A zero rate means no limit; a zero burst
+// holds one second of tokens.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// Unlimited reports whether the limit lets every request through
+func (l RateLimit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+// BurstSize is the configured burst, or one second of tokens when unset
+func (l RateLimit) BurstSize() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Max(1, math.Ceil(l.Rate)))
+}
+
+func (l RateLimit) validate() error {
+	if l.Rate < 0 || l.Burst < 0 {
+		return fmt.Errorf("rate and burst must not be negative")
+	}
+	return nil
+}
+
+// RateLimitPolicy declares the request rates the service accepts. Global
+// limits all callers together, PerCaller each account or API key, and
+// Operations each caller per HTTP route ("POST /api/orders") or gRPC method
+// ("/stockorder.v1.StockOrderService/CreateOrder"). Orders limits the orders
+// each account may place, whichever transport or batch they arrive in.
+type RateLimitPolicy struct {
+	Global     RateLimit            `json:"global"`
+	PerCaller  RateLimit            `json:"per_caller"`
+	Operations map[string]RateLimit `json:"operations"`
+	Orders     RateLimit            `json:"orders"`
+}
+
+// Validate rejects negative rates and bursts
+func (p *RateLimitPolicy) Validate() error {
+	for name, limit := range map[string]RateLimit{"global": p.Global, "per_caller": p.PerCaller, "orders": p.Orders} {
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("rate limit %s: %w", name, err)
+		}
+	}
+	for operation, limit := range p.Operations {
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("rate limit for %s: %w", operation, err)
+		}
+	}
+	return nil
+}
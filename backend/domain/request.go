@@ -0,0 +1,57 @@
+package domain
+
+import "context"
+
+type requestIDKey struct{}
+
+type methodKey struct{}
+
+type orderIDKey struct{}
+
+type clientAddressKey struct{}
+
+// ContextWithRequestID attaches the ID correlating everything done for one
+// request
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID, or "" outside of a request
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ContextWithMethod attaches the HTTP route or gRPC method being served
+func ContextWithMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+// MethodFromContext returns the HTTP route or gRPC method being served, or ""
+func MethodFromContext(ctx context.Context) string {
+	method, _ := ctx.Value(methodKey{}).(string)
+	return method
+}
+
+// ContextWithOrderID attaches the order an operation acts on
+func ContextWithOrderID(ctx context.Context, orderID string) context.Context {
+	return context.WithValue(ctx, orderIDKey{}, orderID)
+}
+
+// OrderIDFromContext returns the order an operation acts on, or ""
+func OrderIDFromContext(ctx context.Context) string {
+	orderID, _ := ctx.Value(orderIDKey{}).(string)
+	return orderID
+}
+
+// ContextWithClientAddress attaches the network address, without the port, a
+// request came from
+func ContextWithClientAddress(ctx context.Context, address string) context.Context {
+	return context.WithValue(ctx, clientAddressKey{}, address)
+}
+
+// ClientAddressFromContext returns the address a request came from, or ""
+func ClientAddressFromContext(ctx context.Context) string {
+	address, _ := ctx.Value(clientAddressKey{}).(string)
+	return address
+}
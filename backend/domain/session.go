@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+type SessionPhase string
+
+const (
+	SessionPhasePreOpen        SessionPhase = "PRE_OPEN"
+	SessionPhaseContinuous     SessionPhase = "CONTINUOUS"
+	SessionPhaseClosingAuction SessionPhase = "CLOSING_AUCTION"
+	SessionPhaseClosed         SessionPhase = "CLOSED"
+)
+
+// AcceptsOrders reports whether new orders may be entered during the phase
+func (p SessionPhase) AcceptsOrders() bool {
+	return p != SessionPhaseClosed
+}
+
+// AllowsMatching reports whether incoming orders may trade immediately
+func (p SessionPhase) AllowsMatching() bool {
+	return p == SessionPhaseContinuous
+}
+
+// IsCallAuction reports whether orders are collected for a single-price uncross
+func (p SessionPhase) IsCallAuction() bool {
+	return p == SessionPhasePreOpen || p == SessionPhaseClosingAuction
+}
+
+// MarketSchedule describes the daily trading timetable of a market.
+// Times are "HH:MM" in the market's local timezone.
+type MarketSchedule struct {
+	Market         string         `json:"market"`
+	Timezone       string         `json:"timezone"`
+	PreOpen        string         `json:"pre_open"`
+	Open           string         `json:"open"`
+	ClosingAuction string         `json:"closing_auction"`
+	Close          string         `json:"close"`
+	TradingDays    []time.Weekday `json:"trading_days"`
+	Symbols        []string       `json:"symbols,omitempty"`
+}
+
+// Holiday closes a market for the whole day or, when EarlyClose is set, makes
+// it a half day that closes at EarlyClose ("HH:MM" local time)
+type Holiday struct {
+	Market     string    `json:"market"`
+	Date       time.Time `json:"date"`
+	Name       string    `json:"name,omitempty"`
+	EarlyClose string    `json:"early_close,omitempty"`
+}
+
+type MarketSession struct {
+	Market      string       `json:"market"`
+	Symbol      string       `json:"symbol,omitempty"`
+	Phase       SessionPhase `json:"phase"`
+	Holiday     string       `json:"holiday,omitempty"`
+	NextPhase   SessionPhase `json:"next_phase"`
+	NextPhaseAt time.Time    `json:"next_phase_at"`
+	AsOf        time.Time    `json:"as_of"`
+}
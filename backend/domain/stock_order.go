@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 type OrderType string
 type OrderSide string
@@ -13,29 +17,109 @@ const (
 	OrderSideBuy  OrderSide = "BUY"
 	OrderSideSell OrderSide = "SELL"
 
-	OrderStatusPending   OrderStatus = "PENDING"
-	OrderStatusFilled    OrderStatus = "FILLED"
-	OrderStatusCancelled OrderStatus = "CANCELLED"
-	OrderStatusRejected  OrderStatus = "REJECTED"
+	OrderStatusPending         OrderStatus = "PENDING"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCancelled       OrderStatus = "CANCELLED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+)
+
+// IsValid reports whether t is a known order type
+func (t OrderType) IsValid() bool {
+	return t == OrderTypeMarket || t == OrderTypeLimit
+}
+
+// IsValid reports whether s is a known order side
+func (s OrderSide) IsValid() bool {
+	return s == OrderSideBuy || s == OrderSideSell
+}
+
+var (
+	// ErrDuplicateClientOrderID is returned when an account reuses a client order ID
+	ErrDuplicateClientOrderID = &Error{Kind: ErrConflict, Message: "duplicate client order ID", Field: "client_order_id"}
+	// ErrClientOrderIDMismatch is returned when a retry reuses a client order ID
+	// with different order parameters
+	ErrClientOrderIDMismatch = &Error{Kind: ErrConflict, Message: "client order ID was already used for a different order", Field: "client_order_id"}
+	// ErrBatchRolledBack is returned when an atomic batch created no orders
+	// because at least one of them was invalid
+	ErrBatchRolledBack = &Error{Kind: ErrValidation, Message: "atomic batch rolled back"}
 )
 
 type StockOrder struct {
-	ID          string      `json:"id"`
-	Symbol      string      `json:"symbol"`
-	OrderType   OrderType   `json:"order_type"`
-	OrderSide   OrderSide   `json:"order_side"`
-	Quantity    int         `json:"quantity"`
-	Price       float64     `json:"price,omitempty"`
-	Status      OrderStatus `json:"status"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
-	Description string      `json:"description,omitempty"`
+	ID             string      `json:"id"`
+	AccountID      string      `json:"account_id,omitempty"`
+	ClientOrderID  string      `json:"client_order_id,omitempty"`
+	Symbol         string      `json:"symbol"`
+	OrderType      OrderType   `json:"order_type"`
+	OrderSide      OrderSide   `json:"order_side"`
+	Quantity       int         `json:"quantity"`
+	Price          float64     `json:"price,omitempty"`
+	Status         OrderStatus `json:"status"`
+	FilledQuantity int         `json:"filled_quantity"`
+	AveragePrice   float64     `json:"average_price,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	Description    string      `json:"description,omitempty"`
+	// RequestFingerprint is the Fingerprint of the request that created the
+	// order; it is only loaded when looking an order up by client order ID
+	RequestFingerprint string `json:"-"`
+}
+
+// RemainingQuantity returns the quantity that is still open for execution
+func (o *StockOrder) RemainingQuantity() int {
+	return o.Quantity - o.FilledQuantity
+}
+
+// IsOpen reports whether the order can still be executed or cancelled
+func (o *StockOrder) IsOpen() bool {
+	return o.Status == OrderStatusPending || o.Status == OrderStatusPartiallyFilled
 }
 
 type CreateOrderRequest struct {
-	Symbol    string    `json:"symbol" validate:"required"`
-	OrderType OrderType `json:"order_type" validate:"required"`
-	OrderSide OrderSide `json:"order_side" validate:"required"`
-	Quantity  int       `json:"quantity" validate:"required,gt=0"`
-	Price     float64   `json:"price,omitempty"`
+	// ClientOrderID makes the request idempotent: retrying it returns the
+	// order created by the first attempt
+	ClientOrderID string    `json:"client_order_id,omitempty" validate:"max=64"`
+	Symbol        string    `json:"symbol" validate:"required"`
+	OrderType     OrderType `json:"order_type" validate:"required,enum"`
+	OrderSide     OrderSide `json:"order_side" validate:"required,enum"`
+	Quantity      int       `json:"quantity" validate:"required,gt=0"`
+	Price         float64   `json:"price,omitempty" validate:"gte=0"`
+}
+
+// Fingerprint identifies the order parameters of req, so that a retry is still
+// recognised after the order it created has been amended
+func (req CreateOrderRequest) Fingerprint() string {
+	return strings.Join([]string{
+		NormalizeSymbol(req.Symbol),
+		string(req.OrderType),
+		string(req.OrderSide),
+		strconv.Itoa(req.Quantity),
+		strconv.FormatFloat(req.Price, 'g', -1, 64),
+	}, "|")
+}
+
+// Matches reports whether order was created from the same parameters as req.
+// Orders stored before fingerprints were recorded are compared with their
+// current parameters.
+func (req CreateOrderRequest) Matches(order *StockOrder) bool {
+	if order.RequestFingerprint != "" {
+		return req.Fingerprint() == order.RequestFingerprint
+	}
+	return NormalizeSymbol(req.Symbol) == order.Symbol &&
+		req.OrderType == order.OrderType &&
+		req.OrderSide == order.OrderSide &&
+		req.Quantity == order.Quantity &&
+		req.Price == order.Price
+}
+
+// NormalizeSymbol returns symbol in the form orders, books and market data are
+// keyed by: upper case without surrounding spaces
+func NormalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+// AmendOrderRequest changes an open limit order; zero fields are left unchanged
+type AmendOrderRequest struct {
+	Quantity int     `json:"quantity,omitempty" validate:"gte=0"`
+	Price    float64 `json:"price,omitempty" validate:"gte=0"`
 }
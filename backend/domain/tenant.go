@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// DefaultTenantID is the tenant of callers that name none, and of API keys
+// and tokens issued without one
+const DefaultTenantID = "default"
+
+// tenantIDPattern keeps tenant IDs usable in file names
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Tenant is a desk or broker sharing the deployment. Tenants never see each
+// other's orders, fills or instruments. Instruments are registered for the
+// tenant at startup, Fees are charged on its executions and OrderRate
+// replaces the deployment's order rate limit for its accounts.
+type Tenant struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name,omitempty"`
+	Instruments []TenantInstrument `json:"instruments,omitempty"`
+	Fees        FeeSchedule        `json:"fees"`
+	OrderRate   *RateLimit         `json:"order_rate,omitempty"`
+}
+
+// TenantInstrument is the reference data of an instrument registered for a
+// tenant; its halt state is kept across restarts
+type TenantInstrument struct {
+	Symbol string `json:"symbol"`
+	UpsertInstrumentRequest
+}
+
+// FeeSchedule is the commission charged to each side of an execution:
+// RateBps basis points of the notional, but at least Minimum
+type FeeSchedule struct {
+	RateBps float64 `json:"rate_bps"`
+	Minimum float64 `json:"minimum"`
+}
+
+// Fee returns the commission for executing quantity shares at price
+func (f FeeSchedule) Fee(price float64, quantity int) float64 {
+	if f.RateBps <= 0 && f.Minimum <= 0 {
+		return 0
+	}
+	fee := math.Max(price*float64(quantity)*f.RateBps/10000, f.Minimum)
+	// Drop the floating point noise of the multiplication
+	return math.Round(fee*1e6) / 1e6
+}
+
+// Validate rejects malformed IDs, negative fees and limits, and instruments
+// without a symbol
+func (t *Tenant) Validate() error {
+	if !tenantIDPattern.MatchString(t.ID) {
+		return fmt.Errorf("tenant ID %q must be lower case letters, digits, '-' or '_'", t.ID)
+	}
+	if t.Fees.RateBps < 0 || t.Fees.Minimum < 0 {
+		return fmt.Errorf("tenant %s: fees must not be negative", t.ID)
+	}
+	if t.OrderRate != nil {
+		if err := t.OrderRate.validate(); err != nil {
+			return fmt.Errorf("tenant %s: order rate: %w", t.ID, err)
+		}
+	}
+	for _, instrument := range t.Instruments {
+		if instrument.Symbol == "" {
+			return fmt.Errorf("tenant %s: instrument symbol is required", t.ID)
+		}
+		if instrument.LotSize < 0 || instrument.TickSize < 0 {
+			return fmt.Errorf("tenant %s: instrument %s: lot and tick size must not be negative", t.ID, instrument.Symbol)
+		}
+	}
+	return nil
+}
+
+type tenantIDKey struct{}
+
+// ContextWithTenantID attaches the calling tenant to ctx
+func ContextWithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+// TenantIDFromContext returns the calling tenant, or DefaultTenantID when
+// ctx names none
+func TenantIDFromContext(ctx context.Context) string {
+	if tenantID, _ := ctx.Value(tenantIDKey{}).(string); tenantID != "" {
+		return tenantID
+	}
+	return DefaultTenantID
+}
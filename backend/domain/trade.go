@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Trade is an execution between a buy and a sell order. Fee is the
+// commission charged to each side.
+type Trade struct {
+	ID          string    `json:"id"`
+	Symbol      string    `json:"symbol"`
+	Price       float64   `json:"price"`
+	Quantity    int       `json:"quantity"`
+	BuyOrderID  string    `json:"buy_order_id,omitempty"`
+	SellOrderID string    `json:"sell_order_id,omitempty"`
+	Fee         float64   `json:"fee,omitempty"`
+	ExecutedAt  time.Time `json:"executed_at"`
+}
+
+// AuctionState describes a running call auction. While orders are being
+// collected the indicative price is the price at which the book would
+// uncross if the call ended now.
+type AuctionState struct {
+	Symbol           string       `json:"symbol"`
+	Phase            SessionPhase `json:"phase"`
+	IndicativePrice  float64      `json:"indicative_price,omitempty"`
+	IndicativeVolume int          `json:"indicative_volume"`
+	ImbalanceSide    OrderSide    `json:"imbalance_side,omitempty"`
+	ImbalanceVolume  int          `json:"imbalance_volume"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+type AuthService interface {
+	// Authenticate returns the caller the credentials belong to, or nil
+	// without an error when no credentials were presented
+	Authenticate(ctx context.Context, credentials domain.Credentials) (*domain.Principal, error)
+	// CreateAPIKey stores a new key and returns it with its secret, which
+	// cannot be recovered later
+	CreateAPIKey(ctx context.Context, req domain.CreateAPIKeyRequest) (*domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID string) error
+}
+
+// TokenVerifier checks the signature and claims of a bearer token
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*domain.Principal, error)
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+type CandleRepository interface {
+	Upsert(ctx context.Context, candles []*domain.Candle) error
+	List(ctx context.Context, query domain.CandleQuery) ([]*domain.Candle, error)
+	Close() error
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+// ConcurrencyLimiter bounds the operations in flight, queueing or shedding
+// the rest when the limit is reached
+type ConcurrencyLimiter interface {
+	// Acquire waits for a slot. It fails with an unavailable error when the
+	// request is shed, or with the context error when ctx ends first. The
+	// caller must call release with the result of its operation, which the
+	// limiter uses to adapt the limit.
+	Acquire(ctx context.Context, priority domain.Priority) (release func(err error), err error)
+}
@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+type InstrumentRepository interface {
+	Upsert(ctx context.Context, instrument *domain.Instrument) error
+	// Get returns nil without an error when the symbol is not registered
+	Get(ctx context.Context, symbol string) (*domain.Instrument, error)
+	List(ctx context.Context) ([]*domain.Instrument, error)
+	Delete(ctx context.Context, symbol string) error
+	Close() error
+}
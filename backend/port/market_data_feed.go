@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+type MarketDataFeed interface {
+	// Subscribe streams quotes and trades for the given symbols, or for every
+	// symbol when none are given. The channel is closed when ctx is cancelled,
+	// the feed is exhausted or the feed is closed.
+	Subscribe(ctx context.Context, symbols ...string) (<-chan domain.MarketDataEvent, error)
+	Close() error
+}
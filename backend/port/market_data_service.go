@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+type MarketDataService interface {
+	GetQuote(ctx context.Context, symbol string) (*domain.SymbolQuote, error)
+	GetCandles(ctx context.Context, query domain.CandleQuery) ([]*domain.Candle, error)
+	// SubscribeQuotes streams quote snapshots for the given symbols until ctx
+	// is cancelled. Updates are conflated: a slow reader receives the latest
+	// quote of each symbol rather than every intermediate one.
+	SubscribeQuotes(ctx context.Context, symbols []string) (<-chan domain.SymbolQuote, error)
+	// StreamOrderBook sends a snapshot of up to depth price levels per side of
+	// symbol's order book followed by incremental updates until ctx is cancelled.
+	// A depth of zero streams every level.
+	StreamOrderBook(ctx context.Context, symbol string, depth int) (<-chan domain.OrderBookUpdate, error)
+}
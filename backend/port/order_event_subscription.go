@@ -0,0 +1,11 @@
+package port
+
+import "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+
+type OrderEventSubscription interface {
+	// Events is closed when the subscription ends
+	Events() <-chan domain.OrderEvent
+	// Err explains why Events was closed; nil means the caller cancelled or
+	// the server is shutting down
+	Err() error
+}
@@ -0,0 +1,14 @@
+package port
+
+import "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+
+// OrderMetrics counts orders as they move through their lifecycle
+type OrderMetrics interface {
+	// OrderCreated counts an order accepted by the service
+	OrderCreated(order *domain.StockOrder)
+	// OrderCancelled counts an order cancelled by a caller or for lack of
+	// liquidity
+	OrderCancelled(order *domain.StockOrder)
+	// OrderFilled counts an order executed in full
+	OrderFilled(order *domain.StockOrder)
+}
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+// PolicySource loads an authorization policy from configuration
+type PolicySource interface {
+	LoadPolicy(ctx context.Context) (domain.Policy, error)
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+// RateLimiter keeps one token bucket per key, such as an account
+type RateLimiter interface {
+	// Allow takes a token from the bucket of key. When the bucket is empty
+	// it returns false and how long until a token is available.
+	Allow(key string) (bool, time.Duration)
+	// Reserve is Allow for a request that must pass several limiters: undo
+	// returns the token when another limiter rejects the request
+	Reserve(key string) (undo func(), ok bool, retryAfter time.Duration)
+	// SetLimit changes the limit of every bucket
+	SetLimit(limit domain.RateLimit)
+}
+
+// RateLimitSource loads a rate limit policy from configuration
+type RateLimitSource interface {
+	LoadRateLimits(ctx context.Context) (domain.RateLimitPolicy, error)
+}
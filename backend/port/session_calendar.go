@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+type SessionCalendar interface {
+	Session(symbol string, at time.Time) (*domain.MarketSession, error)
+}
+
+type HolidaySource interface {
+	LoadHolidays(ctx context.Context) ([]domain.Holiday, error)
+}
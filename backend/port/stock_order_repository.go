@@ -8,8 +8,12 @@ import (
 
 type StockOrderRepository interface {
 	Create(ctx context.Context, order *domain.StockOrder) error
+	// CreateBatch creates every order or, on error, none of them
+	CreateBatch(ctx context.Context, orders []*domain.StockOrder) error
 	GetByID(ctx context.Context, orderID string) (*domain.StockOrder, error)
-	List(ctx context.Context) ([]*domain.StockOrder, error)
+	// GetByClientOrderID returns nil without an error when no order uses the ID
+	GetByClientOrderID(ctx context.Context, accountID, clientOrderID string) (*domain.StockOrder, error)
+	List(ctx context.Context, query domain.OrderQuery) (*domain.OrderPage, error)
 	Update(ctx context.Context, order *domain.StockOrder) error
 	Close() error
 }
@@ -9,6 +9,18 @@ import (
 type StockOrderService interface {
 	CreateOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.StockOrder, error)
 	GetOrder(ctx context.Context, orderID string) (*domain.StockOrder, error)
-	ListOrders(ctx context.Context) ([]*domain.StockOrder, error)
+	ListOrders(ctx context.Context, query domain.OrderQuery) (*domain.OrderPage, error)
+	ListOrderFills(ctx context.Context, orderID string) ([]domain.Trade, error)
 	CancelOrder(ctx context.Context, orderID string) error
+	BatchCreateOrders(ctx context.Context, req domain.BatchCreateOrdersRequest) ([]domain.BatchOrderResult, error)
+	MassCancel(ctx context.Context, req domain.MassCancelRequest) ([]domain.CancelResult, error)
+	AmendOrder(ctx context.Context, orderID string, req domain.AmendOrderRequest) (*domain.StockOrder, error)
+	GetMarketSession(ctx context.Context, symbol string) (*domain.MarketSession, error)
+	GetAuctionState(ctx context.Context, symbol string) (*domain.AuctionState, error)
+	WatchOrders(ctx context.Context, filter domain.OrderEventFilter) (OrderEventSubscription, error)
+	ListInstruments(ctx context.Context) ([]*domain.Instrument, error)
+	UpsertInstrument(ctx context.Context, symbol string, req domain.UpsertInstrumentRequest) (*domain.Instrument, error)
+	DeleteInstrument(ctx context.Context, symbol string) error
+	HaltSymbol(ctx context.Context, symbol string, req domain.HaltSymbolRequest) (*domain.Instrument, error)
+	ResumeSymbol(ctx context.Context, symbol string) (*domain.Instrument, error)
 }
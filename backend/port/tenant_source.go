@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+// TenantSource loads the tenants sharing the deployment from configuration
+type TenantSource interface {
+	LoadTenants(ctx context.Context) ([]domain.Tenant, error)
+}
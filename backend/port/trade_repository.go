@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+type TradeRepository interface {
+	Save(ctx context.Context, trade domain.Trade) error
+	// ListByOrderID returns the trades either side of which is orderID, oldest first
+	ListByOrderID(ctx context.Context, orderID string) ([]domain.Trade, error)
+	// ListByOrderIDs returns the trades of each of orderIDs in one query,
+	// keyed by order ID and oldest first. Orders without trades are absent.
+	ListByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]domain.Trade, error)
+	Close() error
+}
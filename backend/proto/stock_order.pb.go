@@ -1,721 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.36.10
-// 	protoc        v6.33.1
-// source: proto/stock_order.proto
-
-package proto
-
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-// Enums
-type OrderType int32
-
-const (
-	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
-	OrderType_MARKET                 OrderType = 1
-	OrderType_LIMIT                  OrderType = 2
-)
-
-// Enum value maps for OrderType.
-var (
-	OrderType_name = map[int32]string{
-		0: "ORDER_TYPE_UNSPECIFIED",
-		1: "MARKET",
-		2: "LIMIT",
-	}
-	OrderType_value = map[string]int32{
-		"ORDER_TYPE_UNSPECIFIED": 0,
-		"MARKET":                 1,
-		"LIMIT":                  2,
-	}
-)
-
-func (x OrderType) Enum() *OrderType {
-	p := new(OrderType)
-	*p = x
-	return p
-}
-
-func (x OrderType) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (OrderType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_stock_order_proto_enumTypes[0].Descriptor()
-}
-
-func (OrderType) Type() protoreflect.EnumType {
-	return &file_proto_stock_order_proto_enumTypes[0]
-}
-
-func (x OrderType) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use OrderType.Descriptor instead.
-func (OrderType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{0}
-}
-
-type OrderSide int32
-
-const (
-	OrderSide_ORDER_SIDE_UNSPECIFIED OrderSide = 0
-	OrderSide_BUY                    OrderSide = 1
-	OrderSide_SELL                   OrderSide = 2
-)
-
-// Enum value maps for OrderSide.
-var (
-	OrderSide_name = map[int32]string{
-		0: "ORDER_SIDE_UNSPECIFIED",
-		1: "BUY",
-		2: "SELL",
-	}
-	OrderSide_value = map[string]int32{
-		"ORDER_SIDE_UNSPECIFIED": 0,
-		"BUY":                    1,
-		"SELL":                   2,
-	}
-)
-
-func (x OrderSide) Enum() *OrderSide {
-	p := new(OrderSide)
-	*p = x
-	return p
-}
-
-func (x OrderSide) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (OrderSide) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_stock_order_proto_enumTypes[1].Descriptor()
-}
-
-func (OrderSide) Type() protoreflect.EnumType {
-	return &file_proto_stock_order_proto_enumTypes[1]
-}
-
-func (x OrderSide) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use OrderSide.Descriptor instead.
-func (OrderSide) EnumDescriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{1}
-}
-
-type OrderStatus int32
-
-const (
-	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
-	OrderStatus_PENDING                  OrderStatus = 1
-	OrderStatus_FILLED                   OrderStatus = 2
-	OrderStatus_CANCELLED                OrderStatus = 3
-	OrderStatus_REJECTED                 OrderStatus = 4
-)
-
-// Enum value maps for OrderStatus.
-var (
-	OrderStatus_name = map[int32]string{
-		0: "ORDER_STATUS_UNSPECIFIED",
-		1: "PENDING",
-		2: "FILLED",
-		3: "CANCELLED",
-		4: "REJECTED",
-	}
-	OrderStatus_value = map[string]int32{
-		"ORDER_STATUS_UNSPECIFIED": 0,
-		"PENDING":                  1,
-		"FILLED":                   2,
-		"CANCELLED":                3,
-		"REJECTED":                 4,
-	}
-)
-
-func (x OrderStatus) Enum() *OrderStatus {
-	p := new(OrderStatus)
-	*p = x
-	return p
-}
-
-func (x OrderStatus) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_stock_order_proto_enumTypes[2].Descriptor()
-}
-
-func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_proto_stock_order_proto_enumTypes[2]
-}
-
-func (x OrderStatus) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use OrderStatus.Descriptor instead.
-func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{2}
-}
-
-// Messages
-type StockOrder struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	OrderType     OrderType              `protobuf:"varint,3,opt,name=order_type,json=orderType,proto3,enum=stockorder.OrderType" json:"order_type,omitempty"`
-	OrderSide     OrderSide              `protobuf:"varint,4,opt,name=order_side,json=orderSide,proto3,enum=stockorder.OrderSide" json:"order_side,omitempty"`
-	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,7,opt,name=status,proto3,enum=stockorder.OrderStatus" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *StockOrder) Reset() {
-	*x = StockOrder{}
-	mi := &file_proto_stock_order_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *StockOrder) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*StockOrder) ProtoMessage() {}
-
-func (x *StockOrder) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_stock_order_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use StockOrder.ProtoReflect.Descriptor instead.
-func (*StockOrder) Descriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *StockOrder) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *StockOrder) GetSymbol() string {
-	if x != nil {
-		return x.Symbol
-	}
-	return ""
-}
-
-func (x *StockOrder) GetOrderType() OrderType {
-	if x != nil {
-		return x.OrderType
-	}
-	return OrderType_ORDER_TYPE_UNSPECIFIED
-}
-
-func (x *StockOrder) GetOrderSide() OrderSide {
-	if x != nil {
-		return x.OrderSide
-	}
-	return OrderSide_ORDER_SIDE_UNSPECIFIED
-}
-
-func (x *StockOrder) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-func (x *StockOrder) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-func (x *StockOrder) GetStatus() OrderStatus {
-	if x != nil {
-		return x.Status
-	}
-	return OrderStatus_ORDER_STATUS_UNSPECIFIED
-}
-
-func (x *StockOrder) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *StockOrder) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-func (x *StockOrder) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-type CreateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	OrderType     OrderType              `protobuf:"varint,2,opt,name=order_type,json=orderType,proto3,enum=stockorder.OrderType" json:"order_type,omitempty"`
-	OrderSide     OrderSide              `protobuf:"varint,3,opt,name=order_side,json=orderSide,proto3,enum=stockorder.OrderSide" json:"order_side,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateOrderRequest) Reset() {
-	*x = CreateOrderRequest{}
-	mi := &file_proto_stock_order_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateOrderRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateOrderRequest) ProtoMessage() {}
-
-func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_stock_order_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *CreateOrderRequest) GetSymbol() string {
-	if x != nil {
-		return x.Symbol
-	}
-	return ""
-}
-
-func (x *CreateOrderRequest) GetOrderType() OrderType {
-	if x != nil {
-		return x.OrderType
-	}
-	return OrderType_ORDER_TYPE_UNSPECIFIED
-}
-
-func (x *CreateOrderRequest) GetOrderSide() OrderSide {
-	if x != nil {
-		return x.OrderSide
-	}
-	return OrderSide_ORDER_SIDE_UNSPECIFIED
-}
-
-func (x *CreateOrderRequest) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-func (x *CreateOrderRequest) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-type GetOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_proto_stock_order_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetOrderRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetOrderRequest) ProtoMessage() {}
-
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_stock_order_proto_msgTypes[2]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *GetOrderRequest) GetOrderId() string {
-	if x != nil {
-		return x.OrderId
-	}
-	return ""
-}
-
-type ListOrdersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListOrdersRequest) Reset() {
-	*x = ListOrdersRequest{}
-	mi := &file_proto_stock_order_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListOrdersRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListOrdersRequest) ProtoMessage() {}
-
-func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_stock_order_proto_msgTypes[3]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
-func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{3}
-}
-
-type ListOrdersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*StockOrder          `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListOrdersResponse) Reset() {
-	*x = ListOrdersResponse{}
-	mi := &file_proto_stock_order_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListOrdersResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListOrdersResponse) ProtoMessage() {}
-
-func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_stock_order_proto_msgTypes[4]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
-func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *ListOrdersResponse) GetOrders() []*StockOrder {
-	if x != nil {
-		return x.Orders
-	}
-	return nil
-}
-
-type CancelOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CancelOrderRequest) Reset() {
-	*x = CancelOrderRequest{}
-	mi := &file_proto_stock_order_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CancelOrderRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CancelOrderRequest) ProtoMessage() {}
-
-func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_stock_order_proto_msgTypes[5]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
-func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *CancelOrderRequest) GetOrderId() string {
-	if x != nil {
-		return x.OrderId
-	}
-	return ""
-}
-
-type CancelOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CancelOrderResponse) Reset() {
-	*x = CancelOrderResponse{}
-	mi := &file_proto_stock_order_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CancelOrderResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CancelOrderResponse) ProtoMessage() {}
-
-func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_stock_order_proto_msgTypes[6]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
-func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_stock_order_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *CancelOrderResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-var File_proto_stock_order_proto protoreflect.FileDescriptor
-
-const file_proto_stock_order_proto_rawDesc = "" +
-	"\n" +
-	"\x17proto/stock_order.proto\x12\n" +
-	"stockorder\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9b\x03\n" +
-	"\n" +
-	"StockOrder\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x124\n" +
-	"\n" +
-	"order_type\x18\x03 \x01(\x0e2\x15.stockorder.OrderTypeR\torderType\x124\n" +
-	"\n" +
-	"order_side\x18\x04 \x01(\x0e2\x15.stockorder.OrderSideR\torderSide\x12\x1a\n" +
-	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x06 \x01(\x01R\x05price\x12/\n" +
-	"\x06status\x18\a \x01(\x0e2\x17.stockorder.OrderStatusR\x06status\x129\n" +
-	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12 \n" +
-	"\vdescription\x18\n" +
-	" \x01(\tR\vdescription\"\xca\x01\n" +
-	"\x12CreateOrderRequest\x12\x16\n" +
-	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x124\n" +
-	"\n" +
-	"order_type\x18\x02 \x01(\x0e2\x15.stockorder.OrderTypeR\torderType\x124\n" +
-	"\n" +
-	"order_side\x18\x03 \x01(\x0e2\x15.stockorder.OrderSideR\torderSide\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\",\n" +
-	"\x0fGetOrderRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\"\x13\n" +
-	"\x11ListOrdersRequest\"D\n" +
-	"\x12ListOrdersResponse\x12.\n" +
-	"\x06orders\x18\x01 \x03(\v2\x16.stockorder.StockOrderR\x06orders\"/\n" +
-	"\x12CancelOrderRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\"/\n" +
-	"\x13CancelOrderResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage*>\n" +
-	"\tOrderType\x12\x1a\n" +
-	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\n" +
-	"\n" +
-	"\x06MARKET\x10\x01\x12\t\n" +
-	"\x05LIMIT\x10\x02*:\n" +
-	"\tOrderSide\x12\x1a\n" +
-	"\x16ORDER_SIDE_UNSPECIFIED\x10\x00\x12\a\n" +
-	"\x03BUY\x10\x01\x12\b\n" +
-	"\x04SELL\x10\x02*a\n" +
-	"\vOrderStatus\x12\x1c\n" +
-	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
-	"\aPENDING\x10\x01\x12\n" +
-	"\n" +
-	"\x06FILLED\x10\x02\x12\r\n" +
-	"\tCANCELLED\x10\x03\x12\f\n" +
-	"\bREJECTED\x10\x042\xb8\x02\n" +
-	"\x11StockOrderService\x12E\n" +
-	"\vCreateOrder\x12\x1e.stockorder.CreateOrderRequest\x1a\x16.stockorder.StockOrder\x12?\n" +
-	"\bGetOrder\x12\x1b.stockorder.GetOrderRequest\x1a\x16.stockorder.StockOrder\x12K\n" +
-	"\n" +
-	"ListOrders\x12\x1d.stockorder.ListOrdersRequest\x1a\x1e.stockorder.ListOrdersResponse\x12N\n" +
-	"\vCancelOrder\x12\x1e.stockorder.CancelOrderRequest\x1a\x1f.stockorder.CancelOrderResponseB>Z<github.com/newnok6/kkp-dime-golang-meetup-2025/backend/protob\x06proto3"
-
-var (
-	file_proto_stock_order_proto_rawDescOnce sync.Once
-	file_proto_stock_order_proto_rawDescData []byte
-)
-
-func file_proto_stock_order_proto_rawDescGZIP() []byte {
-	file_proto_stock_order_proto_rawDescOnce.Do(func() {
-		file_proto_stock_order_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_stock_order_proto_rawDesc), len(file_proto_stock_order_proto_rawDesc)))
-	})
-	return file_proto_stock_order_proto_rawDescData
-}
-
-var file_proto_stock_order_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_proto_stock_order_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_proto_stock_order_proto_goTypes = []any{
-	(OrderType)(0),                // 0: stockorder.OrderType
-	(OrderSide)(0),                // 1: stockorder.OrderSide
-	(OrderStatus)(0),              // 2: stockorder.OrderStatus
-	(*StockOrder)(nil),            // 3: stockorder.StockOrder
-	(*CreateOrderRequest)(nil),    // 4: stockorder.CreateOrderRequest
-	(*GetOrderRequest)(nil),       // 5: stockorder.GetOrderRequest
-	(*ListOrdersRequest)(nil),     // 6: stockorder.ListOrdersRequest
-	(*ListOrdersResponse)(nil),    // 7: stockorder.ListOrdersResponse
-	(*CancelOrderRequest)(nil),    // 8: stockorder.CancelOrderRequest
-	(*CancelOrderResponse)(nil),   // 9: stockorder.CancelOrderResponse
-	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
-}
-var file_proto_stock_order_proto_depIdxs = []int32{
-	0,  // 0: stockorder.StockOrder.order_type:type_name -> stockorder.OrderType
-	1,  // 1: stockorder.StockOrder.order_side:type_name -> stockorder.OrderSide
-	2,  // 2: stockorder.StockOrder.status:type_name -> stockorder.OrderStatus
-	10, // 3: stockorder.StockOrder.created_at:type_name -> google.protobuf.Timestamp
-	10, // 4: stockorder.StockOrder.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 5: stockorder.CreateOrderRequest.order_type:type_name -> stockorder.OrderType
-	1,  // 6: stockorder.CreateOrderRequest.order_side:type_name -> stockorder.OrderSide
-	3,  // 7: stockorder.ListOrdersResponse.orders:type_name -> stockorder.StockOrder
-	4,  // 8: stockorder.StockOrderService.CreateOrder:input_type -> stockorder.CreateOrderRequest
-	5,  // 9: stockorder.StockOrderService.GetOrder:input_type -> stockorder.GetOrderRequest
-	6,  // 10: stockorder.StockOrderService.ListOrders:input_type -> stockorder.ListOrdersRequest
-	8,  // 11: stockorder.StockOrderService.CancelOrder:input_type -> stockorder.CancelOrderRequest
-	3,  // 12: stockorder.StockOrderService.CreateOrder:output_type -> stockorder.StockOrder
-	3,  // 13: stockorder.StockOrderService.GetOrder:output_type -> stockorder.StockOrder
-	7,  // 14: stockorder.StockOrderService.ListOrders:output_type -> stockorder.ListOrdersResponse
-	9,  // 15: stockorder.StockOrderService.CancelOrder:output_type -> stockorder.CancelOrderResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
-}
-
-func init() { file_proto_stock_order_proto_init() }
-func file_proto_stock_order_proto_init() {
-	if File_proto_stock_order_proto != nil {
-		return
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_stock_order_proto_rawDesc), len(file_proto_stock_order_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   7,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_proto_stock_order_proto_goTypes,
-		DependencyIndexes: file_proto_stock_order_proto_depIdxs,
-		EnumInfos:         file_proto_stock_order_proto_enumTypes,
-		MessageInfos:      file_proto_stock_order_proto_msgTypes,
-	}.Build()
-	File_proto_stock_order_proto = out.File
-	file_proto_stock_order_proto_goTypes = nil
-	file_proto_stock_order_proto_depIdxs = nil
-}
@@ -0,0 +1,3192 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: proto/stockorder/v1/stock_order.proto
+
+package stockorderv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Enums
+type OrderType int32
+
+const (
+	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
+	OrderType_MARKET                 OrderType = 1
+	OrderType_LIMIT                  OrderType = 2
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "ORDER_TYPE_UNSPECIFIED",
+		1: "MARKET",
+		2: "LIMIT",
+	}
+	OrderType_value = map[string]int32{
+		"ORDER_TYPE_UNSPECIFIED": 0,
+		"MARKET":                 1,
+		"LIMIT":                  2,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_stockorder_v1_stock_order_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_proto_stockorder_v1_stock_order_proto_enumTypes[0]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderSide int32
+
+const (
+	OrderSide_ORDER_SIDE_UNSPECIFIED OrderSide = 0
+	OrderSide_BUY                    OrderSide = 1
+	OrderSide_SELL                   OrderSide = 2
+)
+
+// Enum value maps for OrderSide.
+var (
+	OrderSide_name = map[int32]string{
+		0: "ORDER_SIDE_UNSPECIFIED",
+		1: "BUY",
+		2: "SELL",
+	}
+	OrderSide_value = map[string]int32{
+		"ORDER_SIDE_UNSPECIFIED": 0,
+		"BUY":                    1,
+		"SELL":                   2,
+	}
+)
+
+func (x OrderSide) Enum() *OrderSide {
+	p := new(OrderSide)
+	*p = x
+	return p
+}
+
+func (x OrderSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_stockorder_v1_stock_order_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderSide) Type() protoreflect.EnumType {
+	return &file_proto_stockorder_v1_stock_order_proto_enumTypes[1]
+}
+
+func (x OrderSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderSide.Descriptor instead.
+func (OrderSide) EnumDescriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
+	OrderStatus_PENDING                  OrderStatus = 1
+	OrderStatus_FILLED                   OrderStatus = 2
+	OrderStatus_CANCELLED                OrderStatus = 3
+	OrderStatus_REJECTED                 OrderStatus = 4
+	OrderStatus_PARTIALLY_FILLED         OrderStatus = 5
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "PENDING",
+		2: "FILLED",
+		3: "CANCELLED",
+		4: "REJECTED",
+		5: "PARTIALLY_FILLED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED": 0,
+		"PENDING":                  1,
+		"FILLED":                   2,
+		"CANCELLED":                3,
+		"REJECTED":                 4,
+		"PARTIALLY_FILLED":         5,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_stockorder_v1_stock_order_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_proto_stockorder_v1_stock_order_proto_enumTypes[2]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{2}
+}
+
+type OrderSort int32
+
+const (
+	OrderSort_CREATED_AT_DESC OrderSort = 0
+	OrderSort_CREATED_AT_ASC  OrderSort = 1
+)
+
+// Enum value maps for OrderSort.
+var (
+	OrderSort_name = map[int32]string{
+		0: "CREATED_AT_DESC",
+		1: "CREATED_AT_ASC",
+	}
+	OrderSort_value = map[string]int32{
+		"CREATED_AT_DESC": 0,
+		"CREATED_AT_ASC":  1,
+	}
+)
+
+func (x OrderSort) Enum() *OrderSort {
+	p := new(OrderSort)
+	*p = x
+	return p
+}
+
+func (x OrderSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_stockorder_v1_stock_order_proto_enumTypes[3].Descriptor()
+}
+
+func (OrderSort) Type() protoreflect.EnumType {
+	return &file_proto_stockorder_v1_stock_order_proto_enumTypes[3]
+}
+
+func (x OrderSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderSort.Descriptor instead.
+func (OrderSort) EnumDescriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{3}
+}
+
+// Messages
+type StockOrder struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol         string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderType      OrderType              `protobuf:"varint,3,opt,name=order_type,json=orderType,proto3,enum=stockorder.v1.OrderType" json:"order_type,omitempty"`
+	OrderSide      OrderSide              `protobuf:"varint,4,opt,name=order_side,json=orderSide,proto3,enum=stockorder.v1.OrderSide" json:"order_side,omitempty"`
+	Quantity       int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price          float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	Status         OrderStatus            `protobuf:"varint,7,opt,name=status,proto3,enum=stockorder.v1.OrderStatus" json:"status,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Description    string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	FilledQuantity int32                  `protobuf:"varint,11,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	AveragePrice   float64                `protobuf:"fixed64,12,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"`
+	AccountId      string                 `protobuf:"bytes,13,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ClientOrderId  string                 `protobuf:"bytes,14,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StockOrder) Reset() {
+	*x = StockOrder{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockOrder) ProtoMessage() {}
+
+func (x *StockOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockOrder.ProtoReflect.Descriptor instead.
+func (*StockOrder) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StockOrder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockOrder) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StockOrder) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *StockOrder) GetOrderSide() OrderSide {
+	if x != nil {
+		return x.OrderSide
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *StockOrder) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockOrder) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *StockOrder) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *StockOrder) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StockOrder) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *StockOrder) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StockOrder) GetFilledQuantity() int32 {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return 0
+}
+
+func (x *StockOrder) GetAveragePrice() float64 {
+	if x != nil {
+		return x.AveragePrice
+	}
+	return 0
+}
+
+func (x *StockOrder) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *StockOrder) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+type CreateOrderRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Symbol    string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderType OrderType              `protobuf:"varint,2,opt,name=order_type,json=orderType,proto3,enum=stockorder.v1.OrderType" json:"order_type,omitempty"`
+	OrderSide OrderSide              `protobuf:"varint,3,opt,name=order_side,json=orderSide,proto3,enum=stockorder.v1.OrderSide" json:"order_side,omitempty"`
+	Quantity  int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price     float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	// Makes the request idempotent per account; the idempotency-key metadata
+	// may be used instead
+	ClientOrderId string `protobuf:"bytes,6,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetOrderSide() OrderSide {
+	if x != nil {
+		return x.OrderSide
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Unset filters match every order
+type ListOrdersRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Symbol    string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderSide OrderSide              `protobuf:"varint,2,opt,name=order_side,json=orderSide,proto3,enum=stockorder.v1.OrderSide" json:"order_side,omitempty"`
+	OrderType OrderType              `protobuf:"varint,3,opt,name=order_type,json=orderType,proto3,enum=stockorder.v1.OrderType" json:"order_type,omitempty"`
+	Status    OrderStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=stockorder.v1.OrderStatus" json:"status,omitempty"`
+	// Inclusive lower and exclusive upper bound on created_at
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Sort        OrderSort              `protobuf:"varint,7,opt,name=sort,proto3,enum=stockorder.v1.OrderSort" json:"sort,omitempty"`
+	// Defaults to 50, at most 500
+	PageSize int32 `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, issued for the same sort order
+	PageToken     string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListOrdersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetOrderSide() OrderSide {
+	if x != nil {
+		return x.OrderSide
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *ListOrdersRequest) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *ListOrdersRequest) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *ListOrdersRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetSort() OrderSort {
+	if x != nil {
+		return x.Sort
+	}
+	return OrderSort_CREATED_AT_DESC
+}
+
+func (x *ListOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*StockOrder          `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*StockOrder {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelOrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BatchCreateOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*CreateOrderRequest  `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Create every order or none of them
+	Atomic        bool `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateOrdersRequest) Reset() {
+	*x = BatchCreateOrdersRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateOrdersRequest) ProtoMessage() {}
+
+func (x *BatchCreateOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateOrdersRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchCreateOrdersRequest) GetOrders() []*CreateOrderRequest {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *BatchCreateOrdersRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+type BatchOrderResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Order         *StockOrder            `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchOrderResult) Reset() {
+	*x = BatchOrderResult{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOrderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOrderResult) ProtoMessage() {}
+
+func (x *BatchOrderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOrderResult.ProtoReflect.Descriptor instead.
+func (*BatchOrderResult) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchOrderResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchOrderResult) GetOrder() *StockOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *BatchOrderResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchCreateOrdersResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*BatchOrderResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// False when an atomic batch was rolled back
+	Committed     bool `protobuf:"varint,2,opt,name=committed,proto3" json:"committed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateOrdersResponse) Reset() {
+	*x = BatchCreateOrdersResponse{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateOrdersResponse) ProtoMessage() {}
+
+func (x *BatchCreateOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateOrdersResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchCreateOrdersResponse) GetResults() []*BatchOrderResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchCreateOrdersResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+// Unset fields match every open order
+type MassCancelRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Symbol    string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderSide OrderSide              `protobuf:"varint,2,opt,name=order_side,json=orderSide,proto3,enum=stockorder.v1.OrderSide" json:"order_side,omitempty"`
+	// Defaults to the caller's account; other accounts require the ops or admin role
+	AccountId string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Cancels the orders of every account instead; requires the ops or admin role
+	AllAccounts   bool `protobuf:"varint,4,opt,name=all_accounts,json=allAccounts,proto3" json:"all_accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MassCancelRequest) Reset() {
+	*x = MassCancelRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MassCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MassCancelRequest) ProtoMessage() {}
+
+func (x *MassCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MassCancelRequest.ProtoReflect.Descriptor instead.
+func (*MassCancelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MassCancelRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *MassCancelRequest) GetOrderSide() OrderSide {
+	if x != nil {
+		return x.OrderSide
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *MassCancelRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *MassCancelRequest) GetAllAccounts() bool {
+	if x != nil {
+		return x.AllAccounts
+	}
+	return false
+}
+
+type CancelResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Cancelled     bool                   `protobuf:"varint,2,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelResult) Reset() {
+	*x = CancelResult{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelResult) ProtoMessage() {}
+
+func (x *CancelResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelResult.ProtoReflect.Descriptor instead.
+func (*CancelResult) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelResult) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelResult) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+func (x *CancelResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type MassCancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancelled     int32                  `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Results       []*CancelResult        `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MassCancelResponse) Reset() {
+	*x = MassCancelResponse{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MassCancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MassCancelResponse) ProtoMessage() {}
+
+func (x *MassCancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MassCancelResponse.ProtoReflect.Descriptor instead.
+func (*MassCancelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MassCancelResponse) GetCancelled() int32 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+func (x *MassCancelResponse) GetResults() []*CancelResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetQuoteRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type PriceLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrderCount    int32                  `protobuf:"varint,3,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceLevel) Reset() {
+	*x = PriceLevel{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLevel) ProtoMessage() {}
+
+func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PriceLevel) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetOrderCount() int32 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+type Quote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	LastPrice     float64                `protobuf:"fixed64,2,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	LastQuantity  int32                  `protobuf:"varint,3,opt,name=last_quantity,json=lastQuantity,proto3" json:"last_quantity,omitempty"`
+	LastTradeAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_trade_at,json=lastTradeAt,proto3" json:"last_trade_at,omitempty"`
+	BidPrice      float64                `protobuf:"fixed64,5,opt,name=bid_price,json=bidPrice,proto3" json:"bid_price,omitempty"`
+	BidSize       int32                  `protobuf:"varint,6,opt,name=bid_size,json=bidSize,proto3" json:"bid_size,omitempty"`
+	AskPrice      float64                `protobuf:"fixed64,7,opt,name=ask_price,json=askPrice,proto3" json:"ask_price,omitempty"`
+	AskSize       int32                  `protobuf:"varint,8,opt,name=ask_size,json=askSize,proto3" json:"ask_size,omitempty"`
+	Bids          []*PriceLevel          `protobuf:"bytes,9,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*PriceLevel          `protobuf:"bytes,10,rep,name=asks,proto3" json:"asks,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Quote) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Quote) GetLastPrice() float64 {
+	if x != nil {
+		return x.LastPrice
+	}
+	return 0
+}
+
+func (x *Quote) GetLastQuantity() int32 {
+	if x != nil {
+		return x.LastQuantity
+	}
+	return 0
+}
+
+func (x *Quote) GetLastTradeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastTradeAt
+	}
+	return nil
+}
+
+func (x *Quote) GetBidPrice() float64 {
+	if x != nil {
+		return x.BidPrice
+	}
+	return 0
+}
+
+func (x *Quote) GetBidSize() int32 {
+	if x != nil {
+		return x.BidSize
+	}
+	return 0
+}
+
+func (x *Quote) GetAskPrice() float64 {
+	if x != nil {
+		return x.AskPrice
+	}
+	return 0
+}
+
+func (x *Quote) GetAskSize() int32 {
+	if x != nil {
+		return x.AskSize
+	}
+	return 0
+}
+
+func (x *Quote) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *Quote) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *Quote) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetCandlesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// One of 1m, 5m, 1h or 1d (default 1m)
+	Interval      string                 `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCandlesRequest) Reset() {
+	*x = GetCandlesRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandlesRequest) ProtoMessage() {}
+
+func (x *GetCandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandlesRequest.ProtoReflect.Descriptor instead.
+func (*GetCandlesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetCandlesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetCandlesRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetCandlesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetCandlesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetCandlesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Candle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Interval      string                 `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	OpenTime      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=open_time,json=openTime,proto3" json:"open_time,omitempty"`
+	Open          float64                `protobuf:"fixed64,4,opt,name=open,proto3" json:"open,omitempty"`
+	High          float64                `protobuf:"fixed64,5,opt,name=high,proto3" json:"high,omitempty"`
+	Low           float64                `protobuf:"fixed64,6,opt,name=low,proto3" json:"low,omitempty"`
+	Close         float64                `protobuf:"fixed64,7,opt,name=close,proto3" json:"close,omitempty"`
+	Volume        int64                  `protobuf:"varint,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	TradeCount    int32                  `protobuf:"varint,9,opt,name=trade_count,json=tradeCount,proto3" json:"trade_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Candle) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Candle) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *Candle) GetOpenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenTime
+	}
+	return nil
+}
+
+func (x *Candle) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Candle) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Candle) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Candle) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *Candle) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Candle) GetTradeCount() int32 {
+	if x != nil {
+		return x.TradeCount
+	}
+	return 0
+}
+
+type GetCandlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candles       []*Candle              `protobuf:"bytes,1,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCandlesResponse) Reset() {
+	*x = GetCandlesResponse{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandlesResponse) ProtoMessage() {}
+
+func (x *GetCandlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandlesResponse.ProtoReflect.Descriptor instead.
+func (*GetCandlesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetCandlesResponse) GetCandles() []*Candle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+type WatchOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream orders for this symbol when set
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Replay the account's retained events starting at this sequence; 0 streams
+	// new events only
+	FromSequence  uint64 `protobuf:"varint,2,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrdersRequest) Reset() {
+	*x = WatchOrdersRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrdersRequest) ProtoMessage() {}
+
+func (x *WatchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchOrdersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *WatchOrdersRequest) GetFromSequence() uint64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
+type OrderEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Per-account sequence, increasing by one for every event of the account
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Order         *StockOrder            `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderEvent) Reset() {
+	*x = OrderEvent{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderEvent) ProtoMessage() {}
+
+func (x *OrderEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderEvent.ProtoReflect.Descriptor instead.
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *OrderEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderEvent) GetOrder() *StockOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *OrderEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type StreamOrderBookRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Price levels per side; 0 streams the whole book
+	Depth         int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamOrderBookRequest) Reset() {
+	*x = StreamOrderBookRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamOrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamOrderBookRequest) ProtoMessage() {}
+
+func (x *StreamOrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamOrderBookRequest.ProtoReflect.Descriptor instead.
+func (*StreamOrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamOrderBookRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StreamOrderBookRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+// OrderBookUpdate is a snapshot when snapshot is set, otherwise it only
+// carries the levels that changed since the previous message, with quantity 0
+// for removed levels. Updates are conflated: a slow client receives fewer
+// messages, never a gap, so every update applies to the book as last received.
+type OrderBookUpdate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Symbol   string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Sequence uint64                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Unused: updates are conflated and always follow the previous message
+	//
+	// Deprecated: Marked as deprecated in proto/stockorder/v1/stock_order.proto.
+	PreviousSequence uint64                 `protobuf:"varint,3,opt,name=previous_sequence,json=previousSequence,proto3" json:"previous_sequence,omitempty"`
+	Snapshot         bool                   `protobuf:"varint,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Bids             []*PriceLevel          `protobuf:"bytes,5,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks             []*PriceLevel          `protobuf:"bytes,6,rep,name=asks,proto3" json:"asks,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OrderBookUpdate) Reset() {
+	*x = OrderBookUpdate{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBookUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookUpdate) ProtoMessage() {}
+
+func (x *OrderBookUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookUpdate.ProtoReflect.Descriptor instead.
+func (*OrderBookUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *OrderBookUpdate) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OrderBookUpdate) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/stockorder/v1/stock_order.proto.
+func (x *OrderBookUpdate) GetPreviousSequence() uint64 {
+	if x != nil {
+		return x.PreviousSequence
+	}
+	return 0
+}
+
+func (x *OrderBookUpdate) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *OrderBookUpdate) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBookUpdate) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBookUpdate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// OrderSessionRequest is one command of an order session. Commands are
+// processed strictly in the order they are sent.
+type OrderSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the command, and for create the new order, within the session
+	ClientOrderId string `protobuf:"bytes,1,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	// Types that are valid to be assigned to Command:
+	//
+	//	*OrderSessionRequest_Create
+	//	*OrderSessionRequest_Cancel
+	//	*OrderSessionRequest_Amend
+	Command       isOrderSessionRequest_Command `protobuf_oneof:"command"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderSessionRequest) Reset() {
+	*x = OrderSessionRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderSessionRequest) ProtoMessage() {}
+
+func (x *OrderSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderSessionRequest.ProtoReflect.Descriptor instead.
+func (*OrderSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OrderSessionRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *OrderSessionRequest) GetCommand() isOrderSessionRequest_Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *OrderSessionRequest) GetCreate() *CreateOrderRequest {
+	if x != nil {
+		if x, ok := x.Command.(*OrderSessionRequest_Create); ok {
+			return x.Create
+		}
+	}
+	return nil
+}
+
+func (x *OrderSessionRequest) GetCancel() *SessionCancelOrder {
+	if x != nil {
+		if x, ok := x.Command.(*OrderSessionRequest_Cancel); ok {
+			return x.Cancel
+		}
+	}
+	return nil
+}
+
+func (x *OrderSessionRequest) GetAmend() *SessionAmendOrder {
+	if x != nil {
+		if x, ok := x.Command.(*OrderSessionRequest_Amend); ok {
+			return x.Amend
+		}
+	}
+	return nil
+}
+
+type isOrderSessionRequest_Command interface {
+	isOrderSessionRequest_Command()
+}
+
+type OrderSessionRequest_Create struct {
+	Create *CreateOrderRequest `protobuf:"bytes,2,opt,name=create,proto3,oneof"`
+}
+
+type OrderSessionRequest_Cancel struct {
+	Cancel *SessionCancelOrder `protobuf:"bytes,3,opt,name=cancel,proto3,oneof"`
+}
+
+type OrderSessionRequest_Amend struct {
+	Amend *SessionAmendOrder `protobuf:"bytes,4,opt,name=amend,proto3,oneof"`
+}
+
+func (*OrderSessionRequest_Create) isOrderSessionRequest_Command() {}
+
+func (*OrderSessionRequest_Cancel) isOrderSessionRequest_Command() {}
+
+func (*OrderSessionRequest_Amend) isOrderSessionRequest_Command() {}
+
+// The target order is named by order_id or by the client_order_id it was
+// created with in this session
+type SessionCancelOrder struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrigClientOrderId string                 `protobuf:"bytes,2,opt,name=orig_client_order_id,json=origClientOrderId,proto3" json:"orig_client_order_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SessionCancelOrder) Reset() {
+	*x = SessionCancelOrder{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionCancelOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionCancelOrder) ProtoMessage() {}
+
+func (x *SessionCancelOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionCancelOrder.ProtoReflect.Descriptor instead.
+func (*SessionCancelOrder) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SessionCancelOrder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SessionCancelOrder) GetOrigClientOrderId() string {
+	if x != nil {
+		return x.OrigClientOrderId
+	}
+	return ""
+}
+
+type SessionAmendOrder struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrigClientOrderId string                 `protobuf:"bytes,2,opt,name=orig_client_order_id,json=origClientOrderId,proto3" json:"orig_client_order_id,omitempty"`
+	// New total quantity, 0 leaves it unchanged
+	Quantity int32 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// New limit price, 0 leaves it unchanged
+	Price         float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionAmendOrder) Reset() {
+	*x = SessionAmendOrder{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionAmendOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionAmendOrder) ProtoMessage() {}
+
+func (x *SessionAmendOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionAmendOrder.ProtoReflect.Descriptor instead.
+func (*SessionAmendOrder) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SessionAmendOrder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SessionAmendOrder) GetOrigClientOrderId() string {
+	if x != nil {
+		return x.OrigClientOrderId
+	}
+	return ""
+}
+
+func (x *SessionAmendOrder) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SessionAmendOrder) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type OrderSessionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Increases by one with every response of the session
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The command's client_order_id for acks and rejects, the order's for executions
+	ClientOrderId string `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*OrderSessionResponse_Ack
+	//	*OrderSessionResponse_Reject
+	//	*OrderSessionResponse_Execution
+	Event         isOrderSessionResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderSessionResponse) Reset() {
+	*x = OrderSessionResponse{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderSessionResponse) ProtoMessage() {}
+
+func (x *OrderSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderSessionResponse.ProtoReflect.Descriptor instead.
+func (*OrderSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OrderSessionResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderSessionResponse) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *OrderSessionResponse) GetEvent() isOrderSessionResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *OrderSessionResponse) GetAck() *OrderAck {
+	if x != nil {
+		if x, ok := x.Event.(*OrderSessionResponse_Ack); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+func (x *OrderSessionResponse) GetReject() *OrderReject {
+	if x != nil {
+		if x, ok := x.Event.(*OrderSessionResponse_Reject); ok {
+			return x.Reject
+		}
+	}
+	return nil
+}
+
+func (x *OrderSessionResponse) GetExecution() *OrderExecution {
+	if x != nil {
+		if x, ok := x.Event.(*OrderSessionResponse_Execution); ok {
+			return x.Execution
+		}
+	}
+	return nil
+}
+
+type isOrderSessionResponse_Event interface {
+	isOrderSessionResponse_Event()
+}
+
+type OrderSessionResponse_Ack struct {
+	Ack *OrderAck `protobuf:"bytes,3,opt,name=ack,proto3,oneof"`
+}
+
+type OrderSessionResponse_Reject struct {
+	Reject *OrderReject `protobuf:"bytes,4,opt,name=reject,proto3,oneof"`
+}
+
+type OrderSessionResponse_Execution struct {
+	Execution *OrderExecution `protobuf:"bytes,5,opt,name=execution,proto3,oneof"`
+}
+
+func (*OrderSessionResponse_Ack) isOrderSessionResponse_Event() {}
+
+func (*OrderSessionResponse_Reject) isOrderSessionResponse_Event() {}
+
+func (*OrderSessionResponse_Execution) isOrderSessionResponse_Event() {}
+
+type OrderAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *StockOrder            `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderAck) Reset() {
+	*x = OrderAck{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderAck) ProtoMessage() {}
+
+func (x *OrderAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderAck.ProtoReflect.Descriptor instead.
+func (*OrderAck) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OrderAck) GetOrder() *StockOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type OrderReject struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Reason string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Canonical gRPC status code describing the reject
+	Code          int32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderReject) Reset() {
+	*x = OrderReject{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderReject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderReject) ProtoMessage() {}
+
+func (x *OrderReject) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderReject.ProtoReflect.Descriptor instead.
+func (*OrderReject) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *OrderReject) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderReject) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+// OrderExecution reports a fill, or a status change the client did not request
+type OrderExecution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *StockOrder            `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	LastQuantity  int32                  `protobuf:"varint,2,opt,name=last_quantity,json=lastQuantity,proto3" json:"last_quantity,omitempty"`
+	LastPrice     float64                `protobuf:"fixed64,3,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderExecution) Reset() {
+	*x = OrderExecution{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderExecution) ProtoMessage() {}
+
+func (x *OrderExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderExecution.ProtoReflect.Descriptor instead.
+func (*OrderExecution) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *OrderExecution) GetOrder() *StockOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *OrderExecution) GetLastQuantity() int32 {
+	if x != nil {
+		return x.LastQuantity
+	}
+	return 0
+}
+
+func (x *OrderExecution) GetLastPrice() float64 {
+	if x != nil {
+		return x.LastPrice
+	}
+	return 0
+}
+
+// Orders must be a multiple of lot_size shares and limit prices a multiple
+// of tick_size; zero disables either check
+type Instrument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	LotSize       int32                  `protobuf:"varint,3,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	TickSize      float64                `protobuf:"fixed64,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	Halted        bool                   `protobuf:"varint,5,opt,name=halted,proto3" json:"halted,omitempty"`
+	HaltReason    string                 `protobuf:"bytes,6,opt,name=halt_reason,json=haltReason,proto3" json:"halt_reason,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Instrument) Reset() {
+	*x = Instrument{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Instrument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instrument) ProtoMessage() {}
+
+func (x *Instrument) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instrument.ProtoReflect.Descriptor instead.
+func (*Instrument) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Instrument) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Instrument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Instrument) GetLotSize() int32 {
+	if x != nil {
+		return x.LotSize
+	}
+	return 0
+}
+
+func (x *Instrument) GetTickSize() float64 {
+	if x != nil {
+		return x.TickSize
+	}
+	return 0
+}
+
+func (x *Instrument) GetHalted() bool {
+	if x != nil {
+		return x.Halted
+	}
+	return false
+}
+
+func (x *Instrument) GetHaltReason() string {
+	if x != nil {
+		return x.HaltReason
+	}
+	return ""
+}
+
+func (x *Instrument) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListInstrumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsRequest) Reset() {
+	*x = ListInstrumentsRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsRequest) ProtoMessage() {}
+
+func (x *ListInstrumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstrumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{31}
+}
+
+type ListInstrumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instruments   []*Instrument          `protobuf:"bytes,1,rep,name=instruments,proto3" json:"instruments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInstrumentsResponse) Reset() {
+	*x = ListInstrumentsResponse{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInstrumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInstrumentsResponse) ProtoMessage() {}
+
+func (x *ListInstrumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInstrumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListInstrumentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListInstrumentsResponse) GetInstruments() []*Instrument {
+	if x != nil {
+		return x.Instruments
+	}
+	return nil
+}
+
+type UpsertInstrumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	LotSize       int32                  `protobuf:"varint,3,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	TickSize      float64                `protobuf:"fixed64,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertInstrumentRequest) Reset() {
+	*x = UpsertInstrumentRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertInstrumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertInstrumentRequest) ProtoMessage() {}
+
+func (x *UpsertInstrumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertInstrumentRequest.ProtoReflect.Descriptor instead.
+func (*UpsertInstrumentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpsertInstrumentRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *UpsertInstrumentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpsertInstrumentRequest) GetLotSize() int32 {
+	if x != nil {
+		return x.LotSize
+	}
+	return 0
+}
+
+func (x *UpsertInstrumentRequest) GetTickSize() float64 {
+	if x != nil {
+		return x.TickSize
+	}
+	return 0
+}
+
+type DeleteInstrumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteInstrumentRequest) Reset() {
+	*x = DeleteInstrumentRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteInstrumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInstrumentRequest) ProtoMessage() {}
+
+func (x *DeleteInstrumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInstrumentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteInstrumentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteInstrumentRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type DeleteInstrumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteInstrumentResponse) Reset() {
+	*x = DeleteInstrumentResponse{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteInstrumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInstrumentResponse) ProtoMessage() {}
+
+func (x *DeleteInstrumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInstrumentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteInstrumentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteInstrumentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type HaltSymbolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HaltSymbolRequest) Reset() {
+	*x = HaltSymbolRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HaltSymbolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HaltSymbolRequest) ProtoMessage() {}
+
+func (x *HaltSymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HaltSymbolRequest.ProtoReflect.Descriptor instead.
+func (*HaltSymbolRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *HaltSymbolRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *HaltSymbolRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResumeSymbolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeSymbolRequest) Reset() {
+	*x = ResumeSymbolRequest{}
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeSymbolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeSymbolRequest) ProtoMessage() {}
+
+func (x *ResumeSymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v1_stock_order_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeSymbolRequest.ProtoReflect.Descriptor instead.
+func (*ResumeSymbolRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v1_stock_order_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ResumeSymbolRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+var File_proto_stockorder_v1_stock_order_proto protoreflect.FileDescriptor
+
+const file_proto_stockorder_v1_stock_order_proto_rawDesc = "" +
+	"\n" +
+	"%proto/stockorder/v1/stock_order.proto\x12\rstockorder.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb9\x04\n" +
+	"\n" +
+	"StockOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x127\n" +
+	"\n" +
+	"order_type\x18\x03 \x01(\x0e2\x18.stockorder.v1.OrderTypeR\torderType\x127\n" +
+	"\n" +
+	"order_side\x18\x04 \x01(\x0e2\x18.stockorder.v1.OrderSideR\torderSide\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x122\n" +
+	"\x06status\x18\a \x01(\x0e2\x1a.stockorder.v1.OrderStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x12'\n" +
+	"\x0ffilled_quantity\x18\v \x01(\x05R\x0efilledQuantity\x12#\n" +
+	"\raverage_price\x18\f \x01(\x01R\faveragePrice\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\r \x01(\tR\taccountId\x12&\n" +
+	"\x0fclient_order_id\x18\x0e \x01(\tR\rclientOrderId\"\xf8\x01\n" +
+	"\x12CreateOrderRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x127\n" +
+	"\n" +
+	"order_type\x18\x02 \x01(\x0e2\x18.stockorder.v1.OrderTypeR\torderType\x127\n" +
+	"\n" +
+	"order_side\x18\x03 \x01(\x0e2\x18.stockorder.v1.OrderSideR\torderSide\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12&\n" +
+	"\x0fclient_order_id\x18\x06 \x01(\tR\rclientOrderId\",\n" +
+	"\x0fGetOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\xb5\x03\n" +
+	"\x11ListOrdersRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x127\n" +
+	"\n" +
+	"order_side\x18\x02 \x01(\x0e2\x18.stockorder.v1.OrderSideR\torderSide\x127\n" +
+	"\n" +
+	"order_type\x18\x03 \x01(\x0e2\x18.stockorder.v1.OrderTypeR\torderType\x122\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1a.stockorder.v1.OrderStatusR\x06status\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12,\n" +
+	"\x04sort\x18\a \x01(\x0e2\x18.stockorder.v1.OrderSortR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\"o\n" +
+	"\x12ListOrdersResponse\x121\n" +
+	"\x06orders\x18\x01 \x03(\v2\x19.stockorder.v1.StockOrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"/\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"/\n" +
+	"\x13CancelOrderResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"m\n" +
+	"\x18BatchCreateOrdersRequest\x129\n" +
+	"\x06orders\x18\x01 \x03(\v2!.stockorder.v1.CreateOrderRequestR\x06orders\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"o\n" +
+	"\x10BatchOrderResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12/\n" +
+	"\x05order\x18\x02 \x01(\v2\x19.stockorder.v1.StockOrderR\x05order\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"t\n" +
+	"\x19BatchCreateOrdersResponse\x129\n" +
+	"\aresults\x18\x01 \x03(\v2\x1f.stockorder.v1.BatchOrderResultR\aresults\x12\x1c\n" +
+	"\tcommitted\x18\x02 \x01(\bR\tcommitted\"\xa6\x01\n" +
+	"\x11MassCancelRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x127\n" +
+	"\n" +
+	"order_side\x18\x02 \x01(\x0e2\x18.stockorder.v1.OrderSideR\torderSide\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12!\n" +
+	"\fall_accounts\x18\x04 \x01(\bR\vallAccounts\"]\n" +
+	"\fCancelResult\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1c\n" +
+	"\tcancelled\x18\x02 \x01(\bR\tcancelled\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"i\n" +
+	"\x12MassCancelResponse\x12\x1c\n" +
+	"\tcancelled\x18\x01 \x01(\x05R\tcancelled\x125\n" +
+	"\aresults\x18\x02 \x03(\v2\x1b.stockorder.v1.CancelResultR\aresults\")\n" +
+	"\x0fGetQuoteRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"_\n" +
+	"\n" +
+	"PriceLevel\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vorder_count\x18\x03 \x01(\x05R\n" +
+	"orderCount\"\xac\x03\n" +
+	"\x05Quote\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\x02 \x01(\x01R\tlastPrice\x12#\n" +
+	"\rlast_quantity\x18\x03 \x01(\x05R\flastQuantity\x12>\n" +
+	"\rlast_trade_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vlastTradeAt\x12\x1b\n" +
+	"\tbid_price\x18\x05 \x01(\x01R\bbidPrice\x12\x19\n" +
+	"\bbid_size\x18\x06 \x01(\x05R\abidSize\x12\x1b\n" +
+	"\task_price\x18\a \x01(\x01R\baskPrice\x12\x19\n" +
+	"\bask_size\x18\b \x01(\x05R\aaskSize\x12-\n" +
+	"\x04bids\x18\t \x03(\v2\x19.stockorder.v1.PriceLevelR\x04bids\x12-\n" +
+	"\x04asks\x18\n" +
+	" \x03(\v2\x19.stockorder.v1.PriceLevelR\x04asks\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb9\x01\n" +
+	"\x11GetCandlesRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\tR\binterval\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\xfe\x01\n" +
+	"\x06Candle\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\tR\binterval\x127\n" +
+	"\topen_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bopenTime\x12\x12\n" +
+	"\x04open\x18\x04 \x01(\x01R\x04open\x12\x12\n" +
+	"\x04high\x18\x05 \x01(\x01R\x04high\x12\x10\n" +
+	"\x03low\x18\x06 \x01(\x01R\x03low\x12\x14\n" +
+	"\x05close\x18\a \x01(\x01R\x05close\x12\x16\n" +
+	"\x06volume\x18\b \x01(\x03R\x06volume\x12\x1f\n" +
+	"\vtrade_count\x18\t \x01(\x05R\n" +
+	"tradeCount\"E\n" +
+	"\x12GetCandlesResponse\x12/\n" +
+	"\acandles\x18\x01 \x03(\v2\x15.stockorder.v1.CandleR\acandles\"Q\n" +
+	"\x12WatchOrdersRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12#\n" +
+	"\rfrom_sequence\x18\x02 \x01(\x04R\ffromSequence\"\x96\x01\n" +
+	"\n" +
+	"OrderEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12/\n" +
+	"\x05order\x18\x02 \x01(\v2\x19.stockorder.v1.StockOrderR\x05order\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"F\n" +
+	"\x16StreamOrderBookRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"\xab\x02\n" +
+	"\x0fOrderBookUpdate\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x04R\bsequence\x12/\n" +
+	"\x11previous_sequence\x18\x03 \x01(\x04B\x02\x18\x01R\x10previousSequence\x12\x1a\n" +
+	"\bsnapshot\x18\x04 \x01(\bR\bsnapshot\x12-\n" +
+	"\x04bids\x18\x05 \x03(\v2\x19.stockorder.v1.PriceLevelR\x04bids\x12-\n" +
+	"\x04asks\x18\x06 \x03(\v2\x19.stockorder.v1.PriceLevelR\x04asks\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xfc\x01\n" +
+	"\x13OrderSessionRequest\x12&\n" +
+	"\x0fclient_order_id\x18\x01 \x01(\tR\rclientOrderId\x12;\n" +
+	"\x06create\x18\x02 \x01(\v2!.stockorder.v1.CreateOrderRequestH\x00R\x06create\x12;\n" +
+	"\x06cancel\x18\x03 \x01(\v2!.stockorder.v1.SessionCancelOrderH\x00R\x06cancel\x128\n" +
+	"\x05amend\x18\x04 \x01(\v2 .stockorder.v1.SessionAmendOrderH\x00R\x05amendB\t\n" +
+	"\acommand\"`\n" +
+	"\x12SessionCancelOrder\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12/\n" +
+	"\x14orig_client_order_id\x18\x02 \x01(\tR\x11origClientOrderId\"\x91\x01\n" +
+	"\x11SessionAmendOrder\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12/\n" +
+	"\x14orig_client_order_id\x18\x02 \x01(\tR\x11origClientOrderId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\"\x85\x02\n" +
+	"\x14OrderSessionResponse\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12&\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12+\n" +
+	"\x03ack\x18\x03 \x01(\v2\x17.stockorder.v1.OrderAckH\x00R\x03ack\x124\n" +
+	"\x06reject\x18\x04 \x01(\v2\x1a.stockorder.v1.OrderRejectH\x00R\x06reject\x12=\n" +
+	"\texecution\x18\x05 \x01(\v2\x1d.stockorder.v1.OrderExecutionH\x00R\texecutionB\a\n" +
+	"\x05event\";\n" +
+	"\bOrderAck\x12/\n" +
+	"\x05order\x18\x01 \x01(\v2\x19.stockorder.v1.StockOrderR\x05order\"9\n" +
+	"\vOrderReject\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\"\x85\x01\n" +
+	"\x0eOrderExecution\x12/\n" +
+	"\x05order\x18\x01 \x01(\v2\x19.stockorder.v1.StockOrderR\x05order\x12#\n" +
+	"\rlast_quantity\x18\x02 \x01(\x05R\flastQuantity\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\x03 \x01(\x01R\tlastPrice\"\xe4\x01\n" +
+	"\n" +
+	"Instrument\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\blot_size\x18\x03 \x01(\x05R\alotSize\x12\x1b\n" +
+	"\ttick_size\x18\x04 \x01(\x01R\btickSize\x12\x16\n" +
+	"\x06halted\x18\x05 \x01(\bR\x06halted\x12\x1f\n" +
+	"\vhalt_reason\x18\x06 \x01(\tR\n" +
+	"haltReason\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x18\n" +
+	"\x16ListInstrumentsRequest\"V\n" +
+	"\x17ListInstrumentsResponse\x12;\n" +
+	"\vinstruments\x18\x01 \x03(\v2\x19.stockorder.v1.InstrumentR\vinstruments\"}\n" +
+	"\x17UpsertInstrumentRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\blot_size\x18\x03 \x01(\x05R\alotSize\x12\x1b\n" +
+	"\ttick_size\x18\x04 \x01(\x01R\btickSize\"1\n" +
+	"\x17DeleteInstrumentRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"4\n" +
+	"\x18DeleteInstrumentResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"C\n" +
+	"\x11HaltSymbolRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"-\n" +
+	"\x13ResumeSymbolRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol*>\n" +
+	"\tOrderType\x12\x1a\n" +
+	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06MARKET\x10\x01\x12\t\n" +
+	"\x05LIMIT\x10\x02*:\n" +
+	"\tOrderSide\x12\x1a\n" +
+	"\x16ORDER_SIDE_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03BUY\x10\x01\x12\b\n" +
+	"\x04SELL\x10\x02*w\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aPENDING\x10\x01\x12\n" +
+	"\n" +
+	"\x06FILLED\x10\x02\x12\r\n" +
+	"\tCANCELLED\x10\x03\x12\f\n" +
+	"\bREJECTED\x10\x04\x12\x14\n" +
+	"\x10PARTIALLY_FILLED\x10\x05*4\n" +
+	"\tOrderSort\x12\x13\n" +
+	"\x0fCREATED_AT_DESC\x10\x00\x12\x12\n" +
+	"\x0eCREATED_AT_ASC\x10\x012\xc1\n" +
+	"\n" +
+	"\x11StockOrderService\x12f\n" +
+	"\vCreateOrder\x12!.stockorder.v1.CreateOrderRequest\x1a\x19.stockorder.v1.StockOrder\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/api/v1/orders\x12h\n" +
+	"\bGetOrder\x12\x1e.stockorder.v1.GetOrderRequest\x1a\x19.stockorder.v1.StockOrder\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/orders/{order_id}\x12i\n" +
+	"\n" +
+	"ListOrders\x12 .stockorder.v1.ListOrdersRequest\x1a!.stockorder.v1.ListOrdersResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/api/v1/orders\x12~\n" +
+	"\vCancelOrder\x12!.stockorder.v1.CancelOrderRequest\x1a\".stockorder.v1.CancelOrderResponse\"(\x82\xd3\xe4\x93\x02\"\" /api/v1/orders/{order_id}/cancel\x12\x87\x01\n" +
+	"\x11BatchCreateOrders\x12'.stockorder.v1.BatchCreateOrdersRequest\x1a(.stockorder.v1.BatchCreateOrdersResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/orders/batch\x12w\n" +
+	"\n" +
+	"MassCancel\x12 .stockorder.v1.MassCancelRequest\x1a!.stockorder.v1.MassCancelResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/orders/cancel-all\x12h\n" +
+	"\bGetQuote\x12\x1e.stockorder.v1.GetQuoteRequest\x1a\x14.stockorder.v1.Quote\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/symbols/{symbol}/quote\x12{\n" +
+	"\n" +
+	"GetCandles\x12 .stockorder.v1.GetCandlesRequest\x1a!.stockorder.v1.GetCandlesResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/symbols/{symbol}/candles\x12}\n" +
+	"\x0fListInstruments\x12%.stockorder.v1.ListInstrumentsRequest\x1a&.stockorder.v1.ListInstrumentsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/api/v1/instruments\x12M\n" +
+	"\vWatchOrders\x12!.stockorder.v1.WatchOrdersRequest\x1a\x19.stockorder.v1.OrderEvent0\x01\x12Z\n" +
+	"\x0fStreamOrderBook\x12%.stockorder.v1.StreamOrderBookRequest\x1a\x1e.stockorder.v1.OrderBookUpdate0\x01\x12[\n" +
+	"\fOrderSession\x12\".stockorder.v1.OrderSessionRequest\x1a#.stockorder.v1.OrderSessionResponse(\x010\x012\xa0\x04\n" +
+	"\fAdminService\x12\x84\x01\n" +
+	"\x10UpsertInstrument\x12&.stockorder.v1.UpsertInstrumentRequest\x1a\x19.stockorder.v1.Instrument\"-\x82\xd3\xe4\x93\x02':\x01*\x1a\"/api/v1/admin/instruments/{symbol}\x12\x8f\x01\n" +
+	"\x10DeleteInstrument\x12&.stockorder.v1.DeleteInstrumentRequest\x1a'.stockorder.v1.DeleteInstrumentResponse\"*\x82\xd3\xe4\x93\x02$*\"/api/v1/admin/instruments/{symbol}\x12y\n" +
+	"\n" +
+	"HaltSymbol\x12 .stockorder.v1.HaltSymbolRequest\x1a\x19.stockorder.v1.Instrument\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/admin/symbols/{symbol}/halt\x12|\n" +
+	"\fResumeSymbol\x12\".stockorder.v1.ResumeSymbolRequest\x1a\x19.stockorder.v1.Instrument\"-\x82\xd3\xe4\x93\x02'\"%/api/v1/admin/symbols/{symbol}/resumeBYZWgithub.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v1;stockorderv1b\x06proto3"
+
+var (
+	file_proto_stockorder_v1_stock_order_proto_rawDescOnce sync.Once
+	file_proto_stockorder_v1_stock_order_proto_rawDescData []byte
+)
+
+func file_proto_stockorder_v1_stock_order_proto_rawDescGZIP() []byte {
+	file_proto_stockorder_v1_stock_order_proto_rawDescOnce.Do(func() {
+		file_proto_stockorder_v1_stock_order_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_stockorder_v1_stock_order_proto_rawDesc), len(file_proto_stockorder_v1_stock_order_proto_rawDesc)))
+	})
+	return file_proto_stockorder_v1_stock_order_proto_rawDescData
+}
+
+var file_proto_stockorder_v1_stock_order_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_stockorder_v1_stock_order_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_proto_stockorder_v1_stock_order_proto_goTypes = []any{
+	(OrderType)(0),                    // 0: stockorder.v1.OrderType
+	(OrderSide)(0),                    // 1: stockorder.v1.OrderSide
+	(OrderStatus)(0),                  // 2: stockorder.v1.OrderStatus
+	(OrderSort)(0),                    // 3: stockorder.v1.OrderSort
+	(*StockOrder)(nil),                // 4: stockorder.v1.StockOrder
+	(*CreateOrderRequest)(nil),        // 5: stockorder.v1.CreateOrderRequest
+	(*GetOrderRequest)(nil),           // 6: stockorder.v1.GetOrderRequest
+	(*ListOrdersRequest)(nil),         // 7: stockorder.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),        // 8: stockorder.v1.ListOrdersResponse
+	(*CancelOrderRequest)(nil),        // 9: stockorder.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),       // 10: stockorder.v1.CancelOrderResponse
+	(*BatchCreateOrdersRequest)(nil),  // 11: stockorder.v1.BatchCreateOrdersRequest
+	(*BatchOrderResult)(nil),          // 12: stockorder.v1.BatchOrderResult
+	(*BatchCreateOrdersResponse)(nil), // 13: stockorder.v1.BatchCreateOrdersResponse
+	(*MassCancelRequest)(nil),         // 14: stockorder.v1.MassCancelRequest
+	(*CancelResult)(nil),              // 15: stockorder.v1.CancelResult
+	(*MassCancelResponse)(nil),        // 16: stockorder.v1.MassCancelResponse
+	(*GetQuoteRequest)(nil),           // 17: stockorder.v1.GetQuoteRequest
+	(*PriceLevel)(nil),                // 18: stockorder.v1.PriceLevel
+	(*Quote)(nil),                     // 19: stockorder.v1.Quote
+	(*GetCandlesRequest)(nil),         // 20: stockorder.v1.GetCandlesRequest
+	(*Candle)(nil),                    // 21: stockorder.v1.Candle
+	(*GetCandlesResponse)(nil),        // 22: stockorder.v1.GetCandlesResponse
+	(*WatchOrdersRequest)(nil),        // 23: stockorder.v1.WatchOrdersRequest
+	(*OrderEvent)(nil),                // 24: stockorder.v1.OrderEvent
+	(*StreamOrderBookRequest)(nil),    // 25: stockorder.v1.StreamOrderBookRequest
+	(*OrderBookUpdate)(nil),           // 26: stockorder.v1.OrderBookUpdate
+	(*OrderSessionRequest)(nil),       // 27: stockorder.v1.OrderSessionRequest
+	(*SessionCancelOrder)(nil),        // 28: stockorder.v1.SessionCancelOrder
+	(*SessionAmendOrder)(nil),         // 29: stockorder.v1.SessionAmendOrder
+	(*OrderSessionResponse)(nil),      // 30: stockorder.v1.OrderSessionResponse
+	(*OrderAck)(nil),                  // 31: stockorder.v1.OrderAck
+	(*OrderReject)(nil),               // 32: stockorder.v1.OrderReject
+	(*OrderExecution)(nil),            // 33: stockorder.v1.OrderExecution
+	(*Instrument)(nil),                // 34: stockorder.v1.Instrument
+	(*ListInstrumentsRequest)(nil),    // 35: stockorder.v1.ListInstrumentsRequest
+	(*ListInstrumentsResponse)(nil),   // 36: stockorder.v1.ListInstrumentsResponse
+	(*UpsertInstrumentRequest)(nil),   // 37: stockorder.v1.UpsertInstrumentRequest
+	(*DeleteInstrumentRequest)(nil),   // 38: stockorder.v1.DeleteInstrumentRequest
+	(*DeleteInstrumentResponse)(nil),  // 39: stockorder.v1.DeleteInstrumentResponse
+	(*HaltSymbolRequest)(nil),         // 40: stockorder.v1.HaltSymbolRequest
+	(*ResumeSymbolRequest)(nil),       // 41: stockorder.v1.ResumeSymbolRequest
+	(*timestamppb.Timestamp)(nil),     // 42: google.protobuf.Timestamp
+}
+var file_proto_stockorder_v1_stock_order_proto_depIdxs = []int32{
+	0,  // 0: stockorder.v1.StockOrder.order_type:type_name -> stockorder.v1.OrderType
+	1,  // 1: stockorder.v1.StockOrder.order_side:type_name -> stockorder.v1.OrderSide
+	2,  // 2: stockorder.v1.StockOrder.status:type_name -> stockorder.v1.OrderStatus
+	42, // 3: stockorder.v1.StockOrder.created_at:type_name -> google.protobuf.Timestamp
+	42, // 4: stockorder.v1.StockOrder.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: stockorder.v1.CreateOrderRequest.order_type:type_name -> stockorder.v1.OrderType
+	1,  // 6: stockorder.v1.CreateOrderRequest.order_side:type_name -> stockorder.v1.OrderSide
+	1,  // 7: stockorder.v1.ListOrdersRequest.order_side:type_name -> stockorder.v1.OrderSide
+	0,  // 8: stockorder.v1.ListOrdersRequest.order_type:type_name -> stockorder.v1.OrderType
+	2,  // 9: stockorder.v1.ListOrdersRequest.status:type_name -> stockorder.v1.OrderStatus
+	42, // 10: stockorder.v1.ListOrdersRequest.created_from:type_name -> google.protobuf.Timestamp
+	42, // 11: stockorder.v1.ListOrdersRequest.created_to:type_name -> google.protobuf.Timestamp
+	3,  // 12: stockorder.v1.ListOrdersRequest.sort:type_name -> stockorder.v1.OrderSort
+	4,  // 13: stockorder.v1.ListOrdersResponse.orders:type_name -> stockorder.v1.StockOrder
+	5,  // 14: stockorder.v1.BatchCreateOrdersRequest.orders:type_name -> stockorder.v1.CreateOrderRequest
+	4,  // 15: stockorder.v1.BatchOrderResult.order:type_name -> stockorder.v1.StockOrder
+	12, // 16: stockorder.v1.BatchCreateOrdersResponse.results:type_name -> stockorder.v1.BatchOrderResult
+	1,  // 17: stockorder.v1.MassCancelRequest.order_side:type_name -> stockorder.v1.OrderSide
+	15, // 18: stockorder.v1.MassCancelResponse.results:type_name -> stockorder.v1.CancelResult
+	42, // 19: stockorder.v1.Quote.last_trade_at:type_name -> google.protobuf.Timestamp
+	18, // 20: stockorder.v1.Quote.bids:type_name -> stockorder.v1.PriceLevel
+	18, // 21: stockorder.v1.Quote.asks:type_name -> stockorder.v1.PriceLevel
+	42, // 22: stockorder.v1.Quote.updated_at:type_name -> google.protobuf.Timestamp
+	42, // 23: stockorder.v1.GetCandlesRequest.from:type_name -> google.protobuf.Timestamp
+	42, // 24: stockorder.v1.GetCandlesRequest.to:type_name -> google.protobuf.Timestamp
+	42, // 25: stockorder.v1.Candle.open_time:type_name -> google.protobuf.Timestamp
+	21, // 26: stockorder.v1.GetCandlesResponse.candles:type_name -> stockorder.v1.Candle
+	4,  // 27: stockorder.v1.OrderEvent.order:type_name -> stockorder.v1.StockOrder
+	42, // 28: stockorder.v1.OrderEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	18, // 29: stockorder.v1.OrderBookUpdate.bids:type_name -> stockorder.v1.PriceLevel
+	18, // 30: stockorder.v1.OrderBookUpdate.asks:type_name -> stockorder.v1.PriceLevel
+	42, // 31: stockorder.v1.OrderBookUpdate.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 32: stockorder.v1.OrderSessionRequest.create:type_name -> stockorder.v1.CreateOrderRequest
+	28, // 33: stockorder.v1.OrderSessionRequest.cancel:type_name -> stockorder.v1.SessionCancelOrder
+	29, // 34: stockorder.v1.OrderSessionRequest.amend:type_name -> stockorder.v1.SessionAmendOrder
+	31, // 35: stockorder.v1.OrderSessionResponse.ack:type_name -> stockorder.v1.OrderAck
+	32, // 36: stockorder.v1.OrderSessionResponse.reject:type_name -> stockorder.v1.OrderReject
+	33, // 37: stockorder.v1.OrderSessionResponse.execution:type_name -> stockorder.v1.OrderExecution
+	4,  // 38: stockorder.v1.OrderAck.order:type_name -> stockorder.v1.StockOrder
+	4,  // 39: stockorder.v1.OrderExecution.order:type_name -> stockorder.v1.StockOrder
+	42, // 40: stockorder.v1.Instrument.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 41: stockorder.v1.ListInstrumentsResponse.instruments:type_name -> stockorder.v1.Instrument
+	5,  // 42: stockorder.v1.StockOrderService.CreateOrder:input_type -> stockorder.v1.CreateOrderRequest
+	6,  // 43: stockorder.v1.StockOrderService.GetOrder:input_type -> stockorder.v1.GetOrderRequest
+	7,  // 44: stockorder.v1.StockOrderService.ListOrders:input_type -> stockorder.v1.ListOrdersRequest
+	9,  // 45: stockorder.v1.StockOrderService.CancelOrder:input_type -> stockorder.v1.CancelOrderRequest
+	11, // 46: stockorder.v1.StockOrderService.BatchCreateOrders:input_type -> stockorder.v1.BatchCreateOrdersRequest
+	14, // 47: stockorder.v1.StockOrderService.MassCancel:input_type -> stockorder.v1.MassCancelRequest
+	17, // 48: stockorder.v1.StockOrderService.GetQuote:input_type -> stockorder.v1.GetQuoteRequest
+	20, // 49: stockorder.v1.StockOrderService.GetCandles:input_type -> stockorder.v1.GetCandlesRequest
+	35, // 50: stockorder.v1.StockOrderService.ListInstruments:input_type -> stockorder.v1.ListInstrumentsRequest
+	23, // 51: stockorder.v1.StockOrderService.WatchOrders:input_type -> stockorder.v1.WatchOrdersRequest
+	25, // 52: stockorder.v1.StockOrderService.StreamOrderBook:input_type -> stockorder.v1.StreamOrderBookRequest
+	27, // 53: stockorder.v1.StockOrderService.OrderSession:input_type -> stockorder.v1.OrderSessionRequest
+	37, // 54: stockorder.v1.AdminService.UpsertInstrument:input_type -> stockorder.v1.UpsertInstrumentRequest
+	38, // 55: stockorder.v1.AdminService.DeleteInstrument:input_type -> stockorder.v1.DeleteInstrumentRequest
+	40, // 56: stockorder.v1.AdminService.HaltSymbol:input_type -> stockorder.v1.HaltSymbolRequest
+	41, // 57: stockorder.v1.AdminService.ResumeSymbol:input_type -> stockorder.v1.ResumeSymbolRequest
+	4,  // 58: stockorder.v1.StockOrderService.CreateOrder:output_type -> stockorder.v1.StockOrder
+	4,  // 59: stockorder.v1.StockOrderService.GetOrder:output_type -> stockorder.v1.StockOrder
+	8,  // 60: stockorder.v1.StockOrderService.ListOrders:output_type -> stockorder.v1.ListOrdersResponse
+	10, // 61: stockorder.v1.StockOrderService.CancelOrder:output_type -> stockorder.v1.CancelOrderResponse
+	13, // 62: stockorder.v1.StockOrderService.BatchCreateOrders:output_type -> stockorder.v1.BatchCreateOrdersResponse
+	16, // 63: stockorder.v1.StockOrderService.MassCancel:output_type -> stockorder.v1.MassCancelResponse
+	19, // 64: stockorder.v1.StockOrderService.GetQuote:output_type -> stockorder.v1.Quote
+	22, // 65: stockorder.v1.StockOrderService.GetCandles:output_type -> stockorder.v1.GetCandlesResponse
+	36, // 66: stockorder.v1.StockOrderService.ListInstruments:output_type -> stockorder.v1.ListInstrumentsResponse
+	24, // 67: stockorder.v1.StockOrderService.WatchOrders:output_type -> stockorder.v1.OrderEvent
+	26, // 68: stockorder.v1.StockOrderService.StreamOrderBook:output_type -> stockorder.v1.OrderBookUpdate
+	30, // 69: stockorder.v1.StockOrderService.OrderSession:output_type -> stockorder.v1.OrderSessionResponse
+	34, // 70: stockorder.v1.AdminService.UpsertInstrument:output_type -> stockorder.v1.Instrument
+	39, // 71: stockorder.v1.AdminService.DeleteInstrument:output_type -> stockorder.v1.DeleteInstrumentResponse
+	34, // 72: stockorder.v1.AdminService.HaltSymbol:output_type -> stockorder.v1.Instrument
+	34, // 73: stockorder.v1.AdminService.ResumeSymbol:output_type -> stockorder.v1.Instrument
+	58, // [58:74] is the sub-list for method output_type
+	42, // [42:58] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_proto_stockorder_v1_stock_order_proto_init() }
+func file_proto_stockorder_v1_stock_order_proto_init() {
+	if File_proto_stockorder_v1_stock_order_proto != nil {
+		return
+	}
+	file_proto_stockorder_v1_stock_order_proto_msgTypes[23].OneofWrappers = []any{
+		(*OrderSessionRequest_Create)(nil),
+		(*OrderSessionRequest_Cancel)(nil),
+		(*OrderSessionRequest_Amend)(nil),
+	}
+	file_proto_stockorder_v1_stock_order_proto_msgTypes[26].OneofWrappers = []any{
+		(*OrderSessionResponse_Ack)(nil),
+		(*OrderSessionResponse_Reject)(nil),
+		(*OrderSessionResponse_Execution)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_stockorder_v1_stock_order_proto_rawDesc), len(file_proto_stockorder_v1_stock_order_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   38,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_proto_stockorder_v1_stock_order_proto_goTypes,
+		DependencyIndexes: file_proto_stockorder_v1_stock_order_proto_depIdxs,
+		EnumInfos:         file_proto_stockorder_v1_stock_order_proto_enumTypes,
+		MessageInfos:      file_proto_stockorder_v1_stock_order_proto_msgTypes,
+	}.Build()
+	File_proto_stockorder_v1_stock_order_proto = out.File
+	file_proto_stockorder_v1_stock_order_proto_goTypes = nil
+	file_proto_stockorder_v1_stock_order_proto_depIdxs = nil
+}
@@ -0,0 +1,786 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.1
+// source: proto/stockorder/v1/stock_order.proto
+
+package stockorderv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StockOrderService_CreateOrder_FullMethodName       = "/stockorder.v1.StockOrderService/CreateOrder"
+	StockOrderService_GetOrder_FullMethodName          = "/stockorder.v1.StockOrderService/GetOrder"
+	StockOrderService_ListOrders_FullMethodName        = "/stockorder.v1.StockOrderService/ListOrders"
+	StockOrderService_CancelOrder_FullMethodName       = "/stockorder.v1.StockOrderService/CancelOrder"
+	StockOrderService_BatchCreateOrders_FullMethodName = "/stockorder.v1.StockOrderService/BatchCreateOrders"
+	StockOrderService_MassCancel_FullMethodName        = "/stockorder.v1.StockOrderService/MassCancel"
+	StockOrderService_GetQuote_FullMethodName          = "/stockorder.v1.StockOrderService/GetQuote"
+	StockOrderService_GetCandles_FullMethodName        = "/stockorder.v1.StockOrderService/GetCandles"
+	StockOrderService_ListInstruments_FullMethodName   = "/stockorder.v1.StockOrderService/ListInstruments"
+	StockOrderService_WatchOrders_FullMethodName       = "/stockorder.v1.StockOrderService/WatchOrders"
+	StockOrderService_StreamOrderBook_FullMethodName   = "/stockorder.v1.StockOrderService/StreamOrderBook"
+	StockOrderService_OrderSession_FullMethodName      = "/stockorder.v1.StockOrderService/OrderSession"
+)
+
+// StockOrderServiceClient is the client API for StockOrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StockOrderService defines the gRPC service for managing stock orders. The
+// google.api.http options generate the REST gateway served under /api/v1.
+//
+// v1 is frozen: fields may be added but never renamed, renumbered or changed
+// in type. Incompatible changes go into stockorder.v2.
+type StockOrderServiceClient interface {
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*StockOrder, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*StockOrder, error)
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	BatchCreateOrders(ctx context.Context, in *BatchCreateOrdersRequest, opts ...grpc.CallOption) (*BatchCreateOrdersResponse, error)
+	// MassCancel cancels the caller's open orders matching the filter
+	MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error)
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	GetCandles(ctx context.Context, in *GetCandlesRequest, opts ...grpc.CallOption) (*GetCandlesResponse, error)
+	ListInstruments(ctx context.Context, in *ListInstrumentsRequest, opts ...grpc.CallOption) (*ListInstrumentsResponse, error)
+	// WatchOrders streams every status change of the caller's orders
+	WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderEvent], error)
+	// StreamOrderBook sends an L2 snapshot followed by incremental updates
+	StreamOrderBook(ctx context.Context, in *StreamOrderBookRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderBookUpdate], error)
+	// OrderSession accepts create, cancel and amend commands on one stream and
+	// answers with acknowledgements, rejects and executions of the session's orders
+	OrderSession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[OrderSessionRequest, OrderSessionResponse], error)
+}
+
+type stockOrderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockOrderServiceClient(cc grpc.ClientConnInterface) StockOrderServiceClient {
+	return &stockOrderServiceClient{cc}
+}
+
+func (c *stockOrderServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*StockOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockOrder)
+	err := c.cc.Invoke(ctx, StockOrderService_CreateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*StockOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockOrder)
+	err := c.cc.Invoke(ctx, StockOrderService_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, StockOrderService_ListOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, StockOrderService_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) BatchCreateOrders(ctx context.Context, in *BatchCreateOrdersRequest, opts ...grpc.CallOption) (*BatchCreateOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCreateOrdersResponse)
+	err := c.cc.Invoke(ctx, StockOrderService_BatchCreateOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MassCancelResponse)
+	err := c.cc.Invoke(ctx, StockOrderService_MassCancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, StockOrderService_GetQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) GetCandles(ctx context.Context, in *GetCandlesRequest, opts ...grpc.CallOption) (*GetCandlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCandlesResponse)
+	err := c.cc.Invoke(ctx, StockOrderService_GetCandles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) ListInstruments(ctx context.Context, in *ListInstrumentsRequest, opts ...grpc.CallOption) (*ListInstrumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInstrumentsResponse)
+	err := c.cc.Invoke(ctx, StockOrderService_ListInstruments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockOrderServiceClient) WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockOrderService_ServiceDesc.Streams[0], StockOrderService_WatchOrders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrdersRequest, OrderEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockOrderService_WatchOrdersClient = grpc.ServerStreamingClient[OrderEvent]
+
+func (c *stockOrderServiceClient) StreamOrderBook(ctx context.Context, in *StreamOrderBookRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderBookUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockOrderService_ServiceDesc.Streams[1], StockOrderService_StreamOrderBook_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamOrderBookRequest, OrderBookUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockOrderService_StreamOrderBookClient = grpc.ServerStreamingClient[OrderBookUpdate]
+
+func (c *stockOrderServiceClient) OrderSession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[OrderSessionRequest, OrderSessionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockOrderService_ServiceDesc.Streams[2], StockOrderService_OrderSession_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[OrderSessionRequest, OrderSessionResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockOrderService_OrderSessionClient = grpc.BidiStreamingClient[OrderSessionRequest, OrderSessionResponse]
+
+// StockOrderServiceServer is the server API for StockOrderService service.
+// All implementations must embed UnimplementedStockOrderServiceServer
+// for forward compatibility.
+//
+// StockOrderService defines the gRPC service for managing stock orders. The
+// google.api.http options generate the REST gateway served under /api/v1.
+//
+// v1 is frozen: fields may be added but never renamed, renumbered or changed
+// in type. Incompatible changes go into stockorder.v2.
+type StockOrderServiceServer interface {
+	CreateOrder(context.Context, *CreateOrderRequest) (*StockOrder, error)
+	GetOrder(context.Context, *GetOrderRequest) (*StockOrder, error)
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	BatchCreateOrders(context.Context, *BatchCreateOrdersRequest) (*BatchCreateOrdersResponse, error)
+	// MassCancel cancels the caller's open orders matching the filter
+	MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error)
+	GetQuote(context.Context, *GetQuoteRequest) (*Quote, error)
+	GetCandles(context.Context, *GetCandlesRequest) (*GetCandlesResponse, error)
+	ListInstruments(context.Context, *ListInstrumentsRequest) (*ListInstrumentsResponse, error)
+	// WatchOrders streams every status change of the caller's orders
+	WatchOrders(*WatchOrdersRequest, grpc.ServerStreamingServer[OrderEvent]) error
+	// StreamOrderBook sends an L2 snapshot followed by incremental updates
+	StreamOrderBook(*StreamOrderBookRequest, grpc.ServerStreamingServer[OrderBookUpdate]) error
+	// OrderSession accepts create, cancel and amend commands on one stream and
+	// answers with acknowledgements, rejects and executions of the session's orders
+	OrderSession(grpc.BidiStreamingServer[OrderSessionRequest, OrderSessionResponse]) error
+	mustEmbedUnimplementedStockOrderServiceServer()
+}
+
+// UnimplementedStockOrderServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStockOrderServiceServer struct{}
+
+func (UnimplementedStockOrderServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*StockOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedStockOrderServiceServer) GetOrder(context.Context, *GetOrderRequest) (*StockOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedStockOrderServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedStockOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedStockOrderServiceServer) BatchCreateOrders(context.Context, *BatchCreateOrdersRequest) (*BatchCreateOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateOrders not implemented")
+}
+func (UnimplementedStockOrderServiceServer) MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MassCancel not implemented")
+}
+func (UnimplementedStockOrderServiceServer) GetQuote(context.Context, *GetQuoteRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (UnimplementedStockOrderServiceServer) GetCandles(context.Context, *GetCandlesRequest) (*GetCandlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandles not implemented")
+}
+func (UnimplementedStockOrderServiceServer) ListInstruments(context.Context, *ListInstrumentsRequest) (*ListInstrumentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInstruments not implemented")
+}
+func (UnimplementedStockOrderServiceServer) WatchOrders(*WatchOrdersRequest, grpc.ServerStreamingServer[OrderEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrders not implemented")
+}
+func (UnimplementedStockOrderServiceServer) StreamOrderBook(*StreamOrderBookRequest, grpc.ServerStreamingServer[OrderBookUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOrderBook not implemented")
+}
+func (UnimplementedStockOrderServiceServer) OrderSession(grpc.BidiStreamingServer[OrderSessionRequest, OrderSessionResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method OrderSession not implemented")
+}
+func (UnimplementedStockOrderServiceServer) mustEmbedUnimplementedStockOrderServiceServer() {}
+func (UnimplementedStockOrderServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeStockOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockOrderServiceServer will
+// result in compilation errors.
+type UnsafeStockOrderServiceServer interface {
+	mustEmbedUnimplementedStockOrderServiceServer()
+}
+
+func RegisterStockOrderServiceServer(s grpc.ServiceRegistrar, srv StockOrderServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStockOrderServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StockOrderService_ServiceDesc, srv)
+}
+
+func _StockOrderService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_ListOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_BatchCreateOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).BatchCreateOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_BatchCreateOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).BatchCreateOrders(ctx, req.(*BatchCreateOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_MassCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MassCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).MassCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_MassCancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).MassCancel(ctx, req.(*MassCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_GetQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_GetCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).GetCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_GetCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).GetCandles(ctx, req.(*GetCandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_ListInstruments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInstrumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockOrderServiceServer).ListInstruments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockOrderService_ListInstruments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockOrderServiceServer).ListInstruments(ctx, req.(*ListInstrumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockOrderService_WatchOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockOrderServiceServer).WatchOrders(m, &grpc.GenericServerStream[WatchOrdersRequest, OrderEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockOrderService_WatchOrdersServer = grpc.ServerStreamingServer[OrderEvent]
+
+func _StockOrderService_StreamOrderBook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOrderBookRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockOrderServiceServer).StreamOrderBook(m, &grpc.GenericServerStream[StreamOrderBookRequest, OrderBookUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockOrderService_StreamOrderBookServer = grpc.ServerStreamingServer[OrderBookUpdate]
+
+func _StockOrderService_OrderSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StockOrderServiceServer).OrderSession(&grpc.GenericServerStream[OrderSessionRequest, OrderSessionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockOrderService_OrderSessionServer = grpc.BidiStreamingServer[OrderSessionRequest, OrderSessionResponse]
+
+// StockOrderService_ServiceDesc is the grpc.ServiceDesc for StockOrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockOrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stockorder.v1.StockOrderService",
+	HandlerType: (*StockOrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrder",
+			Handler:    _StockOrderService_CreateOrder_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _StockOrderService_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOrders",
+			Handler:    _StockOrderService_ListOrders_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _StockOrderService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "BatchCreateOrders",
+			Handler:    _StockOrderService_BatchCreateOrders_Handler,
+		},
+		{
+			MethodName: "MassCancel",
+			Handler:    _StockOrderService_MassCancel_Handler,
+		},
+		{
+			MethodName: "GetQuote",
+			Handler:    _StockOrderService_GetQuote_Handler,
+		},
+		{
+			MethodName: "GetCandles",
+			Handler:    _StockOrderService_GetCandles_Handler,
+		},
+		{
+			MethodName: "ListInstruments",
+			Handler:    _StockOrderService_ListInstruments_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrders",
+			Handler:       _StockOrderService_WatchOrders_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamOrderBook",
+			Handler:       _StockOrderService_StreamOrderBook_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "OrderSession",
+			Handler:       _StockOrderService_OrderSession_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/stockorder/v1/stock_order.proto",
+}
+
+const (
+	AdminService_UpsertInstrument_FullMethodName = "/stockorder.v1.AdminService/UpsertInstrument"
+	AdminService_DeleteInstrument_FullMethodName = "/stockorder.v1.AdminService/DeleteInstrument"
+	AdminService_HaltSymbol_FullMethodName       = "/stockorder.v1.AdminService/HaltSymbol"
+	AdminService_ResumeSymbol_FullMethodName     = "/stockorder.v1.AdminService/ResumeSymbol"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AdminService holds the operations reserved for the ops and admin roles
+type AdminServiceClient interface {
+	// UpsertInstrument creates or replaces the reference data of an instrument
+	UpsertInstrument(ctx context.Context, in *UpsertInstrumentRequest, opts ...grpc.CallOption) (*Instrument, error)
+	DeleteInstrument(ctx context.Context, in *DeleteInstrumentRequest, opts ...grpc.CallOption) (*DeleteInstrumentResponse, error)
+	// HaltSymbol stops new and amended orders for a symbol; cancels are still accepted
+	HaltSymbol(ctx context.Context, in *HaltSymbolRequest, opts ...grpc.CallOption) (*Instrument, error)
+	ResumeSymbol(ctx context.Context, in *ResumeSymbolRequest, opts ...grpc.CallOption) (*Instrument, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) UpsertInstrument(ctx context.Context, in *UpsertInstrumentRequest, opts ...grpc.CallOption) (*Instrument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, AdminService_UpsertInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteInstrument(ctx context.Context, in *DeleteInstrumentRequest, opts ...grpc.CallOption) (*DeleteInstrumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteInstrumentResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) HaltSymbol(ctx context.Context, in *HaltSymbolRequest, opts ...grpc.CallOption) (*Instrument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, AdminService_HaltSymbol_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumeSymbol(ctx context.Context, in *ResumeSymbolRequest, opts ...grpc.CallOption) (*Instrument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, AdminService_ResumeSymbol_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// AdminService holds the operations reserved for the ops and admin roles
+type AdminServiceServer interface {
+	// UpsertInstrument creates or replaces the reference data of an instrument
+	UpsertInstrument(context.Context, *UpsertInstrumentRequest) (*Instrument, error)
+	DeleteInstrument(context.Context, *DeleteInstrumentRequest) (*DeleteInstrumentResponse, error)
+	// HaltSymbol stops new and amended orders for a symbol; cancels are still accepted
+	HaltSymbol(context.Context, *HaltSymbolRequest) (*Instrument, error)
+	ResumeSymbol(context.Context, *ResumeSymbolRequest) (*Instrument, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) UpsertInstrument(context.Context, *UpsertInstrumentRequest) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertInstrument not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteInstrument(context.Context, *DeleteInstrumentRequest) (*DeleteInstrumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInstrument not implemented")
+}
+func (UnimplementedAdminServiceServer) HaltSymbol(context.Context, *HaltSymbolRequest) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HaltSymbol not implemented")
+}
+func (UnimplementedAdminServiceServer) ResumeSymbol(context.Context, *ResumeSymbolRequest) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSymbol not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_UpsertInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertInstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpsertInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpsertInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpsertInstrument(ctx, req.(*UpsertInstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteInstrument(ctx, req.(*DeleteInstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_HaltSymbol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaltSymbolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).HaltSymbol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_HaltSymbol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).HaltSymbol(ctx, req.(*HaltSymbolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumeSymbol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeSymbolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumeSymbol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ResumeSymbol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumeSymbol(ctx, req.(*ResumeSymbolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stockorder.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertInstrument",
+			Handler:    _AdminService_UpsertInstrument_Handler,
+		},
+		{
+			MethodName: "DeleteInstrument",
+			Handler:    _AdminService_DeleteInstrument_Handler,
+		},
+		{
+			MethodName: "HaltSymbol",
+			Handler:    _AdminService_HaltSymbol_Handler,
+		},
+		{
+			MethodName: "ResumeSymbol",
+			Handler:    _AdminService_ResumeSymbol_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/stockorder/v1/stock_order.proto",
+}
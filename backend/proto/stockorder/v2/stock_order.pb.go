@@ -0,0 +1,986 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: proto/stockorder/v2/stock_order.proto
+
+package stockorderv2
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Enums
+type OrderType int32
+
+const (
+	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
+	OrderType_ORDER_TYPE_MARKET      OrderType = 1
+	OrderType_ORDER_TYPE_LIMIT       OrderType = 2
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "ORDER_TYPE_UNSPECIFIED",
+		1: "ORDER_TYPE_MARKET",
+		2: "ORDER_TYPE_LIMIT",
+	}
+	OrderType_value = map[string]int32{
+		"ORDER_TYPE_UNSPECIFIED": 0,
+		"ORDER_TYPE_MARKET":      1,
+		"ORDER_TYPE_LIMIT":       2,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_stockorder_v2_stock_order_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_proto_stockorder_v2_stock_order_proto_enumTypes[0]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderSide int32
+
+const (
+	OrderSide_ORDER_SIDE_UNSPECIFIED OrderSide = 0
+	OrderSide_ORDER_SIDE_BUY         OrderSide = 1
+	OrderSide_ORDER_SIDE_SELL        OrderSide = 2
+)
+
+// Enum value maps for OrderSide.
+var (
+	OrderSide_name = map[int32]string{
+		0: "ORDER_SIDE_UNSPECIFIED",
+		1: "ORDER_SIDE_BUY",
+		2: "ORDER_SIDE_SELL",
+	}
+	OrderSide_value = map[string]int32{
+		"ORDER_SIDE_UNSPECIFIED": 0,
+		"ORDER_SIDE_BUY":         1,
+		"ORDER_SIDE_SELL":        2,
+	}
+)
+
+func (x OrderSide) Enum() *OrderSide {
+	p := new(OrderSide)
+	*p = x
+	return p
+}
+
+func (x OrderSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_stockorder_v2_stock_order_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderSide) Type() protoreflect.EnumType {
+	return &file_proto_stockorder_v2_stock_order_proto_enumTypes[1]
+}
+
+func (x OrderSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderSide.Descriptor instead.
+func (OrderSide) EnumDescriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED      OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING          OrderStatus = 1
+	OrderStatus_ORDER_STATUS_PARTIALLY_FILLED OrderStatus = 2
+	OrderStatus_ORDER_STATUS_FILLED           OrderStatus = 3
+	OrderStatus_ORDER_STATUS_CANCELLED        OrderStatus = 4
+	OrderStatus_ORDER_STATUS_REJECTED         OrderStatus = 5
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_PENDING",
+		2: "ORDER_STATUS_PARTIALLY_FILLED",
+		3: "ORDER_STATUS_FILLED",
+		4: "ORDER_STATUS_CANCELLED",
+		5: "ORDER_STATUS_REJECTED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED":      0,
+		"ORDER_STATUS_PENDING":          1,
+		"ORDER_STATUS_PARTIALLY_FILLED": 2,
+		"ORDER_STATUS_FILLED":           3,
+		"ORDER_STATUS_CANCELLED":        4,
+		"ORDER_STATUS_REJECTED":         5,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_stockorder_v2_stock_order_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_proto_stockorder_v2_stock_order_proto_enumTypes[2]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{2}
+}
+
+type OrderSort int32
+
+const (
+	OrderSort_ORDER_SORT_UNSPECIFIED     OrderSort = 0
+	OrderSort_ORDER_SORT_CREATED_AT_DESC OrderSort = 1
+	OrderSort_ORDER_SORT_CREATED_AT_ASC  OrderSort = 2
+)
+
+// Enum value maps for OrderSort.
+var (
+	OrderSort_name = map[int32]string{
+		0: "ORDER_SORT_UNSPECIFIED",
+		1: "ORDER_SORT_CREATED_AT_DESC",
+		2: "ORDER_SORT_CREATED_AT_ASC",
+	}
+	OrderSort_value = map[string]int32{
+		"ORDER_SORT_UNSPECIFIED":     0,
+		"ORDER_SORT_CREATED_AT_DESC": 1,
+		"ORDER_SORT_CREATED_AT_ASC":  2,
+	}
+)
+
+func (x OrderSort) Enum() *OrderSort {
+	p := new(OrderSort)
+	*p = x
+	return p
+}
+
+func (x OrderSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_stockorder_v2_stock_order_proto_enumTypes[3].Descriptor()
+}
+
+func (OrderSort) Type() protoreflect.EnumType {
+	return &file_proto_stockorder_v2_stock_order_proto_enumTypes[3]
+}
+
+func (x OrderSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderSort.Descriptor instead.
+func (OrderSort) EnumDescriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{3}
+}
+
+// Messages
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderType     OrderType              `protobuf:"varint,5,opt,name=order_type,json=orderType,proto3,enum=stockorder.v2.OrderType" json:"order_type,omitempty"`
+	OrderSide     OrderSide              `protobuf:"varint,6,opt,name=order_side,json=orderSide,proto3,enum=stockorder.v2.OrderSide" json:"order_side,omitempty"`
+	Quantity      int64                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Decimal limit price; empty for market orders
+	Price          string      `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	Status         OrderStatus `protobuf:"varint,9,opt,name=status,proto3,enum=stockorder.v2.OrderStatus" json:"status,omitempty"`
+	FilledQuantity int64       `protobuf:"varint,10,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	// Decimal volume-weighted price of the fills; empty until the first fill
+	AveragePrice string `protobuf:"bytes,11,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"`
+	// Executions of the order, oldest first
+	Fills         []*Fill                `protobuf:"bytes,12,rep,name=fills,proto3" json:"fills,omitempty"`
+	Description   string                 `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Order) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *Order) GetOrderSide() OrderSide {
+	if x != nil {
+		return x.OrderSide
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *Order) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Order) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetFilledQuantity() int64 {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return 0
+}
+
+func (x *Order) GetAveragePrice() string {
+	if x != nil {
+		return x.AveragePrice
+	}
+	return ""
+}
+
+func (x *Order) GetFills() []*Fill {
+	if x != nil {
+		return x.Fills
+	}
+	return nil
+}
+
+func (x *Order) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Fill struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TradeId string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	// Decimal execution price
+	Price      string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity   int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExecutedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	// Decimal commission charged to the order's account on this fill, from its
+	// tenant's fee schedule
+	Fee           string `protobuf:"bytes,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fill) Reset() {
+	*x = Fill{}
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fill) ProtoMessage() {}
+
+func (x *Fill) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fill.ProtoReflect.Descriptor instead.
+func (*Fill) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Fill) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *Fill) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Fill) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Fill) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+func (x *Fill) GetFee() string {
+	if x != nil {
+		return x.Fee
+	}
+	return ""
+}
+
+type CreateOrderRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Symbol    string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderType OrderType              `protobuf:"varint,2,opt,name=order_type,json=orderType,proto3,enum=stockorder.v2.OrderType" json:"order_type,omitempty"`
+	OrderSide OrderSide              `protobuf:"varint,3,opt,name=order_side,json=orderSide,proto3,enum=stockorder.v2.OrderSide" json:"order_side,omitempty"`
+	Quantity  int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Decimal limit price, required for limit orders, with at most 15
+	// significant digits
+	Price string `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	// Makes the request idempotent per account; the idempotency-key metadata
+	// may be used instead
+	ClientOrderId string `protobuf:"bytes,6,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetOrderSide() OrderSide {
+	if x != nil {
+		return x.OrderSide
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Unset filters match every order
+type ListOrdersRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Symbol    string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	OrderSide OrderSide              `protobuf:"varint,2,opt,name=order_side,json=orderSide,proto3,enum=stockorder.v2.OrderSide" json:"order_side,omitempty"`
+	OrderType OrderType              `protobuf:"varint,3,opt,name=order_type,json=orderType,proto3,enum=stockorder.v2.OrderType" json:"order_type,omitempty"`
+	Status    OrderStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=stockorder.v2.OrderStatus" json:"status,omitempty"`
+	// Inclusive lower and exclusive upper bound on created_at
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// Defaults to ORDER_SORT_CREATED_AT_DESC
+	Sort OrderSort `protobuf:"varint,7,opt,name=sort,proto3,enum=stockorder.v2.OrderSort" json:"sort,omitempty"`
+	// Defaults to 50, at most 500
+	PageSize int32 `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, issued for the same sort order
+	PageToken     string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListOrdersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetOrderSide() OrderSide {
+	if x != nil {
+		return x.OrderSide
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
+}
+
+func (x *ListOrdersRequest) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *ListOrdersRequest) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *ListOrdersRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetSort() OrderSort {
+	if x != nil {
+		return x.Sort
+	}
+	return OrderSort_ORDER_SORT_UNSPECIFIED
+}
+
+func (x *ListOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stockorder_v2_stock_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_stockorder_v2_stock_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+var File_proto_stockorder_v2_stock_order_proto protoreflect.FileDescriptor
+
+const file_proto_stockorder_v2_stock_order_proto_rawDesc = "" +
+	"\n" +
+	"%proto/stockorder/v2/stock_order.proto\x12\rstockorder.v2\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdf\x04\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12&\n" +
+	"\x0fclient_order_id\x18\x03 \x01(\tR\rclientOrderId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x127\n" +
+	"\n" +
+	"order_type\x18\x05 \x01(\x0e2\x18.stockorder.v2.OrderTypeR\torderType\x127\n" +
+	"\n" +
+	"order_side\x18\x06 \x01(\x0e2\x18.stockorder.v2.OrderSideR\torderSide\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x03R\bquantity\x12\x14\n" +
+	"\x05price\x18\b \x01(\tR\x05price\x122\n" +
+	"\x06status\x18\t \x01(\x0e2\x1a.stockorder.v2.OrderStatusR\x06status\x12'\n" +
+	"\x0ffilled_quantity\x18\n" +
+	" \x01(\x03R\x0efilledQuantity\x12#\n" +
+	"\raverage_price\x18\v \x01(\tR\faveragePrice\x12)\n" +
+	"\x05fills\x18\f \x03(\v2\x13.stockorder.v2.FillR\x05fills\x12 \n" +
+	"\vdescription\x18\r \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa2\x01\n" +
+	"\x04Fill\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\x12;\n" +
+	"\vexecuted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAt\x12\x10\n" +
+	"\x03fee\x18\x05 \x01(\tR\x03fee\"\xf8\x01\n" +
+	"\x12CreateOrderRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x127\n" +
+	"\n" +
+	"order_type\x18\x02 \x01(\x0e2\x18.stockorder.v2.OrderTypeR\torderType\x127\n" +
+	"\n" +
+	"order_side\x18\x03 \x01(\x0e2\x18.stockorder.v2.OrderSideR\torderSide\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12&\n" +
+	"\x0fclient_order_id\x18\x06 \x01(\tR\rclientOrderId\",\n" +
+	"\x0fGetOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\xb5\x03\n" +
+	"\x11ListOrdersRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x127\n" +
+	"\n" +
+	"order_side\x18\x02 \x01(\x0e2\x18.stockorder.v2.OrderSideR\torderSide\x127\n" +
+	"\n" +
+	"order_type\x18\x03 \x01(\x0e2\x18.stockorder.v2.OrderTypeR\torderType\x122\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1a.stockorder.v2.OrderStatusR\x06status\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12,\n" +
+	"\x04sort\x18\a \x01(\x0e2\x18.stockorder.v2.OrderSortR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\"j\n" +
+	"\x12ListOrdersResponse\x12,\n" +
+	"\x06orders\x18\x01 \x03(\v2\x14.stockorder.v2.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"/\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId*T\n" +
+	"\tOrderType\x12\x1a\n" +
+	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11ORDER_TYPE_MARKET\x10\x01\x12\x14\n" +
+	"\x10ORDER_TYPE_LIMIT\x10\x02*P\n" +
+	"\tOrderSide\x12\x1a\n" +
+	"\x16ORDER_SIDE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eORDER_SIDE_BUY\x10\x01\x12\x13\n" +
+	"\x0fORDER_SIDE_SELL\x10\x02*\xb8\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_PENDING\x10\x01\x12!\n" +
+	"\x1dORDER_STATUS_PARTIALLY_FILLED\x10\x02\x12\x17\n" +
+	"\x13ORDER_STATUS_FILLED\x10\x03\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x04\x12\x19\n" +
+	"\x15ORDER_STATUS_REJECTED\x10\x05*f\n" +
+	"\tOrderSort\x12\x1a\n" +
+	"\x16ORDER_SORT_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aORDER_SORT_CREATED_AT_DESC\x10\x01\x12\x1d\n" +
+	"\x19ORDER_SORT_CREATED_AT_ASC\x10\x022\xb8\x03\n" +
+	"\x11StockOrderService\x12a\n" +
+	"\vCreateOrder\x12!.stockorder.v2.CreateOrderRequest\x1a\x14.stockorder.v2.Order\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/api/v2/orders\x12c\n" +
+	"\bGetOrder\x12\x1e.stockorder.v2.GetOrderRequest\x1a\x14.stockorder.v2.Order\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v2/orders/{order_id}\x12i\n" +
+	"\n" +
+	"ListOrders\x12 .stockorder.v2.ListOrdersRequest\x1a!.stockorder.v2.ListOrdersResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/api/v2/orders\x12p\n" +
+	"\vCancelOrder\x12!.stockorder.v2.CancelOrderRequest\x1a\x14.stockorder.v2.Order\"(\x82\xd3\xe4\x93\x02\"\" /api/v2/orders/{order_id}/cancelBYZWgithub.com/newnok6/kkp-dime-golang-meetup-2025/backend/proto/stockorder/v2;stockorderv2b\x06proto3"
+
+var (
+	file_proto_stockorder_v2_stock_order_proto_rawDescOnce sync.Once
+	file_proto_stockorder_v2_stock_order_proto_rawDescData []byte
+)
+
+func file_proto_stockorder_v2_stock_order_proto_rawDescGZIP() []byte {
+	file_proto_stockorder_v2_stock_order_proto_rawDescOnce.Do(func() {
+		file_proto_stockorder_v2_stock_order_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_stockorder_v2_stock_order_proto_rawDesc), len(file_proto_stockorder_v2_stock_order_proto_rawDesc)))
+	})
+	return file_proto_stockorder_v2_stock_order_proto_rawDescData
+}
+
+var file_proto_stockorder_v2_stock_order_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_stockorder_v2_stock_order_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_stockorder_v2_stock_order_proto_goTypes = []any{
+	(OrderType)(0),                // 0: stockorder.v2.OrderType
+	(OrderSide)(0),                // 1: stockorder.v2.OrderSide
+	(OrderStatus)(0),              // 2: stockorder.v2.OrderStatus
+	(OrderSort)(0),                // 3: stockorder.v2.OrderSort
+	(*Order)(nil),                 // 4: stockorder.v2.Order
+	(*Fill)(nil),                  // 5: stockorder.v2.Fill
+	(*CreateOrderRequest)(nil),    // 6: stockorder.v2.CreateOrderRequest
+	(*GetOrderRequest)(nil),       // 7: stockorder.v2.GetOrderRequest
+	(*ListOrdersRequest)(nil),     // 8: stockorder.v2.ListOrdersRequest
+	(*ListOrdersResponse)(nil),    // 9: stockorder.v2.ListOrdersResponse
+	(*CancelOrderRequest)(nil),    // 10: stockorder.v2.CancelOrderRequest
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+}
+var file_proto_stockorder_v2_stock_order_proto_depIdxs = []int32{
+	0,  // 0: stockorder.v2.Order.order_type:type_name -> stockorder.v2.OrderType
+	1,  // 1: stockorder.v2.Order.order_side:type_name -> stockorder.v2.OrderSide
+	2,  // 2: stockorder.v2.Order.status:type_name -> stockorder.v2.OrderStatus
+	5,  // 3: stockorder.v2.Order.fills:type_name -> stockorder.v2.Fill
+	11, // 4: stockorder.v2.Order.created_at:type_name -> google.protobuf.Timestamp
+	11, // 5: stockorder.v2.Order.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 6: stockorder.v2.Fill.executed_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: stockorder.v2.CreateOrderRequest.order_type:type_name -> stockorder.v2.OrderType
+	1,  // 8: stockorder.v2.CreateOrderRequest.order_side:type_name -> stockorder.v2.OrderSide
+	1,  // 9: stockorder.v2.ListOrdersRequest.order_side:type_name -> stockorder.v2.OrderSide
+	0,  // 10: stockorder.v2.ListOrdersRequest.order_type:type_name -> stockorder.v2.OrderType
+	2,  // 11: stockorder.v2.ListOrdersRequest.status:type_name -> stockorder.v2.OrderStatus
+	11, // 12: stockorder.v2.ListOrdersRequest.created_from:type_name -> google.protobuf.Timestamp
+	11, // 13: stockorder.v2.ListOrdersRequest.created_to:type_name -> google.protobuf.Timestamp
+	3,  // 14: stockorder.v2.ListOrdersRequest.sort:type_name -> stockorder.v2.OrderSort
+	4,  // 15: stockorder.v2.ListOrdersResponse.orders:type_name -> stockorder.v2.Order
+	6,  // 16: stockorder.v2.StockOrderService.CreateOrder:input_type -> stockorder.v2.CreateOrderRequest
+	7,  // 17: stockorder.v2.StockOrderService.GetOrder:input_type -> stockorder.v2.GetOrderRequest
+	8,  // 18: stockorder.v2.StockOrderService.ListOrders:input_type -> stockorder.v2.ListOrdersRequest
+	10, // 19: stockorder.v2.StockOrderService.CancelOrder:input_type -> stockorder.v2.CancelOrderRequest
+	4,  // 20: stockorder.v2.StockOrderService.CreateOrder:output_type -> stockorder.v2.Order
+	4,  // 21: stockorder.v2.StockOrderService.GetOrder:output_type -> stockorder.v2.Order
+	9,  // 22: stockorder.v2.StockOrderService.ListOrders:output_type -> stockorder.v2.ListOrdersResponse
+	4,  // 23: stockorder.v2.StockOrderService.CancelOrder:output_type -> stockorder.v2.Order
+	20, // [20:24] is the sub-list for method output_type
+	16, // [16:20] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_proto_stockorder_v2_stock_order_proto_init() }
+func file_proto_stockorder_v2_stock_order_proto_init() {
+	if File_proto_stockorder_v2_stock_order_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_stockorder_v2_stock_order_proto_rawDesc), len(file_proto_stockorder_v2_stock_order_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_stockorder_v2_stock_order_proto_goTypes,
+		DependencyIndexes: file_proto_stockorder_v2_stock_order_proto_depIdxs,
+		EnumInfos:         file_proto_stockorder_v2_stock_order_proto_enumTypes,
+		MessageInfos:      file_proto_stockorder_v2_stock_order_proto_msgTypes,
+	}.Build()
+	File_proto_stockorder_v2_stock_order_proto = out.File
+	file_proto_stockorder_v2_stock_order_proto_goTypes = nil
+	file_proto_stockorder_v2_stock_order_proto_depIdxs = nil
+}
@@ -2,9 +2,9 @@
 // versions:
 // - protoc-gen-go-grpc v1.5.1
 // - protoc             v6.33.1
-// source: proto/stock_order.proto
+// source: proto/stockorder/v2/stock_order.proto
 
-package proto
+package stockorderv2
 
 import (
 	context "context"
@@ -19,22 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StockOrderService_CreateOrder_FullMethodName = "/stockorder.StockOrderService/CreateOrder"
-	StockOrderService_GetOrder_FullMethodName    = "/stockorder.StockOrderService/GetOrder"
-	StockOrderService_ListOrders_FullMethodName  = "/stockorder.StockOrderService/ListOrders"
-	StockOrderService_CancelOrder_FullMethodName = "/stockorder.StockOrderService/CancelOrder"
+	StockOrderService_CreateOrder_FullMethodName = "/stockorder.v2.StockOrderService/CreateOrder"
+	StockOrderService_GetOrder_FullMethodName    = "/stockorder.v2.StockOrderService/GetOrder"
+	StockOrderService_ListOrders_FullMethodName  = "/stockorder.v2.StockOrderService/ListOrders"
+	StockOrderService_CancelOrder_FullMethodName = "/stockorder.v2.StockOrderService/CancelOrder"
 )
 
 // StockOrderServiceClient is the client API for StockOrderService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// StockOrderService defines the gRPC service for managing stock orders
+// StockOrderService is version 2 of the order API. It is served by the same
+// service as stockorder.v1; orders created through either version are visible
+// through both. The google.api.http options generate the REST gateway served
+// under /api/v2.
+//
+// Changes from v1:
+//   - prices are decimal strings such as "101.25" instead of doubles
+//   - quantities are int64
+//   - orders carry the account they belong to and the fills that executed them
+//   - CancelOrder returns the cancelled order
+//   - enum values are prefixed with their type name
+//
+// Batches, mass cancel, market data and the streaming RPCs remain on v1.
 type StockOrderServiceClient interface {
-	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*StockOrder, error)
-	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*StockOrder, error)
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
 	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
-	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error)
 }
 
 type stockOrderServiceClient struct {
@@ -45,9 +57,9 @@ func NewStockOrderServiceClient(cc grpc.ClientConnInterface) StockOrderServiceCl
 	return &stockOrderServiceClient{cc}
 }
 
-func (c *stockOrderServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*StockOrder, error) {
+func (c *stockOrderServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(StockOrder)
+	out := new(Order)
 	err := c.cc.Invoke(ctx, StockOrderService_CreateOrder_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -55,9 +67,9 @@ func (c *stockOrderServiceClient) CreateOrder(ctx context.Context, in *CreateOrd
 	return out, nil
 }
 
-func (c *stockOrderServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*StockOrder, error) {
+func (c *stockOrderServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(StockOrder)
+	out := new(Order)
 	err := c.cc.Invoke(ctx, StockOrderService_GetOrder_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -75,9 +87,9 @@ func (c *stockOrderServiceClient) ListOrders(ctx context.Context, in *ListOrders
 	return out, nil
 }
 
-func (c *stockOrderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+func (c *stockOrderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CancelOrderResponse)
+	out := new(Order)
 	err := c.cc.Invoke(ctx, StockOrderService_CancelOrder_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -89,12 +101,24 @@ func (c *stockOrderServiceClient) CancelOrder(ctx context.Context, in *CancelOrd
 // All implementations must embed UnimplementedStockOrderServiceServer
 // for forward compatibility.
 //
-// StockOrderService defines the gRPC service for managing stock orders
+// StockOrderService is version 2 of the order API. It is served by the same
+// service as stockorder.v1; orders created through either version are visible
+// through both. The google.api.http options generate the REST gateway served
+// under /api/v2.
+//
+// Changes from v1:
+//   - prices are decimal strings such as "101.25" instead of doubles
+//   - quantities are int64
+//   - orders carry the account they belong to and the fills that executed them
+//   - CancelOrder returns the cancelled order
+//   - enum values are prefixed with their type name
+//
+// Batches, mass cancel, market data and the streaming RPCs remain on v1.
 type StockOrderServiceServer interface {
-	CreateOrder(context.Context, *CreateOrderRequest) (*StockOrder, error)
-	GetOrder(context.Context, *GetOrderRequest) (*StockOrder, error)
+	CreateOrder(context.Context, *CreateOrderRequest) (*Order, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
 	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
-	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*Order, error)
 	mustEmbedUnimplementedStockOrderServiceServer()
 }
 
@@ -105,16 +129,16 @@ type StockOrderServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedStockOrderServiceServer struct{}
 
-func (UnimplementedStockOrderServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*StockOrder, error) {
+func (UnimplementedStockOrderServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*Order, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
 }
-func (UnimplementedStockOrderServiceServer) GetOrder(context.Context, *GetOrderRequest) (*StockOrder, error) {
+func (UnimplementedStockOrderServiceServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
 }
 func (UnimplementedStockOrderServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
 }
-func (UnimplementedStockOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+func (UnimplementedStockOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*Order, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
 }
 func (UnimplementedStockOrderServiceServer) mustEmbedUnimplementedStockOrderServiceServer() {}
@@ -214,7 +238,7 @@ func _StockOrderService_CancelOrder_Handler(srv interface{}, ctx context.Context
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var StockOrderService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "stockorder.StockOrderService",
+	ServiceName: "stockorder.v2.StockOrderService",
 	HandlerType: (*StockOrderServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -235,5 +259,5 @@ var StockOrderService_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/stock_order.proto",
+	Metadata: "proto/stockorder/v2/stock_order.proto",
 }
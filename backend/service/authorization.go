@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// DefaultPolicy lets every role read, traders create and manage their own
+// orders, and ops and admins cancel any account's orders, halt symbols and
+// manage instruments. Anonymous callers may do nothing.
+func DefaultPolicy() domain.Policy {
+	readers := []domain.Role{domain.RoleViewer, domain.RoleTrader, domain.RoleOps, domain.RoleAdmin}
+	traders := []domain.Role{domain.RoleTrader, domain.RoleAdmin}
+	cancellers := []domain.Role{domain.RoleTrader, domain.RoleOps, domain.RoleAdmin}
+	operators := []domain.Role{domain.RoleOps, domain.RoleAdmin}
+
+	return domain.Policy{
+		Grants: map[domain.Operation][]domain.Role{
+			domain.OperationGetOrder:          readers,
+			domain.OperationListOrders:        readers,
+			domain.OperationListOrderFills:    readers,
+			domain.OperationWatchOrders:       readers,
+			domain.OperationGetMarketSession:  readers,
+			domain.OperationGetAuctionState:   readers,
+			domain.OperationListInstruments:   readers,
+			domain.OperationGetQuote:          readers,
+			domain.OperationGetCandles:        readers,
+			domain.OperationSubscribeQuotes:   readers,
+			domain.OperationStreamOrderBook:   readers,
+			domain.OperationCreateOrder:       traders,
+			domain.OperationBatchCreateOrders: traders,
+			domain.OperationAmendOrder:        traders,
+			domain.OperationCancelOrder:       cancellers,
+			domain.OperationMassCancel:        cancellers,
+			domain.OperationHaltSymbol:        operators,
+			domain.OperationResumeSymbol:      operators,
+			domain.OperationUpsertInstrument:  operators,
+			domain.OperationDeleteInstrument:  operators,
+		},
+		AllAccounts: operators,
+	}
+}
+
+// authorizedStockOrderService checks the policy before every operation and
+// hides orders of other accounts from callers limited to their own
+type authorizedStockOrderService struct {
+	next   port.StockOrderService
+	policy *domain.Policy
+}
+
+// NewAuthorizedStockOrderService wraps next so that every operation is
+// authorized against policy
+func NewAuthorizedStockOrderService(next port.StockOrderService, policy domain.Policy) (port.StockOrderService, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &authorizedStockOrderService{next: next, policy: &policy}, nil
+}
+
+func (s *authorizedStockOrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.StockOrder, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationCreateOrder); err != nil {
+		return nil, err
+	}
+	return s.next.CreateOrder(ctx, req)
+}
+
+func (s *authorizedStockOrderService) GetOrder(ctx context.Context, orderID string) (*domain.StockOrder, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationGetOrder); err != nil {
+		return nil, err
+	}
+	return s.ownOrder(ctx, orderID)
+}
+
+func (s *authorizedStockOrderService) ListOrders(ctx context.Context, query domain.OrderQuery) (*domain.OrderPage, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationListOrders); err != nil {
+		return nil, err
+	}
+	if query.IncludeFills {
+		if err := s.policy.Authorize(ctx, domain.OperationListOrderFills); err != nil {
+			return nil, err
+		}
+	}
+	if !s.policy.CanAccessAllAccounts(ctx) {
+		accountID, err := ownAccount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		query.AccountID = accountID
+	}
+	return s.next.ListOrders(ctx, query)
+}
+
+func (s *authorizedStockOrderService) ListOrderFills(ctx context.Context, orderID string) ([]domain.Trade, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationListOrderFills); err != nil {
+		return nil, err
+	}
+	if _, err := s.ownOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.next.ListOrderFills(ctx, orderID)
+}
+
+func (s *authorizedStockOrderService) CancelOrder(ctx context.Context, orderID string) error {
+	if err := s.policy.Authorize(ctx, domain.OperationCancelOrder); err != nil {
+		return err
+	}
+	if _, err := s.ownOrder(ctx, orderID); err != nil {
+		return err
+	}
+	return s.next.CancelOrder(ctx, orderID)
+}
+
+func (s *authorizedStockOrderService) BatchCreateOrders(ctx context.Context, req domain.BatchCreateOrdersRequest) ([]domain.BatchOrderResult, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationBatchCreateOrders); err != nil {
+		return nil, err
+	}
+	return s.next.BatchCreateOrders(ctx, req)
+}
+
+func (s *authorizedStockOrderService) MassCancel(ctx context.Context, req domain.MassCancelRequest) ([]domain.CancelResult, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationMassCancel); err != nil {
+		return nil, err
+	}
+	otherAccount := req.AllAccounts || req.AccountID != "" && req.AccountID != domain.AccountIDFromContext(ctx)
+	if otherAccount && !s.policy.CanAccessAllAccounts(ctx) {
+		return nil, domain.NewPermissionDeniedError(domain.OperationMassCancel)
+	}
+	return s.next.MassCancel(ctx, req)
+}
+
+func (s *authorizedStockOrderService) AmendOrder(ctx context.Context, orderID string, req domain.AmendOrderRequest) (*domain.StockOrder, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationAmendOrder); err != nil {
+		return nil, err
+	}
+	if _, err := s.ownOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.next.AmendOrder(ctx, orderID, req)
+}
+
+func (s *authorizedStockOrderService) GetMarketSession(ctx context.Context, symbol string) (*domain.MarketSession, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationGetMarketSession); err != nil {
+		return nil, err
+	}
+	return s.next.GetMarketSession(ctx, symbol)
+}
+
+func (s *authorizedStockOrderService) GetAuctionState(ctx context.Context, symbol string) (*domain.AuctionState, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationGetAuctionState); err != nil {
+		return nil, err
+	}
+	return s.next.GetAuctionState(ctx, symbol)
+}
+
+func (s *authorizedStockOrderService) WatchOrders(ctx context.Context, filter domain.OrderEventFilter) (port.OrderEventSubscription, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationWatchOrders); err != nil {
+		return nil, err
+	}
+	return s.next.WatchOrders(ctx, filter)
+}
+
+func (s *authorizedStockOrderService) ListInstruments(ctx context.Context) ([]*domain.Instrument, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationListInstruments); err != nil {
+		return nil, err
+	}
+	return s.next.ListInstruments(ctx)
+}
+
+func (s *authorizedStockOrderService) UpsertInstrument(ctx context.Context, symbol string, req domain.UpsertInstrumentRequest) (*domain.Instrument, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationUpsertInstrument); err != nil {
+		return nil, err
+	}
+	return s.next.UpsertInstrument(ctx, symbol, req)
+}
+
+func (s *authorizedStockOrderService) DeleteInstrument(ctx context.Context, symbol string) error {
+	if err := s.policy.Authorize(ctx, domain.OperationDeleteInstrument); err != nil {
+		return err
+	}
+	return s.next.DeleteInstrument(ctx, symbol)
+}
+
+func (s *authorizedStockOrderService) HaltSymbol(ctx context.Context, symbol string, req domain.HaltSymbolRequest) (*domain.Instrument, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationHaltSymbol); err != nil {
+		return nil, err
+	}
+	return s.next.HaltSymbol(ctx, symbol, req)
+}
+
+func (s *authorizedStockOrderService) ResumeSymbol(ctx context.Context, symbol string) (*domain.Instrument, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationResumeSymbol); err != nil {
+		return nil, err
+	}
+	return s.next.ResumeSymbol(ctx, symbol)
+}
+
+// ownOrder returns the order if the caller may act on it. Orders of other
+// accounts are reported as not found so their existence is not revealed.
+func (s *authorizedStockOrderService) ownOrder(ctx context.Context, orderID string) (*domain.StockOrder, error) {
+	if s.policy.CanAccessAllAccounts(ctx) {
+		return s.next.GetOrder(ctx, orderID)
+	}
+
+	accountID, err := ownAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.next.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.AccountID != accountID {
+		return nil, domain.NewNotFoundError("order", orderID)
+	}
+	return order, nil
+}
+
+// ownAccount returns the account of a caller limited to its own orders. An
+// empty account ID would match every account, or the orders of every other
+// caller without one, so a caller without an account is rejected.
+func ownAccount(ctx context.Context) (string, error) {
+	accountID := domain.AccountIDFromContext(ctx)
+	if accountID == "" {
+		return "", domain.NewUnauthenticatedError("an account is required to access orders")
+	}
+	return accountID, nil
+}
+
+// authorizedMarketDataService checks the policy before every market data operation
+type authorizedMarketDataService struct {
+	next   port.MarketDataService
+	policy *domain.Policy
+}
+
+// NewAuthorizedMarketDataService wraps next so that every operation is
+// authorized against policy
+func NewAuthorizedMarketDataService(next port.MarketDataService, policy domain.Policy) (port.MarketDataService, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &authorizedMarketDataService{next: next, policy: &policy}, nil
+}
+
+func (s *authorizedMarketDataService) GetQuote(ctx context.Context, symbol string) (*domain.SymbolQuote, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationGetQuote); err != nil {
+		return nil, err
+	}
+	return s.next.GetQuote(ctx, symbol)
+}
+
+func (s *authorizedMarketDataService) GetCandles(ctx context.Context, query domain.CandleQuery) ([]*domain.Candle, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationGetCandles); err != nil {
+		return nil, err
+	}
+	return s.next.GetCandles(ctx, query)
+}
+
+func (s *authorizedMarketDataService) SubscribeQuotes(ctx context.Context, symbols []string) (<-chan domain.SymbolQuote, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationSubscribeQuotes); err != nil {
+		return nil, err
+	}
+	return s.next.SubscribeQuotes(ctx, symbols)
+}
+
+func (s *authorizedMarketDataService) StreamOrderBook(ctx context.Context, symbol string, depth int) (<-chan domain.OrderBookUpdate, error) {
+	if err := s.policy.Authorize(ctx, domain.OperationStreamOrderBook); err != nil {
+		return nil, err
+	}
+	return s.next.StreamOrderBook(ctx, symbol, depth)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+func (s *stockOrderService) ListInstruments(ctx context.Context) ([]*domain.Instrument, error) {
+	if s.instruments == nil {
+		return []*domain.Instrument{}, nil
+	}
+
+	return s.instruments.List(ctx)
+}
+
+func (s *stockOrderService) UpsertInstrument(ctx context.Context, symbol string, req domain.UpsertInstrumentRequest) (*domain.Instrument, error) {
+	if s.instruments == nil {
+		return nil, domain.NewUnavailableError(nil, "instruments are not configured")
+	}
+	symbol = domain.NormalizeSymbol(symbol)
+	if symbol == "" {
+		return nil, domain.NewValidationError("symbol", "symbol is required")
+	}
+	if err := s.requests.Struct(req); err != nil {
+		return nil, err
+	}
+
+	// Reference data changes keep the halt state
+	instrument, err := s.instruments.Get(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if instrument == nil {
+		instrument = &domain.Instrument{Symbol: symbol}
+	}
+	instrument.Name = req.Name
+	instrument.LotSize = req.LotSize
+	instrument.TickSize = req.TickSize
+	instrument.UpdatedAt = time.Now()
+
+	if err := s.instruments.Upsert(ctx, instrument); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Instrument updated", "component", "UpsertInstrument", "symbol", symbol, "lot_size", req.LotSize, "tick_size", req.TickSize)
+	return instrument, nil
+}
+
+func (s *stockOrderService) DeleteInstrument(ctx context.Context, symbol string) error {
+	if s.instruments == nil {
+		return domain.NewUnavailableError(nil, "instruments are not configured")
+	}
+
+	symbol = domain.NormalizeSymbol(symbol)
+	if err := s.instruments.Delete(ctx, symbol); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Instrument deleted", "component", "DeleteInstrument", "symbol", symbol)
+	return nil
+}
+
+// HaltSymbol stops trading in symbol, registering it first if needed.
+// Resting orders stay on the book and may still be cancelled.
+func (s *stockOrderService) HaltSymbol(ctx context.Context, symbol string, req domain.HaltSymbolRequest) (*domain.Instrument, error) {
+	if err := s.requests.Struct(req); err != nil {
+		return nil, err
+	}
+
+	symbol = domain.NormalizeSymbol(symbol)
+	instrument, err := s.setHalted(ctx, symbol, true, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Instrument halted", "component", "HaltSymbol", "symbol", symbol, "reason", req.Reason)
+	return instrument, nil
+}
+
+func (s *stockOrderService) ResumeSymbol(ctx context.Context, symbol string) (*domain.Instrument, error) {
+	symbol = domain.NormalizeSymbol(symbol)
+	instrument, err := s.setHalted(ctx, symbol, false, "")
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Instrument resumed", "component", "ResumeSymbol", "symbol", symbol)
+	return instrument, nil
+}
+
+func (s *stockOrderService) setHalted(ctx context.Context, symbol string, halted bool, reason string) (*domain.Instrument, error) {
+	if s.instruments == nil {
+		return nil, domain.NewUnavailableError(nil, "instruments are not configured")
+	}
+	if symbol == "" {
+		return nil, domain.NewValidationError("symbol", "symbol is required")
+	}
+
+	instrument, err := s.instruments.Get(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if instrument == nil {
+		if !halted {
+			return nil, domain.NewNotFoundError("instrument", symbol)
+		}
+		instrument = &domain.Instrument{Symbol: symbol}
+	}
+	instrument.Halted = halted
+	instrument.HaltReason = reason
+	instrument.UpdatedAt = time.Now()
+
+	if err := s.instruments.Upsert(ctx, instrument); err != nil {
+		return nil, err
+	}
+	return instrument, nil
+}
+
+// checkInstrument rejects orders for halted symbols and orders that do not
+// fit the lot and tick size of their instrument. Once any instrument is
+// registered, orders for unregistered symbols are rejected too.
+func (s *stockOrderService) checkInstrument(ctx context.Context, symbol string, quantity int, price float64) error {
+	if s.instruments == nil {
+		return nil
+	}
+
+	symbol = domain.NormalizeSymbol(symbol)
+	instrument, err := s.instruments.Get(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	if instrument == nil {
+		registered, err := s.instruments.List(ctx)
+		if err != nil || len(registered) == 0 {
+			return err
+		}
+		return domain.NewValidationError("symbol", "symbol %s is not a registered instrument", symbol)
+	}
+
+	if instrument.Halted {
+		if instrument.HaltReason != "" {
+			return domain.NewInvalidStateError("trading in %s is halted: %s", symbol, instrument.HaltReason)
+		}
+		return domain.NewInvalidStateError("trading in %s is halted", symbol)
+	}
+	if instrument.LotSize > 0 && quantity%instrument.LotSize != 0 {
+		return domain.NewValidationError("quantity", "quantity must be a multiple of the lot size %d", instrument.LotSize)
+	}
+	if instrument.TickSize > 0 && price > 0 {
+		ticks := price / instrument.TickSize
+		if math.Abs(ticks-math.Round(ticks)) > 1e-9*math.Max(1, ticks) {
+			return domain.NewValidationError("price", "price must be a multiple of the tick size %g", instrument.TickSize)
+		}
+	}
+	return nil
+}
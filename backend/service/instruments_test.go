@@ -0,0 +1,41 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+func TestInstrumentsUseNormalizedSymbols(t *testing.T) {
+	orders := newTestOrderService(t)
+	ctx := domain.ContextWithAccountID(context.Background(), "acct-1")
+
+	if _, err := orders.UpsertInstrument(ctx, " aapl", domain.UpsertInstrumentRequest{LotSize: 1, TickSize: 0.01}); err != nil {
+		t.Fatalf("failed to register instrument: %v", err)
+	}
+	if _, err := orders.HaltSymbol(ctx, "Aapl", domain.HaltSymbolRequest{}); err != nil {
+		t.Fatalf("failed to halt symbol: %v", err)
+	}
+	instruments, err := orders.ListInstruments(ctx)
+	if err != nil {
+		t.Fatalf("failed to list instruments: %v", err)
+	}
+	if len(instruments) != 1 || instruments[0].Symbol != "AAPL" || !instruments[0].Halted {
+		t.Fatalf("instruments = %+v, want one halted AAPL", instruments)
+	}
+
+	create := func(symbol string) error {
+		_, err := orders.CreateOrder(ctx, domain.CreateOrderRequest{
+			Symbol: symbol, OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100,
+		})
+		return err
+	}
+	if err := create("aapl"); !errors.Is(err, domain.ErrInvalidState) {
+		t.Errorf("order for halted aapl error = %v, want invalid state", err)
+	}
+	if err := create("MSFT"); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("order for unregistered MSFT error = %v, want validation", err)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// loadSheddingStockOrderService admits operations through a concurrency
+// limiter, so that a slow database makes requests fail fast instead of
+// piling up. Cancels and halts go first and new orders last.
+type loadSheddingStockOrderService struct {
+	next    port.StockOrderService
+	limiter port.ConcurrencyLimiter
+}
+
+// NewLoadSheddingStockOrderService wraps next so that every operation but
+// WatchOrders, which holds its stream open, takes a slot of limiter
+func NewLoadSheddingStockOrderService(next port.StockOrderService, limiter port.ConcurrencyLimiter) port.StockOrderService {
+	return &loadSheddingStockOrderService{next: next, limiter: limiter}
+}
+
+// admit runs fn once limiter grants a slot at priority. The slot is released
+// with the error of fn even if fn panics.
+func admit[T any](ctx context.Context, limiter port.ConcurrencyLimiter, priority domain.Priority, fn func() (T, error)) (result T, err error) {
+	release, err := limiter.Acquire(ctx, priority)
+	if err != nil {
+		return result, err
+	}
+	defer func() { release(err) }()
+	return fn()
+}
+
+// admitErr is admit for operations without a result
+func admitErr(ctx context.Context, limiter port.ConcurrencyLimiter, priority domain.Priority, fn func() error) error {
+	_, err := admit(ctx, limiter, priority, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+func (s *loadSheddingStockOrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.StockOrder, error) {
+	return admit(ctx, s.limiter, domain.PriorityLow, func() (*domain.StockOrder, error) {
+		return s.next.CreateOrder(ctx, req)
+	})
+}
+
+func (s *loadSheddingStockOrderService) GetOrder(ctx context.Context, orderID string) (*domain.StockOrder, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() (*domain.StockOrder, error) {
+		return s.next.GetOrder(ctx, orderID)
+	})
+}
+
+func (s *loadSheddingStockOrderService) ListOrders(ctx context.Context, query domain.OrderQuery) (*domain.OrderPage, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() (*domain.OrderPage, error) {
+		return s.next.ListOrders(ctx, query)
+	})
+}
+
+func (s *loadSheddingStockOrderService) ListOrderFills(ctx context.Context, orderID string) ([]domain.Trade, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() ([]domain.Trade, error) {
+		return s.next.ListOrderFills(ctx, orderID)
+	})
+}
+
+func (s *loadSheddingStockOrderService) CancelOrder(ctx context.Context, orderID string) error {
+	return admitErr(ctx, s.limiter, domain.PriorityHigh, func() error {
+		return s.next.CancelOrder(ctx, orderID)
+	})
+}
+
+func (s *loadSheddingStockOrderService) BatchCreateOrders(ctx context.Context, req domain.BatchCreateOrdersRequest) ([]domain.BatchOrderResult, error) {
+	return admit(ctx, s.limiter, domain.PriorityLow, func() ([]domain.BatchOrderResult, error) {
+		return s.next.BatchCreateOrders(ctx, req)
+	})
+}
+
+func (s *loadSheddingStockOrderService) MassCancel(ctx context.Context, req domain.MassCancelRequest) ([]domain.CancelResult, error) {
+	return admit(ctx, s.limiter, domain.PriorityHigh, func() ([]domain.CancelResult, error) {
+		return s.next.MassCancel(ctx, req)
+	})
+}
+
+func (s *loadSheddingStockOrderService) AmendOrder(ctx context.Context, orderID string, req domain.AmendOrderRequest) (*domain.StockOrder, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() (*domain.StockOrder, error) {
+		return s.next.AmendOrder(ctx, orderID, req)
+	})
+}
+
+func (s *loadSheddingStockOrderService) GetMarketSession(ctx context.Context, symbol string) (*domain.MarketSession, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() (*domain.MarketSession, error) {
+		return s.next.GetMarketSession(ctx, symbol)
+	})
+}
+
+func (s *loadSheddingStockOrderService) GetAuctionState(ctx context.Context, symbol string) (*domain.AuctionState, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() (*domain.AuctionState, error) {
+		return s.next.GetAuctionState(ctx, symbol)
+	})
+}
+
+func (s *loadSheddingStockOrderService) WatchOrders(ctx context.Context, filter domain.OrderEventFilter) (port.OrderEventSubscription, error) {
+	return s.next.WatchOrders(ctx, filter)
+}
+
+func (s *loadSheddingStockOrderService) ListInstruments(ctx context.Context) ([]*domain.Instrument, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() ([]*domain.Instrument, error) {
+		return s.next.ListInstruments(ctx)
+	})
+}
+
+func (s *loadSheddingStockOrderService) UpsertInstrument(ctx context.Context, symbol string, req domain.UpsertInstrumentRequest) (*domain.Instrument, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() (*domain.Instrument, error) {
+		return s.next.UpsertInstrument(ctx, symbol, req)
+	})
+}
+
+func (s *loadSheddingStockOrderService) DeleteInstrument(ctx context.Context, symbol string) error {
+	return admitErr(ctx, s.limiter, domain.PriorityNormal, func() error {
+		return s.next.DeleteInstrument(ctx, symbol)
+	})
+}
+
+func (s *loadSheddingStockOrderService) HaltSymbol(ctx context.Context, symbol string, req domain.HaltSymbolRequest) (*domain.Instrument, error) {
+	return admit(ctx, s.limiter, domain.PriorityHigh, func() (*domain.Instrument, error) {
+		return s.next.HaltSymbol(ctx, symbol, req)
+	})
+}
+
+func (s *loadSheddingStockOrderService) ResumeSymbol(ctx context.Context, symbol string) (*domain.Instrument, error) {
+	return admit(ctx, s.limiter, domain.PriorityNormal, func() (*domain.Instrument, error) {
+		return s.next.ResumeSymbol(ctx, symbol)
+	})
+}
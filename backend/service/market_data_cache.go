@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+const (
+	defaultQuoteDepth  = 10
+	defaultCandleLimit = 100
+	maxCandleLimit     = 1000
+	candleFlushPeriod  = 5 * time.Second
+)
+
+type candleKey struct {
+	symbol   string
+	interval domain.CandleInterval
+}
+
+// MarketDataCache keeps the latest trade, top of book and OHLCV candles per
+// symbol. It is fed by trades from the matching engine and by an optional
+// external market data feed, and periodically persists candles.
+type MarketDataCache struct {
+	engine  *MatchingEngine
+	candles port.CandleRepository
+
+	mu          sync.Mutex
+	lastTrades  map[string]domain.Trade
+	quotes      map[string]domain.Quote
+	current     map[candleKey]*domain.Candle
+	dirty       map[candleKey]bool
+	completed   []*domain.Candle
+	lastUpdates map[string]time.Time
+	subscribers map[*quoteSubscription]struct{}
+	bookStreams map[*bookSubscription]struct{}
+	closed      chan struct{}
+	closeOnce   sync.Once
+}
+
+// quoteSubscription collects the symbols that changed since the subscriber
+// last read, so updates for the same symbol are conflated
+type quoteSubscription struct {
+	symbols map[string]bool
+	changed map[string]bool
+	notify  chan struct{}
+}
+
+// bookSubscription is notified whenever the order book of its symbol changes;
+// like quotes, changes that arrive while the subscriber is busy are conflated
+type bookSubscription struct {
+	symbol string
+	notify chan struct{}
+}
+
+// NewMarketDataCache creates a cache that follows trades from engine. Either
+// dependency may be nil; without a candle repository candles are kept in memory only.
+func NewMarketDataCache(engine *MatchingEngine, candles port.CandleRepository) *MarketDataCache {
+	c := &MarketDataCache{
+		engine:      engine,
+		candles:     candles,
+		lastTrades:  map[string]domain.Trade{},
+		quotes:      map[string]domain.Quote{},
+		current:     map[candleKey]*domain.Candle{},
+		dirty:       map[candleKey]bool{},
+		lastUpdates: map[string]time.Time{},
+		subscribers: map[*quoteSubscription]struct{}{},
+		bookStreams: map[*bookSubscription]struct{}{},
+		closed:      make(chan struct{}),
+	}
+	if engine != nil {
+		engine.addTradeListener(c.recordTrade)
+		engine.addBookListener(c.recordBookChange)
+	}
+	return c
+}
+
+// Run consumes the external feed, if any, and flushes candles until ctx is
+// cancelled. Pending candles are flushed once more before Run returns.
+func (c *MarketDataCache) Run(ctx context.Context, feed port.MarketDataFeed) {
+	var events <-chan domain.MarketDataEvent
+	if feed != nil {
+		subscription, err := feed.Subscribe(ctx)
+		if err != nil {
+			slog.Error("Failed to subscribe to market data feed", "component", "MarketDataCache", "error", err)
+		} else {
+			events = subscription
+		}
+	}
+
+	ticker := time.NewTicker(candleFlushPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			c.flush(flushCtx)
+			cancel()
+			slog.Info("Stopped", "component", "MarketDataCache")
+			return
+		case event, ok := <-events:
+			if !ok {
+				slog.Info("Market data feed ended", "component", "MarketDataCache")
+				events = nil
+				continue
+			}
+			c.recordEvent(event)
+		case <-ticker.C:
+			c.flush(ctx)
+		}
+	}
+}
+
+func (c *MarketDataCache) recordEvent(event domain.MarketDataEvent) {
+	switch event.Type {
+	case domain.MarketDataEventTrade:
+		if event.Trade != nil {
+			c.recordTrade(*event.Trade)
+		}
+	case domain.MarketDataEventQuote:
+		if event.Quote != nil {
+			c.mu.Lock()
+			c.quotes[event.Symbol] = *event.Quote
+			c.lastUpdates[event.Symbol] = event.Timestamp
+			c.notifyLocked(event.Symbol)
+			c.mu.Unlock()
+		}
+	}
+}
+
+// recordTrade updates the last trade and rolls every candle interval
+func (c *MarketDataCache) recordTrade(trade domain.Trade) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if last, ok := c.lastTrades[trade.Symbol]; !ok || !trade.ExecutedAt.Before(last.ExecutedAt) {
+		c.lastTrades[trade.Symbol] = trade
+		c.lastUpdates[trade.Symbol] = trade.ExecutedAt
+		c.notifyLocked(trade.Symbol)
+	}
+
+	for _, interval := range domain.CandleIntervals {
+		key := candleKey{symbol: trade.Symbol, interval: interval}
+		openTime := trade.ExecutedAt.UTC().Truncate(interval.Duration())
+
+		candle, ok := c.current[key]
+		switch {
+		case ok && candle.OpenTime.Equal(openTime):
+			candle.High = max(candle.High, trade.Price)
+			candle.Low = min(candle.Low, trade.Price)
+			candle.Close = trade.Price
+			candle.Volume += int64(trade.Quantity)
+			candle.TradeCount++
+		case ok && openTime.Before(candle.OpenTime):
+			// Late trades for a closed candle are ignored
+			continue
+		default:
+			if ok {
+				c.completed = append(c.completed, candle)
+			}
+			c.current[key] = &domain.Candle{
+				Symbol:     trade.Symbol,
+				Interval:   interval,
+				OpenTime:   openTime,
+				Open:       trade.Price,
+				High:       trade.Price,
+				Low:        trade.Price,
+				Close:      trade.Price,
+				Volume:     int64(trade.Quantity),
+				TradeCount: 1,
+			}
+		}
+		c.dirty[key] = true
+	}
+}
+
+// recordBookChange wakes the quote and order book subscribers of symbol
+func (c *MarketDataCache) recordBookChange(symbol string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.notifyLocked(symbol)
+	for sub := range c.bookStreams {
+		if sub.symbol != symbol {
+			continue
+		}
+		select {
+		case sub.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// CloseSubscriptions ends every quote and order book subscription so that
+// streaming handlers can return during graceful shutdown
+func (c *MarketDataCache) CloseSubscriptions() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
+}
+
+// flush persists completed candles and the current state of open ones
+func (c *MarketDataCache) flush(ctx context.Context) {
+	if c.candles == nil {
+		return
+	}
+
+	c.mu.Lock()
+	pending := c.completed
+	c.completed = nil
+	for key := range c.dirty {
+		candle := *c.current[key]
+		pending = append(pending, &candle)
+	}
+	c.dirty = map[candleKey]bool{}
+	c.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	if err := c.candles.Upsert(ctx, pending); err != nil {
+		slog.Error("Failed to persist candles", "component", "MarketDataCache", "candles", len(pending), "error", err)
+	}
+}
+
+func (c *MarketDataCache) SubscribeQuotes(ctx context.Context, symbols []string) (<-chan domain.SymbolQuote, error) {
+	if len(symbols) == 0 {
+		return nil, domain.NewValidationError("symbols", "at least one symbol is required")
+	}
+
+	sub := &quoteSubscription{
+		symbols: map[string]bool{},
+		changed: map[string]bool{},
+		notify:  make(chan struct{}, 1),
+	}
+	for _, symbol := range symbols {
+		symbol = domain.NormalizeSymbol(symbol)
+		sub.symbols[symbol] = true
+		// Start every subscriber off with the current quote
+		sub.changed[symbol] = true
+	}
+	sub.notify <- struct{}{}
+
+	c.mu.Lock()
+	c.subscribers[sub] = struct{}{}
+	c.mu.Unlock()
+
+	quotes := make(chan domain.SymbolQuote)
+	go func() {
+		defer close(quotes)
+		defer func() {
+			c.mu.Lock()
+			delete(c.subscribers, sub)
+			c.mu.Unlock()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.closed:
+				return
+			case <-sub.notify:
+			}
+
+			c.mu.Lock()
+			changed := sub.changed
+			sub.changed = map[string]bool{}
+			c.mu.Unlock()
+
+			for symbol := range changed {
+				quote, err := c.GetQuote(ctx, symbol)
+				if err != nil {
+					continue
+				}
+				select {
+				case quotes <- *quote:
+				case <-ctx.Done():
+					return
+				case <-c.closed:
+					return
+				}
+			}
+		}
+	}()
+
+	return quotes, nil
+}
+
+func (c *MarketDataCache) StreamOrderBook(ctx context.Context, symbol string, depth int) (<-chan domain.OrderBookUpdate, error) {
+	if c.engine == nil {
+		return nil, domain.NewUnavailableError(nil, "order book is not available")
+	}
+	symbol = domain.NormalizeSymbol(symbol)
+	if symbol == "" {
+		return nil, domain.NewValidationError("symbol", "symbol is required")
+	}
+	if depth < 0 {
+		return nil, domain.NewValidationError("depth", "depth must not be negative")
+	}
+
+	sub := &bookSubscription{
+		symbol: symbol,
+		notify: make(chan struct{}, 1),
+	}
+	// The first wake-up sends the snapshot
+	sub.notify <- struct{}{}
+
+	c.mu.Lock()
+	c.bookStreams[sub] = struct{}{}
+	c.mu.Unlock()
+
+	updates := make(chan domain.OrderBookUpdate)
+	go func() {
+		defer close(updates)
+		defer func() {
+			c.mu.Lock()
+			delete(c.bookStreams, sub)
+			c.mu.Unlock()
+		}()
+
+		var bids, asks []domain.PriceLevel
+		snapshot := true
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.closed:
+				return
+			case <-sub.notify:
+			}
+
+			currentBids, currentAsks, sequence := c.engine.depth(sub.symbol, depth)
+			update := domain.OrderBookUpdate{
+				Symbol:    sub.symbol,
+				Sequence:  sequence,
+				Snapshot:  snapshot,
+				Bids:      currentBids,
+				Asks:      currentAsks,
+				UpdatedAt: time.Now(),
+			}
+			if !snapshot {
+				update.Bids = diffLevels(bids, currentBids)
+				update.Asks = diffLevels(asks, currentAsks)
+				// Changes beyond the requested depth are not visible to this stream
+				if len(update.Bids) == 0 && len(update.Asks) == 0 {
+					continue
+				}
+			}
+
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			case <-c.closed:
+				return
+			}
+			bids, asks, snapshot = currentBids, currentAsks, false
+		}
+	}()
+
+	return updates, nil
+}
+
+// diffLevels returns the levels of current that differ from previous, followed
+// by zero quantity levels for prices that are no longer present
+func diffLevels(previous, current []domain.PriceLevel) []domain.PriceLevel {
+	before := make(map[float64]domain.PriceLevel, len(previous))
+	for _, level := range previous {
+		before[level.Price] = level
+	}
+
+	changed := []domain.PriceLevel{}
+	for _, level := range current {
+		if old, ok := before[level.Price]; !ok || old != level {
+			changed = append(changed, level)
+		}
+		delete(before, level.Price)
+	}
+	for _, level := range previous {
+		if _, removed := before[level.Price]; removed {
+			changed = append(changed, domain.PriceLevel{Price: level.Price})
+		}
+	}
+	return changed
+}
+
+// notifyLocked marks symbol as changed for its subscribers; c.mu must be held
+func (c *MarketDataCache) notifyLocked(symbol string) {
+	for sub := range c.subscribers {
+		if !sub.symbols[symbol] {
+			continue
+		}
+		sub.changed[symbol] = true
+		select {
+		case sub.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *MarketDataCache) GetQuote(ctx context.Context, symbol string) (*domain.SymbolQuote, error) {
+	symbol = domain.NormalizeSymbol(symbol)
+	quote := &domain.SymbolQuote{
+		Symbol: symbol,
+		Bids:   []domain.PriceLevel{},
+		Asks:   []domain.PriceLevel{},
+	}
+	if c.engine != nil {
+		quote.Bids, quote.Asks, _ = c.engine.depth(symbol, defaultQuoteDepth)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if trade, ok := c.lastTrades[symbol]; ok {
+		executedAt := trade.ExecutedAt
+		quote.LastPrice = trade.Price
+		quote.LastQuantity = trade.Quantity
+		quote.LastTradeAt = &executedAt
+	}
+
+	if len(quote.Bids) > 0 {
+		quote.BidPrice, quote.BidSize = quote.Bids[0].Price, quote.Bids[0].Quantity
+	}
+	if len(quote.Asks) > 0 {
+		quote.AskPrice, quote.AskSize = quote.Asks[0].Price, quote.Asks[0].Quantity
+	}
+
+	// Prefer the external quote wherever it improves on the internal book
+	if external, ok := c.quotes[symbol]; ok {
+		if external.BidPrice > 0 && external.BidPrice > quote.BidPrice {
+			quote.BidPrice, quote.BidSize = external.BidPrice, external.BidSize
+		}
+		if external.AskPrice > 0 && (quote.AskPrice == 0 || external.AskPrice < quote.AskPrice) {
+			quote.AskPrice, quote.AskSize = external.AskPrice, external.AskSize
+		}
+	}
+
+	quote.UpdatedAt = c.lastUpdates[symbol]
+	if quote.LastTradeAt == nil && quote.BidPrice == 0 && quote.AskPrice == 0 {
+		return nil, domain.NewNotFoundError("market data", symbol)
+	}
+
+	return quote, nil
+}
+
+func (c *MarketDataCache) GetCandles(ctx context.Context, query domain.CandleQuery) ([]*domain.Candle, error) {
+	query.Symbol = domain.NormalizeSymbol(query.Symbol)
+	if query.Interval == "" {
+		query.Interval = domain.CandleInterval1m
+	}
+	if query.Interval.Duration() == 0 {
+		return nil, domain.NewValidationError("interval", "unsupported candle interval: %s", query.Interval)
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultCandleLimit
+	}
+	if query.Limit > maxCandleLimit {
+		query.Limit = maxCandleLimit
+	}
+
+	candles := []*domain.Candle{}
+	if c.candles != nil {
+		stored, err := c.candles.List(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		candles = stored
+	}
+
+	// Overlay the open candle, which may not have been flushed yet
+	c.mu.Lock()
+	open, ok := c.current[candleKey{symbol: query.Symbol, interval: query.Interval}]
+	if ok && !open.OpenTime.Before(query.From) && (query.To.IsZero() || open.OpenTime.Before(query.To)) {
+		copied := *open
+		if last := len(candles) - 1; last >= 0 && candles[last].OpenTime.Equal(copied.OpenTime) {
+			candles[last] = &copied
+		} else {
+			candles = append(candles, &copied)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(candles) > query.Limit {
+		candles = candles[len(candles)-query.Limit:]
+	}
+
+	return candles, nil
+}
@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/adaptor"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+)
+
+func TestLowerCaseOrdersReachMarketData(t *testing.T) {
+	repo, err := adaptor.NewSQLiteRepository(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatalf("failed to open order repository: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	engine := service.NewMatchingEngine(repo, nil)
+	marketData := service.NewMarketDataCache(engine, nil)
+	orders := service.NewStockOrderService(repo, service.WithMatchingEngine(engine))
+	ctx := domain.ContextWithAccountID(context.Background(), "acct-1")
+
+	for _, req := range []domain.CreateOrderRequest{
+		{Symbol: "aapl", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100},
+		{Symbol: "aapl", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideSell, Quantity: 5, Price: 100},
+	} {
+		if _, err := orders.CreateOrder(ctx, req); err != nil {
+			t.Fatalf("failed to create %s order: %v", req.OrderSide, err)
+		}
+	}
+
+	quote, err := marketData.GetQuote(ctx, "AAPL")
+	if err != nil {
+		t.Fatalf("failed to get quote: %v", err)
+	}
+	if quote.LastPrice != 100 || quote.BidSize != 5 {
+		t.Errorf("quote last price %g, bid size %d, want 100 and 5", quote.LastPrice, quote.BidSize)
+	}
+
+	candles, err := marketData.GetCandles(ctx, domain.CandleQuery{Symbol: "AAPL"})
+	if err != nil {
+		t.Fatalf("failed to get candles: %v", err)
+	}
+	if len(candles) != 1 || candles[0].Volume != 5 {
+		t.Errorf("candles = %+v, want one candle with volume 5", candles)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	updates, err := marketData.StreamOrderBook(streamCtx, "AAPL", 5)
+	if err != nil {
+		t.Fatalf("failed to stream order book: %v", err)
+	}
+	if snapshot := <-updates; len(snapshot.Bids) != 1 || snapshot.Bids[0].Quantity != 5 {
+		t.Errorf("order book snapshot bids = %+v, want 5 at 100", snapshot.Bids)
+	}
+}
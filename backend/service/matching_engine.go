@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// MatchingEngine routes orders into per-symbol order books. Each book matches
+// continuously while the market is in continuous trading and collects orders
+// for a single-price uncross during the opening and closing call auctions.
+type MatchingEngine struct {
+	mu       sync.Mutex
+	repo     port.StockOrderRepository
+	calendar port.SessionCalendar
+	books    map[string]*orderBook
+	auctions map[string]*domain.AuctionState
+
+	// Listeners are called while the engine lock is held: tradeListeners for
+	// every execution, orderListeners for every persisted order change and
+	// bookListeners whenever the resting orders of a book change
+	tradeListeners []func(domain.Trade)
+	orderListeners []func(*domain.StockOrder)
+	bookListeners  []func(symbol string)
+}
+
+// NewMatchingEngine creates a matching engine. Without a calendar every book
+// is permanently in continuous trading.
+func NewMatchingEngine(repo port.StockOrderRepository, calendar port.SessionCalendar) *MatchingEngine {
+	return &MatchingEngine{
+		repo:     repo,
+		calendar: calendar,
+		books:    map[string]*orderBook{},
+		auctions: map[string]*domain.AuctionState{},
+	}
+}
+
+// Restore loads open limit orders from the repository back into the books
+func (e *MatchingEngine) Restore(ctx context.Context) error {
+	orders := []*domain.StockOrder{}
+	for _, status := range []domain.OrderStatus{domain.OrderStatusPending, domain.OrderStatusPartiallyFilled} {
+		// Oldest first, so orders regain their time priority
+		query := domain.OrderQuery{
+			OrderType: domain.OrderTypeLimit,
+			Status:    status,
+			Sort:      domain.OrderSortCreatedAtAsc,
+			Limit:     1000,
+		}
+		for {
+			page, err := e.repo.List(ctx, query)
+			if err != nil {
+				return err
+			}
+			orders = append(orders, page.Orders...)
+			if page.NextPageToken == "" {
+				break
+			}
+			query.PageToken = page.NextPageToken
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, order := range orders {
+		resting := *order
+		e.bookFor(order.Symbol).add(&resting)
+	}
+	restored := len(orders)
+
+	now := time.Now()
+	for _, book := range e.books {
+		e.syncPhase(ctx, book, now)
+	}
+
+	slog.InfoContext(ctx, "Restored open orders", "component", "MatchingEngine", "orders", restored, "books", len(e.books))
+	return nil
+}
+
+// Run follows session phase changes until ctx is cancelled, uncrossing
+// books when a call auction ends and refreshing indicative prices while
+// a call is running.
+func (e *MatchingEngine) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Stopped", "component", "MatchingEngine")
+			return
+		case now := <-ticker.C:
+			e.mu.Lock()
+			for _, book := range e.books {
+				e.syncPhase(ctx, book, now)
+				e.refreshIndicative(book, now)
+			}
+			e.mu.Unlock()
+		}
+	}
+}
+
+// submit stores newly created orders with store, then matches or queues
+// them. Both happen under the engine lock, so no cancel can find an order in
+// the repository before it has reached its book. The orders are updated in
+// place with any fills; a copy of each unfilled remainder rests in the book.
+func (e *MatchingEngine) submit(ctx context.Context, store func(context.Context) error, orders ...*domain.StockOrder) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := store(ctx); err != nil {
+		return err
+	}
+
+	// Counterparty fills must be stored even if the caller goes away
+	ctx = context.WithoutCancel(ctx)
+
+	now := time.Now()
+	for _, order := range orders {
+		book := e.bookFor(order.Symbol)
+		e.syncPhase(ctx, book, now)
+		e.place(ctx, book, order, now, nil)
+	}
+	return nil
+}
+
+// amend changes the quantity and price of a resting order and returns a copy
+// of the amended order, or nil when the order is not resting. Reducing the
+// quantity keeps the order's time priority; any other change re-enters the
+// order at the back of its price level, where it may execute immediately.
+func (e *MatchingEngine) amend(ctx context.Context, orderID, symbol string, quantity int, price float64) *domain.StockOrder {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+
+	book, ok := e.books[symbol]
+	if !ok {
+		return nil
+	}
+	order := book.find(orderID)
+	if order == nil {
+		return nil
+	}
+
+	now := time.Now()
+	e.syncPhase(ctx, book, now)
+
+	if price == order.Price && quantity <= order.Quantity {
+		order.Quantity = quantity
+		order.UpdatedAt = now
+		e.persist(ctx, []*domain.StockOrder{order})
+		e.refreshIndicative(book, now)
+		e.publishBookChange(book)
+		amended := *order
+		return &amended
+	}
+
+	book.remove(orderID)
+	order.Quantity = quantity
+	order.Price = price
+	order.UpdatedAt = now
+	e.place(ctx, book, order, now, []*domain.StockOrder{order})
+	amended := *order
+	return &amended
+}
+
+// place matches order against the book when the phase allows it and rests
+// the unfilled limit remainder. changed lists orders that must be persisted
+// even if nothing executes; the caller must hold e.mu.
+func (e *MatchingEngine) place(ctx context.Context, book *orderBook, order *domain.StockOrder, now time.Time, changed []*domain.StockOrder) {
+	bookChanged := len(changed) > 0
+	if book.phase.AllowsMatching() {
+		trades, touched := book.match(order, now)
+		for _, trade := range trades {
+			slog.InfoContext(ctx, "Traded", "component", "MatchingEngine", "symbol", trade.Symbol,
+				"quantity", trade.Quantity, "price", trade.Price, "buy_order_id", trade.BuyOrderID, "sell_order_id", trade.SellOrderID)
+		}
+		e.publishTrades(trades)
+		changed = append(changed, touched...)
+		if len(trades) > 0 {
+			changed = append(changed, order)
+			bookChanged = true
+		}
+	}
+
+	if order.RemainingQuantity() > 0 {
+		if order.OrderType == domain.OrderTypeMarket {
+			order.Status = domain.OrderStatusCancelled
+			order.Description = "unfilled market order quantity cancelled: insufficient liquidity"
+			order.UpdatedAt = now
+			changed = append(changed, order)
+		} else {
+			resting := *order
+			book.add(&resting)
+			bookChanged = true
+		}
+	}
+
+	e.persist(ctx, changed)
+	e.refreshIndicative(book, now)
+	if bookChanged {
+		e.publishBookChange(book)
+	}
+}
+
+// cancel removes an order from its book and returns the resting instance,
+// or nil when the order is not resting
+func (e *MatchingEngine) cancel(orderID, symbol string) *domain.StockOrder {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	book, ok := e.books[symbol]
+	if !ok {
+		return nil
+	}
+
+	order := book.remove(orderID)
+	if order != nil {
+		e.refreshIndicative(book, time.Now())
+		e.publishBookChange(book)
+	}
+	return order
+}
+
+// auctionState returns the indicative auction for symbol, or only the
+// current phase when no call is running
+func (e *MatchingEngine) auctionState(symbol string) *domain.AuctionState {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	if state, ok := e.auctions[symbol]; ok {
+		copied := *state
+		return &copied
+	}
+
+	return &domain.AuctionState{
+		Symbol:    symbol,
+		Phase:     e.phaseFor(symbol, now),
+		UpdatedAt: now,
+	}
+}
+
+// depth returns the aggregated price levels of symbol's book together with
+// the book sequence they reflect
+func (e *MatchingEngine) depth(symbol string, levels int) ([]domain.PriceLevel, []domain.PriceLevel, uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	book, ok := e.books[symbol]
+	if !ok {
+		return []domain.PriceLevel{}, []domain.PriceLevel{}, 0
+	}
+	bids, asks := book.levels(levels)
+	return bids, asks, book.sequence
+}
+
+func (e *MatchingEngine) addTradeListener(listener func(domain.Trade)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.tradeListeners = append(e.tradeListeners, listener)
+}
+
+func (e *MatchingEngine) addOrderListener(listener func(*domain.StockOrder)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.orderListeners = append(e.orderListeners, listener)
+}
+
+func (e *MatchingEngine) addBookListener(listener func(symbol string)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.bookListeners = append(e.bookListeners, listener)
+}
+
+func (e *MatchingEngine) publishTrades(trades []domain.Trade) {
+	for _, trade := range trades {
+		for _, listener := range e.tradeListeners {
+			listener(trade)
+		}
+	}
+}
+
+// publishBookChange advances the book sequence and notifies book listeners
+func (e *MatchingEngine) publishBookChange(book *orderBook) {
+	book.sequence++
+	for _, listener := range e.bookListeners {
+		listener(book.symbol)
+	}
+}
+
+func (e *MatchingEngine) bookFor(symbol string) *orderBook {
+	book, ok := e.books[symbol]
+	if !ok {
+		book = newOrderBook(symbol)
+		e.books[symbol] = book
+	}
+	return book
+}
+
+func (e *MatchingEngine) phaseFor(symbol string, now time.Time) domain.SessionPhase {
+	if e.calendar == nil {
+		return domain.SessionPhaseContinuous
+	}
+
+	session, err := e.calendar.Session(symbol, now)
+	if err != nil {
+		slog.Error("Failed to resolve session", "component", "MatchingEngine", "symbol", symbol, "error", err)
+		return domain.SessionPhaseClosed
+	}
+	return session.Phase
+}
+
+// syncPhase moves the book into the current session phase and uncrosses it
+// when a call auction has just ended
+func (e *MatchingEngine) syncPhase(ctx context.Context, book *orderBook, now time.Time) {
+	phase := e.phaseFor(book.symbol, now)
+	if phase == book.phase {
+		return
+	}
+
+	previous := book.phase
+	book.phase = phase
+	slog.InfoContext(ctx, "Phase changed", "component", "MatchingEngine", "symbol", book.symbol, "from", previous, "to", phase)
+
+	if previous.IsCallAuction() {
+		e.uncross(ctx, book, now)
+	}
+}
+
+func (e *MatchingEngine) uncross(ctx context.Context, book *orderBook, now time.Time) {
+	delete(e.auctions, book.symbol)
+
+	result, ok := book.equilibrium()
+	if !ok {
+		slog.InfoContext(ctx, "Auction ended without executable volume", "component", "MatchingEngine", "symbol", book.symbol)
+		return
+	}
+
+	trades, touched := book.uncross(result.price, now)
+	slog.InfoContext(ctx, "Auction uncrossed", "component", "MatchingEngine", "symbol", book.symbol,
+		"volume", result.volume, "price", result.price, "trades", len(trades))
+	e.publishTrades(trades)
+
+	e.persist(ctx, touched)
+	e.publishBookChange(book)
+}
+
+// refreshIndicative publishes the price the book would uncross at if the
+// running call auction ended now
+func (e *MatchingEngine) refreshIndicative(book *orderBook, now time.Time) {
+	if !book.phase.IsCallAuction() {
+		delete(e.auctions, book.symbol)
+		return
+	}
+
+	state := &domain.AuctionState{
+		Symbol:    book.symbol,
+		Phase:     book.phase,
+		UpdatedAt: now,
+	}
+	if result, ok := book.equilibrium(); ok {
+		state.IndicativePrice = result.price
+		state.IndicativeVolume = result.volume
+		state.ImbalanceSide = result.imbalanceSide
+		state.ImbalanceVolume = result.imbalance
+	}
+
+	previous, ok := e.auctions[book.symbol]
+	if !ok || previous.IndicativePrice != state.IndicativePrice || previous.IndicativeVolume != state.IndicativeVolume {
+		slog.Info("Indicative auction price", "component", "MatchingEngine", "symbol", book.symbol,
+			"price", state.IndicativePrice, "volume", state.IndicativeVolume, "imbalance", state.ImbalanceVolume, "imbalance_side", state.ImbalanceSide)
+	}
+	e.auctions[book.symbol] = state
+}
+
+// persist writes every order changed by an execution back to the repository
+func (e *MatchingEngine) persist(ctx context.Context, orders []*domain.StockOrder) {
+	seen := map[string]bool{}
+	for _, order := range orders {
+		if seen[order.ID] {
+			continue
+		}
+		seen[order.ID] = true
+
+		if err := e.repo.Update(ctx, order); err != nil {
+			slog.ErrorContext(ctx, "Failed to persist execution", "component", "MatchingEngine", "order_id", order.ID, "error", err)
+			continue
+		}
+		for _, listener := range e.orderListeners {
+			listener(order)
+		}
+	}
+}
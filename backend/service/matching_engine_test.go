@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// recordingRepository keeps the last stored state of every order
+type recordingRepository struct {
+	port.StockOrderRepository
+	mu     sync.Mutex
+	orders map[string]domain.StockOrder
+}
+
+func newRecordingRepository() *recordingRepository {
+	return &recordingRepository{orders: map[string]domain.StockOrder{}}
+}
+
+func (r *recordingRepository) Update(ctx context.Context, order *domain.StockOrder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orders[order.ID] = *order
+	return nil
+}
+
+func (r *recordingRepository) stored(id string) domain.StockOrder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.orders[id]
+}
+
+// phaseCalendar puts every symbol in one settable phase
+type phaseCalendar struct {
+	mu    sync.Mutex
+	phase domain.SessionPhase
+}
+
+func (c *phaseCalendar) Session(symbol string, at time.Time) (*domain.MarketSession, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &domain.MarketSession{Symbol: symbol, Phase: c.phase, AsOf: at}, nil
+}
+
+func (c *phaseCalendar) set(phase domain.SessionPhase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.phase = phase
+}
+
+func limitOrder(id string, side domain.OrderSide, quantity int, price float64) *domain.StockOrder {
+	return &domain.StockOrder{
+		ID: id, Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: side,
+		Quantity: quantity, Price: price, Status: domain.OrderStatusPending,
+	}
+}
+
+// newTestEngine returns an engine whose trades are collected in the returned slice
+func newTestEngine(calendar port.SessionCalendar) (*MatchingEngine, *recordingRepository, *[]domain.Trade) {
+	repo := newRecordingRepository()
+	engine := NewMatchingEngine(repo, calendar)
+	trades := &[]domain.Trade{}
+	engine.addTradeListener(func(trade domain.Trade) { *trades = append(*trades, trade) })
+	return engine, repo, trades
+}
+
+func submitOrders(t *testing.T, engine *MatchingEngine, orders ...*domain.StockOrder) {
+	t.Helper()
+	noop := func(context.Context) error { return nil }
+	if err := engine.submit(context.Background(), noop, orders...); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+}
+
+func TestMatchingEngineMatchesContinuously(t *testing.T) {
+	engine, repo, trades := newTestEngine(nil)
+
+	submitOrders(t, engine,
+		limitOrder("a1", domain.OrderSideSell, 5, 101),
+		limitOrder("a2", domain.OrderSideSell, 5, 100),
+	)
+	buy := limitOrder("b1", domain.OrderSideBuy, 8, 101)
+	submitOrders(t, engine, buy)
+
+	if got, want := fills(*trades), []string{"5@100 b1/a2", "3@101 b1/a1"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got trades %v, want %v", got, want)
+	}
+	if buy.Status != domain.OrderStatusFilled || repo.stored("b1").Status != domain.OrderStatusFilled {
+		t.Errorf("buy order is %s and stored as %s, want FILLED", buy.Status, repo.stored("b1").Status)
+	}
+	if stored := repo.stored("a1"); stored.Status != domain.OrderStatusPartiallyFilled || stored.FilledQuantity != 3 {
+		t.Errorf("resting sell is stored as %s with %d filled, want PARTIALLY_FILLED with 3", stored.Status, stored.FilledQuantity)
+	}
+
+	// A market order takes what is left and cancels its remainder
+	market := &domain.StockOrder{ID: "b2", Symbol: "AAPL", OrderType: domain.OrderTypeMarket, OrderSide: domain.OrderSideBuy, Quantity: 4, Status: domain.OrderStatusPending}
+	submitOrders(t, engine, market)
+	if market.Status != domain.OrderStatusCancelled || market.FilledQuantity != 2 {
+		t.Errorf("market order is %s with %d filled, want CANCELLED with 2", market.Status, market.FilledQuantity)
+	}
+	if bids, asks, _ := engine.depth("AAPL", 0); len(bids) != 0 || len(asks) != 0 {
+		t.Errorf("book is %v / %v, want empty", bids, asks)
+	}
+}
+
+func TestMatchingEngineUncrossesWhenTheCallEnds(t *testing.T) {
+	calendar := &phaseCalendar{phase: domain.SessionPhasePreOpen}
+	engine, repo, trades := newTestEngine(calendar)
+
+	// Crossing orders are collected rather than matched during the call
+	submitOrders(t, engine,
+		limitOrder("b1", domain.OrderSideBuy, 10, 101),
+		limitOrder("a1", domain.OrderSideSell, 8, 99),
+		limitOrder("b2", domain.OrderSideBuy, 5, 100),
+		limitOrder("a2", domain.OrderSideSell, 4, 100),
+	)
+	if len(*trades) != 0 {
+		t.Fatalf("got trades %v during the call", fills(*trades))
+	}
+	state := engine.auctionState("AAPL")
+	if state.Phase != domain.SessionPhasePreOpen || state.IndicativePrice != 100 || state.IndicativeVolume != 12 ||
+		state.ImbalanceSide != domain.OrderSideBuy || state.ImbalanceVolume != 3 {
+		t.Errorf("auction state = %+v, want 12 at 100 with 3 bought unmatched", state)
+	}
+
+	// The next order after the call ends triggers the uncross
+	calendar.set(domain.SessionPhaseContinuous)
+	submitOrders(t, engine, limitOrder("b3", domain.OrderSideBuy, 1, 90))
+
+	if got, want := fills(*trades), []string{"8@100 b1/a1", "2@100 b1/a2", "2@100 b2/a2"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got trades %v, want %v", got, want)
+	}
+	if stored := repo.stored("b2"); stored.Status != domain.OrderStatusPartiallyFilled || stored.FilledQuantity != 2 {
+		t.Errorf("b2 is stored as %s with %d filled, want PARTIALLY_FILLED with 2", stored.Status, stored.FilledQuantity)
+	}
+	if state := engine.auctionState("AAPL"); state.Phase != domain.SessionPhaseContinuous || state.IndicativeVolume != 0 {
+		t.Errorf("auction state after the call = %+v, want continuous trading", state)
+	}
+	bids, asks, _ := engine.depth("AAPL", 0)
+	if fmt.Sprint(bids) != fmt.Sprint([]domain.PriceLevel{{Price: 100, Quantity: 3, OrderCount: 1}, {Price: 90, Quantity: 1, OrderCount: 1}}) || len(asks) != 0 {
+		t.Errorf("book after the uncross is %v / %v", bids, asks)
+	}
+}
+
+func TestMatchingEngineDoesNotMatchWhileClosed(t *testing.T) {
+	calendar := &phaseCalendar{phase: domain.SessionPhaseClosed}
+	engine, _, trades := newTestEngine(calendar)
+
+	submitOrders(t, engine,
+		limitOrder("b1", domain.OrderSideBuy, 10, 101),
+		limitOrder("a1", domain.OrderSideSell, 10, 99),
+	)
+	if len(*trades) != 0 {
+		t.Errorf("got trades %v while closed", fills(*trades))
+	}
+	if state := engine.auctionState("AAPL"); state.IndicativeVolume != 0 {
+		t.Errorf("auction state = %+v, want no indicative price outside a call", state)
+	}
+}
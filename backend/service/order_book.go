@@ -0,0 +1,259 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+// orderBook keeps the resting limit orders of one symbol in price-time priority.
+// Bids are sorted by descending price and asks by ascending price; orders at
+// the same price keep their arrival order.
+type orderBook struct {
+	symbol    string
+	bids      []*domain.StockOrder
+	asks      []*domain.StockOrder
+	phase     domain.SessionPhase
+	lastPrice float64
+	// sequence counts changes to the resting orders
+	sequence uint64
+}
+
+// auctionResult is the outcome of an equilibrium price calculation
+type auctionResult struct {
+	price         float64
+	volume        int
+	imbalanceSide domain.OrderSide
+	imbalance     int
+}
+
+func newOrderBook(symbol string) *orderBook {
+	return &orderBook{symbol: symbol}
+}
+
+// add inserts a resting order behind all orders with the same or better price
+func (b *orderBook) add(order *domain.StockOrder) {
+	if order.OrderSide == domain.OrderSideBuy {
+		i := sort.Search(len(b.bids), func(i int) bool { return b.bids[i].Price < order.Price })
+		b.bids = insertAt(b.bids, i, order)
+		return
+	}
+	i := sort.Search(len(b.asks), func(i int) bool { return b.asks[i].Price > order.Price })
+	b.asks = insertAt(b.asks, i, order)
+}
+
+// remove takes an order out of the book and returns the resting instance
+func (b *orderBook) remove(orderID string) *domain.StockOrder {
+	for _, side := range []*[]*domain.StockOrder{&b.bids, &b.asks} {
+		for i, order := range *side {
+			if order.ID == orderID {
+				*side = append((*side)[:i], (*side)[i+1:]...)
+				return order
+			}
+		}
+	}
+	return nil
+}
+
+// find returns the resting instance of an order without removing it
+func (b *orderBook) find(orderID string) *domain.StockOrder {
+	for _, side := range [][]*domain.StockOrder{b.bids, b.asks} {
+		for _, order := range side {
+			if order.ID == orderID {
+				return order
+			}
+		}
+	}
+	return nil
+}
+
+// levels aggregates up to depth price levels per side, best price first.
+// A depth of zero returns every level.
+func (b *orderBook) levels(depth int) ([]domain.PriceLevel, []domain.PriceLevel) {
+	return aggregateLevels(b.bids, depth), aggregateLevels(b.asks, depth)
+}
+
+func aggregateLevels(orders []*domain.StockOrder, depth int) []domain.PriceLevel {
+	levels := []domain.PriceLevel{}
+	for _, order := range orders {
+		last := len(levels) - 1
+		if last >= 0 && levels[last].Price == order.Price {
+			levels[last].Quantity += order.RemainingQuantity()
+			levels[last].OrderCount++
+			continue
+		}
+		if depth > 0 && len(levels) == depth {
+			break
+		}
+		levels = append(levels, domain.PriceLevel{
+			Price:      order.Price,
+			Quantity:   order.RemainingQuantity(),
+			OrderCount: 1,
+		})
+	}
+	return levels
+}
+
+// match executes an incoming order against the opposite side of the book
+// using the resting order's price. The unfilled remainder is not added to
+// the book; the caller decides whether it rests or is cancelled.
+func (b *orderBook) match(incoming *domain.StockOrder, now time.Time) ([]domain.Trade, []*domain.StockOrder) {
+	opposite := &b.asks
+	if incoming.OrderSide == domain.OrderSideSell {
+		opposite = &b.bids
+	}
+
+	trades := []domain.Trade{}
+	touched := []*domain.StockOrder{}
+	for incoming.RemainingQuantity() > 0 && len(*opposite) > 0 {
+		resting := (*opposite)[0]
+		if incoming.OrderType == domain.OrderTypeLimit && !crosses(incoming, resting.Price) {
+			break
+		}
+
+		trades = append(trades, b.execute(incoming, resting, resting.Price, now))
+		touched = append(touched, resting)
+		if resting.RemainingQuantity() == 0 {
+			*opposite = (*opposite)[1:]
+		}
+	}
+
+	return trades, touched
+}
+
+// equilibrium finds the single price that maximises executable volume.
+// Ties are broken by the smallest imbalance, then by market pressure
+// (highest price for a buy surplus, lowest for a sell surplus), and finally
+// by the distance to the last traded price.
+func (b *orderBook) equilibrium() (auctionResult, bool) {
+	candidates := map[float64]bool{}
+	for _, order := range b.bids {
+		candidates[order.Price] = true
+	}
+	for _, order := range b.asks {
+		candidates[order.Price] = true
+	}
+
+	var best auctionResult
+	found := false
+	for price := range candidates {
+		buy, sell := 0, 0
+		for _, order := range b.bids {
+			if order.Price >= price {
+				buy += order.RemainingQuantity()
+			}
+		}
+		for _, order := range b.asks {
+			if order.Price <= price {
+				sell += order.RemainingQuantity()
+			}
+		}
+
+		candidate := auctionResult{price: price, volume: min(buy, sell)}
+		switch {
+		case buy > sell:
+			candidate.imbalanceSide, candidate.imbalance = domain.OrderSideBuy, buy-sell
+		case sell > buy:
+			candidate.imbalanceSide, candidate.imbalance = domain.OrderSideSell, sell-buy
+		}
+
+		if candidate.volume > 0 && (!found || b.better(candidate, best)) {
+			best, found = candidate, true
+		}
+	}
+
+	return best, found
+}
+
+func (b *orderBook) better(candidate, best auctionResult) bool {
+	if candidate.volume != best.volume {
+		return candidate.volume > best.volume
+	}
+	if candidate.imbalance != best.imbalance {
+		return candidate.imbalance < best.imbalance
+	}
+	if candidate.imbalanceSide == best.imbalanceSide {
+		switch candidate.imbalanceSide {
+		case domain.OrderSideBuy:
+			return candidate.price > best.price
+		case domain.OrderSideSell:
+			return candidate.price < best.price
+		}
+	}
+	if b.lastPrice > 0 {
+		return math.Abs(candidate.price-b.lastPrice) < math.Abs(best.price-b.lastPrice)
+	}
+	return candidate.price < best.price
+}
+
+// uncross executes every order that is eligible at the auction price
+func (b *orderBook) uncross(price float64, now time.Time) ([]domain.Trade, []*domain.StockOrder) {
+	trades := []domain.Trade{}
+	touched := []*domain.StockOrder{}
+	for len(b.bids) > 0 && len(b.asks) > 0 {
+		bid, ask := b.bids[0], b.asks[0]
+		if bid.Price < price || ask.Price > price {
+			break
+		}
+
+		trades = append(trades, b.execute(bid, ask, price, now))
+		touched = append(touched, bid, ask)
+		if bid.RemainingQuantity() == 0 {
+			b.bids = b.bids[1:]
+		}
+		if ask.RemainingQuantity() == 0 {
+			b.asks = b.asks[1:]
+		}
+	}
+
+	return trades, touched
+}
+
+func (b *orderBook) execute(incoming, resting *domain.StockOrder, price float64, now time.Time) domain.Trade {
+	quantity := min(incoming.RemainingQuantity(), resting.RemainingQuantity())
+	applyFill(incoming, quantity, price, now)
+	applyFill(resting, quantity, price, now)
+	b.lastPrice = price
+
+	trade := domain.Trade{
+		ID:         uuid.New().String(),
+		Symbol:     b.symbol,
+		Price:      price,
+		Quantity:   quantity,
+		ExecutedAt: now,
+	}
+	if incoming.OrderSide == domain.OrderSideBuy {
+		trade.BuyOrderID, trade.SellOrderID = incoming.ID, resting.ID
+	} else {
+		trade.BuyOrderID, trade.SellOrderID = resting.ID, incoming.ID
+	}
+	return trade
+}
+
+func applyFill(order *domain.StockOrder, quantity int, price float64, now time.Time) {
+	notional := order.AveragePrice*float64(order.FilledQuantity) + price*float64(quantity)
+	order.FilledQuantity += quantity
+	order.AveragePrice = notional / float64(order.FilledQuantity)
+	order.Status = domain.OrderStatusPartiallyFilled
+	if order.RemainingQuantity() == 0 {
+		order.Status = domain.OrderStatusFilled
+	}
+	order.UpdatedAt = now
+}
+
+func crosses(order *domain.StockOrder, price float64) bool {
+	if order.OrderSide == domain.OrderSideBuy {
+		return order.Price >= price
+	}
+	return order.Price <= price
+}
+
+func insertAt(orders []*domain.StockOrder, i int, order *domain.StockOrder) []*domain.StockOrder {
+	orders = append(orders, nil)
+	copy(orders[i+1:], orders[i:])
+	orders[i] = order
+	return orders
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+// restingOrder is a limit order for the book, given as side, quantity and price
+type restingOrder struct {
+	side     domain.OrderSide
+	quantity int
+	price    float64
+}
+
+func bid(quantity int, price float64) restingOrder {
+	return restingOrder{domain.OrderSideBuy, quantity, price}
+}
+
+func ask(quantity int, price float64) restingOrder {
+	return restingOrder{domain.OrderSideSell, quantity, price}
+}
+
+// newTestBook returns a book holding orders in arrival order; their IDs are
+// b1, b2, ... for bids and a1, a2, ... for asks
+func newTestBook(orders ...restingOrder) *orderBook {
+	book := newOrderBook("AAPL")
+	bids, asks := 0, 0
+	for _, order := range orders {
+		id := ""
+		if order.side == domain.OrderSideBuy {
+			bids++
+			id = fmt.Sprintf("b%d", bids)
+		} else {
+			asks++
+			id = fmt.Sprintf("a%d", asks)
+		}
+		book.add(&domain.StockOrder{
+			ID: id, Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: order.side,
+			Quantity: order.quantity, Price: order.price, Status: domain.OrderStatusPending,
+		})
+	}
+	return book
+}
+
+// fills describes trades as "quantity@price buy/sell" for comparison
+func fills(trades []domain.Trade) []string {
+	described := []string{}
+	for _, trade := range trades {
+		described = append(described, fmt.Sprintf("%d@%g %s/%s", trade.Quantity, trade.Price, trade.BuyOrderID, trade.SellOrderID))
+	}
+	return described
+}
+
+func TestOrderBookEquilibrium(t *testing.T) {
+	tests := []struct {
+		name          string
+		orders        []restingOrder
+		lastPrice     float64
+		wantFound     bool
+		wantPrice     float64
+		wantVolume    int
+		imbalanceSide domain.OrderSide
+		imbalance     int
+	}{
+		{
+			name:   "no cross",
+			orders: []restingOrder{bid(10, 99), ask(10, 100)},
+		},
+		{
+			name:   "one side only",
+			orders: []restingOrder{bid(10, 99), bid(10, 100)},
+		},
+		{
+			// 99 executes 5, 100 executes 15 and 101 executes 10
+			name:      "maximum volume",
+			orders:    []restingOrder{bid(10, 101), bid(10, 100), ask(5, 99), ask(10, 100)},
+			wantFound: true, wantPrice: 100, wantVolume: 15,
+			imbalanceSide: domain.OrderSideBuy, imbalance: 5,
+		},
+		{
+			// Every price executes 10; only 102 leaves nothing unmatched
+			name:      "minimum imbalance",
+			orders:    []restingOrder{bid(10, 102), bid(5, 101), ask(10, 100)},
+			wantFound: true, wantPrice: 102, wantVolume: 10,
+		},
+		{
+			name:      "buy pressure takes the highest price",
+			orders:    []restingOrder{bid(15, 102), ask(10, 100)},
+			lastPrice: 100,
+			wantFound: true, wantPrice: 102, wantVolume: 10,
+			imbalanceSide: domain.OrderSideBuy, imbalance: 5,
+		},
+		{
+			name:      "sell pressure takes the lowest price",
+			orders:    []restingOrder{bid(10, 102), ask(15, 100)},
+			lastPrice: 102,
+			wantFound: true, wantPrice: 100, wantVolume: 10,
+			imbalanceSide: domain.OrderSideSell, imbalance: 5,
+		},
+		{
+			name:      "balanced book takes the price nearest the last trade",
+			orders:    []restingOrder{bid(10, 102), ask(10, 100)},
+			lastPrice: 101.5,
+			wantFound: true, wantPrice: 102, wantVolume: 10,
+		},
+		{
+			name:      "balanced book below the last trade",
+			orders:    []restingOrder{bid(10, 102), ask(10, 100)},
+			lastPrice: 90,
+			wantFound: true, wantPrice: 100, wantVolume: 10,
+		},
+		{
+			name:      "balanced book without a last trade takes the lowest price",
+			orders:    []restingOrder{bid(10, 102), ask(10, 100)},
+			wantFound: true, wantPrice: 100, wantVolume: 10,
+		},
+		{
+			// 100 leaves 5 bought and 102 leaves 5 sold unmatched
+			name:      "opposite pressure takes the price nearest the last trade",
+			orders:    []restingOrder{bid(10, 102), bid(5, 100), ask(10, 100), ask(5, 102)},
+			lastPrice: 101.9,
+			wantFound: true, wantPrice: 102, wantVolume: 10,
+			imbalanceSide: domain.OrderSideSell, imbalance: 5,
+		},
+		{
+			name:      "opposite pressure without a last trade takes the lowest price",
+			orders:    []restingOrder{bid(10, 102), bid(5, 100), ask(10, 100), ask(5, 102)},
+			wantFound: true, wantPrice: 100, wantVolume: 10,
+			imbalanceSide: domain.OrderSideBuy, imbalance: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Candidate prices are visited in map order, so repeat to catch
+			// tie-breaks that depend on it
+			for i := 0; i < 20; i++ {
+				book := newTestBook(tt.orders...)
+				book.lastPrice = tt.lastPrice
+
+				result, found := book.equilibrium()
+				if found != tt.wantFound {
+					t.Fatalf("found = %v, want %v", found, tt.wantFound)
+				}
+				want := auctionResult{price: tt.wantPrice, volume: tt.wantVolume, imbalanceSide: tt.imbalanceSide, imbalance: tt.imbalance}
+				if found && result != want {
+					t.Fatalf("got %+v, want %+v", result, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderBookUncross(t *testing.T) {
+	book := newTestBook(bid(10, 101), bid(5, 100), bid(7, 98), ask(8, 99), ask(4, 100), ask(6, 103))
+	result, found := book.equilibrium()
+	if !found || result.price != 100 || result.volume != 12 {
+		t.Fatalf("equilibrium = %+v, %v, want 12 at 100", result, found)
+	}
+
+	trades, touched := book.uncross(result.price, time.Now())
+
+	// Everything executes at the single auction price in price-time priority
+	want := []string{"8@100 b1/a1", "2@100 b1/a2", "2@100 b2/a2"}
+	if got := fills(trades); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got trades %v, want %v", got, want)
+	}
+	if len(touched) != 6 {
+		t.Errorf("got %d touched orders, want both orders of every trade", len(touched))
+	}
+
+	bids, asks := book.levels(0)
+	wantBids := []domain.PriceLevel{{Price: 100, Quantity: 3, OrderCount: 1}, {Price: 98, Quantity: 7, OrderCount: 1}}
+	wantAsks := []domain.PriceLevel{{Price: 103, Quantity: 6, OrderCount: 1}}
+	if fmt.Sprint(bids) != fmt.Sprint(wantBids) || fmt.Sprint(asks) != fmt.Sprint(wantAsks) {
+		t.Errorf("book after uncross is %v / %v, want %v / %v", bids, asks, wantBids, wantAsks)
+	}
+	if book.lastPrice != 100 {
+		t.Errorf("last price = %g, want 100", book.lastPrice)
+	}
+}
+
+func TestOrderBookMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		orders    []restingOrder
+		incoming  domain.StockOrder
+		want      []string
+		remaining int
+		status    domain.OrderStatus
+		average   float64
+	}{
+		{
+			name:     "limit order takes resting prices in price-time priority",
+			orders:   []restingOrder{ask(5, 100), ask(5, 101), ask(5, 100)},
+			incoming: domain.StockOrder{ID: "in", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 12, Price: 101},
+			want:     []string{"5@100 in/a1", "5@100 in/a3", "2@101 in/a2"},
+			status:   domain.OrderStatusFilled, average: (1000 + 202) / 12.0,
+		},
+		{
+			name:      "limit order stops at its price",
+			orders:    []restingOrder{bid(5, 100), bid(5, 99)},
+			incoming:  domain.StockOrder{ID: "in", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideSell, Quantity: 8, Price: 100},
+			want:      []string{"5@100 b1/in"},
+			remaining: 3, status: domain.OrderStatusPartiallyFilled, average: 100,
+		},
+		{
+			name:      "limit order that does not cross",
+			orders:    []restingOrder{ask(5, 101)},
+			incoming:  domain.StockOrder{ID: "in", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 5, Price: 100},
+			want:      []string{},
+			remaining: 5,
+		},
+		{
+			name:      "market order sweeps the book",
+			orders:    []restingOrder{bid(5, 100), bid(5, 90)},
+			incoming:  domain.StockOrder{ID: "in", OrderType: domain.OrderTypeMarket, OrderSide: domain.OrderSideSell, Quantity: 12},
+			want:      []string{"5@100 b1/in", "5@90 b2/in"},
+			remaining: 2, status: domain.OrderStatusPartiallyFilled, average: 95,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := newTestBook(tt.orders...)
+			incoming := tt.incoming
+			trades, touched := book.match(&incoming, time.Now())
+
+			if got := fills(trades); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got trades %v, want %v", got, tt.want)
+			}
+			if len(touched) != len(trades) {
+				t.Errorf("got %d touched orders, want one per trade", len(touched))
+			}
+			if incoming.RemainingQuantity() != tt.remaining || incoming.Status != tt.status || incoming.AveragePrice != tt.average {
+				t.Errorf("incoming order has %d remaining, status %q and average price %g, want %d, %q and %g",
+					incoming.RemainingQuantity(), incoming.Status, incoming.AveragePrice, tt.remaining, tt.status, tt.average)
+			}
+		})
+	}
+}
+
+func TestOrderBookKeepsPriceTimePriority(t *testing.T) {
+	book := newTestBook(bid(1, 100), bid(2, 101), bid(3, 100), ask(4, 102), ask(5, 101.5), ask(6, 102))
+
+	order := func(orders []*domain.StockOrder) []string {
+		ids := []string{}
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		return ids
+	}
+	if got, want := order(book.bids), []string{"b2", "b1", "b3"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("bids are %v, want %v", got, want)
+	}
+	if got, want := order(book.asks), []string{"a2", "a1", "a3"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("asks are %v, want %v", got, want)
+	}
+
+	bids, asks := book.levels(1)
+	if fmt.Sprint(bids) != fmt.Sprint([]domain.PriceLevel{{Price: 101, Quantity: 2, OrderCount: 1}}) ||
+		fmt.Sprint(asks) != fmt.Sprint([]domain.PriceLevel{{Price: 101.5, Quantity: 5, OrderCount: 1}}) {
+		t.Errorf("top of book is %v / %v", bids, asks)
+	}
+	if removed := book.remove("b1"); removed == nil || removed.ID != "b1" || book.find("b1") != nil {
+		t.Errorf("remove returned %v and left b1 in the book", removed)
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+const subscriberBufferSize = 256
+
+// OrderEventHub assigns sequence numbers to order changes, retains the most
+// recent events for resumption and fans them out to subscribers. A subscriber
+// that cannot keep up is disconnected rather than slowing down publishers.
+//
+// Each account has its own sequence, so a watcher of one account sees every
+// number of it and learns nothing of the activity of other accounts.
+type OrderEventHub struct {
+	mu          sync.Mutex
+	sequences   map[string]*accountSequence
+	retained    []domain.OrderEvent
+	capacity    int
+	subscribers map[*orderSubscription]struct{}
+	closed      bool
+}
+
+// accountSequence tracks the sequence of one account's events
+type accountSequence struct {
+	// last is the sequence of the account's latest event
+	last uint64
+	// evicted is the sequence of the account's latest event that is no
+	// longer retained
+	evicted uint64
+}
+
+type orderSubscription struct {
+	filter domain.OrderEventFilter
+	events chan domain.OrderEvent
+	err    error
+	done   bool
+}
+
+// NewOrderEventHub creates a hub that retains up to capacity events for resumption
+func NewOrderEventHub(capacity int) *OrderEventHub {
+	return &OrderEventHub{
+		sequences:   map[string]*accountSequence{},
+		capacity:    capacity,
+		subscribers: map[*orderSubscription]struct{}{},
+	}
+}
+
+// publish records a snapshot of order and delivers it to matching subscribers
+func (h *OrderEventHub) publish(order *domain.StockOrder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	sequence := h.sequence(order.AccountID)
+	sequence.last++
+	event := domain.OrderEvent{
+		Sequence:   sequence.last,
+		Order:      *order,
+		OccurredAt: time.Now(),
+	}
+
+	h.retained = append(h.retained, event)
+	if len(h.retained) > h.capacity {
+		evicted := len(h.retained) - h.capacity
+		for _, old := range h.retained[:evicted] {
+			h.sequence(old.Order.AccountID).evicted = old.Sequence
+		}
+		h.retained = h.retained[evicted:]
+	}
+
+	for sub := range h.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			slog.Warn("Subscriber too slow, disconnecting", "component", "OrderEventHub", "sequence", event.Sequence)
+			h.end(sub, domain.NewUnavailableError(nil, "subscriber fell behind at sequence %d, resume from that sequence", event.Sequence))
+		}
+	}
+}
+
+// Subscribe streams events matching filter until ctx is cancelled or the hub is closed
+func (h *OrderEventHub) Subscribe(ctx context.Context, filter domain.OrderEventFilter) (port.OrderEventSubscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, domain.NewUnavailableError(nil, "order event stream is shutting down")
+	}
+
+	replay := []domain.OrderEvent{}
+	if filter.FromSequence > 0 {
+		// Sequences are per account, so only an account's watcher can resume
+		if filter.AccountID == "" {
+			return nil, domain.NewValidationError("from_sequence", "resuming requires an account")
+		}
+		sequence := h.sequence(filter.AccountID)
+		if filter.FromSequence <= sequence.evicted {
+			return nil, domain.NewInvalidStateError("sequence %d is no longer retained, oldest available is %d",
+				filter.FromSequence, sequence.evicted+1)
+		}
+		if filter.FromSequence > sequence.last+1 {
+			return nil, domain.NewValidationError("from_sequence", "sequence %d has not been published yet, latest is %d",
+				filter.FromSequence, sequence.last)
+		}
+		for _, event := range h.retained {
+			if event.Sequence >= filter.FromSequence && filter.Matches(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	sub := &orderSubscription{
+		filter: filter,
+		events: make(chan domain.OrderEvent, len(replay)+subscriberBufferSize),
+	}
+	for _, event := range replay {
+		sub.events <- event
+	}
+	h.subscribers[sub] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		h.end(sub, nil)
+		h.mu.Unlock()
+	}()
+
+	return sub, nil
+}
+
+// Close ends every subscription so that streaming handlers can return and
+// servers can stop gracefully
+func (h *OrderEventHub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		h.end(sub, nil)
+	}
+	return nil
+}
+
+// sequence returns the sequence of accountID's events; the caller must hold h.mu
+func (h *OrderEventHub) sequence(accountID string) *accountSequence {
+	sequence, ok := h.sequences[accountID]
+	if !ok {
+		sequence = &accountSequence{}
+		h.sequences[accountID] = sequence
+	}
+	return sequence
+}
+
+// end closes a subscription; the caller must hold h.mu
+func (h *OrderEventHub) end(sub *orderSubscription, err error) {
+	if sub.done {
+		return
+	}
+	sub.done = true
+	sub.err = err
+	delete(h.subscribers, sub)
+	close(sub.events)
+}
+
+func (s *orderSubscription) Events() <-chan domain.OrderEvent {
+	return s.events
+}
+
+// Err is only meaningful once Events has been closed
+func (s *orderSubscription) Err() error {
+	return s.err
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// receiveSequences returns the sequences of the next n events of sub
+func receiveSequences(t *testing.T, sub port.OrderEventSubscription, n int) []uint64 {
+	t.Helper()
+	sequences := []uint64{}
+	for len(sequences) < n {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				t.Fatalf("subscription ended after %v: %v", sequences, sub.Err())
+			}
+			sequences = append(sequences, event.Sequence)
+		case <-time.After(time.Second):
+			t.Fatalf("got %v, want %d events", sequences, n)
+		}
+	}
+	return sequences
+}
+
+func TestOrderEventHubSequencesArePerAccount(t *testing.T) {
+	hub := NewOrderEventHub(100)
+	defer hub.Close()
+
+	sub, err := hub.Subscribe(context.Background(), domain.OrderEventFilter{AccountID: "acct-a"})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	// Activity of another account leaves no gaps in acct-a's sequence
+	for _, accountID := range []string{"acct-a", "acct-b", "acct-b", "acct-a", "acct-b", "acct-a"} {
+		hub.publish(&domain.StockOrder{AccountID: accountID, Symbol: "AAPL"})
+	}
+
+	if got, want := receiveSequences(t, sub, 3), []uint64{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got sequences %v, want %v", got, want)
+	}
+}
+
+func TestOrderEventHubResume(t *testing.T) {
+	hub := NewOrderEventHub(4)
+	defer hub.Close()
+
+	// acct-a publishes 1-3 and acct-b 1-3; the hub retains the last four
+	// events, evicting acct-a's 1 and 2
+	for _, accountID := range []string{"acct-a", "acct-a", "acct-b", "acct-b", "acct-a", "acct-b"} {
+		hub.publish(&domain.StockOrder{AccountID: accountID, Symbol: "AAPL"})
+	}
+
+	tests := []struct {
+		name    string
+		filter  domain.OrderEventFilter
+		want    []uint64
+		wantErr error
+	}{
+		{"retained", domain.OrderEventFilter{AccountID: "acct-b", FromSequence: 1}, []uint64{1, 2, 3}, nil},
+		{"latest", domain.OrderEventFilter{AccountID: "acct-a", FromSequence: 3}, []uint64{3}, nil},
+		{"next", domain.OrderEventFilter{AccountID: "acct-a", FromSequence: 4}, []uint64{}, nil},
+		{"evicted", domain.OrderEventFilter{AccountID: "acct-a", FromSequence: 2}, nil, domain.ErrInvalidState},
+		{"not published", domain.OrderEventFilter{AccountID: "acct-a", FromSequence: 5}, nil, domain.ErrValidation},
+		{"unknown account", domain.OrderEventFilter{AccountID: "acct-c", FromSequence: 2}, nil, domain.ErrValidation},
+		{"no account", domain.OrderEventFilter{FromSequence: 1}, nil, domain.ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sub, err := hub.Subscribe(ctx, tt.filter)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Subscribe failed: %v", err)
+			}
+			if got := receiveSequences(t, sub, len(tt.want)); !slices.Equal(got, tt.want) {
+				t.Errorf("got sequences %v, want %v", got, tt.want)
+			}
+			select {
+			case event := <-sub.Events():
+				t.Errorf("got unexpected event %d", event.Sequence)
+			default:
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package service
+
+import "github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+
+// DefaultRateLimitPolicy stops a single runaway client without getting in
+// the way of interactive use: each caller may make 50 requests and place 10
+// orders per second, with bursts of twice that.
+func DefaultRateLimitPolicy() domain.RateLimitPolicy {
+	return domain.RateLimitPolicy{
+		Global:    domain.RateLimit{Rate: 2000, Burst: 4000},
+		PerCaller: domain.RateLimit{Rate: 50, Burst: 100},
+		Orders:    domain.RateLimit{Rate: 10, Burst: 20},
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+)
+
+// enumValue is implemented by domain enums such as OrderType and OrderSide
+type enumValue interface {
+	IsValid() bool
+}
+
+// requestValidator evaluates the validate struct tags of domain requests.
+// Besides the built-in tags it supports "enum", which accepts only values
+// whose IsValid method reports true.
+type requestValidator struct {
+	validate *validator.Validate
+}
+
+func newRequestValidator() *requestValidator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	// Report fields by their JSON names, as clients send them
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	validate.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
+		value, ok := fl.Field().Interface().(enumValue)
+		return ok && value.IsValid()
+	})
+
+	return &requestValidator{validate: validate}
+}
+
+// Struct validates req and returns a validation error listing every invalid
+// field, or nil
+func (v *requestValidator) Struct(req any) error {
+	err := v.validate.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return domain.NewValidationError("", "invalid request: %v", err)
+	}
+
+	violations := make([]domain.FieldViolation, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		violations = append(violations, domain.FieldViolation{
+			Field:   fieldPath(fieldErr),
+			Message: violationMessage(fieldErr),
+		})
+	}
+	return domain.NewFieldValidationError(violations)
+}
+
+// fieldPath returns the JSON path of a field without the request type name
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+func violationMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "enum":
+		return fmt.Sprintf("has unsupported value %q", fmt.Sprint(fieldErr.Value()))
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be at least " + param
+	case "lt":
+		return "must be less than " + param
+	case "lte":
+		return "must be at most " + param
+	case "max":
+		if fieldErr.Kind() == reflect.String {
+			return "must be at most " + param + " characters"
+		}
+		return "must be at most " + param
+	case "min":
+		if fieldErr.Kind() == reflect.String {
+			return "must be at least " + param + " characters"
+		}
+		return "must be at least " + param
+	default:
+		return "failed " + fieldErr.Tag() + " validation"
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+const dateLayout = "2006-01-02"
+
+type marketCalendar struct {
+	market         string
+	location       *time.Location
+	preOpen        int
+	open           int
+	closingAuction int
+	close          int
+	tradingDays    map[time.Weekday]bool
+	holidays       map[string]string
+	// halfDays maps dates to the name and early close of their half day
+	halfDays map[string]halfDay
+}
+
+type halfDay struct {
+	name  string
+	close int
+}
+
+type sessionCalendar struct {
+	markets       map[string]*marketCalendar
+	symbols       map[string]string
+	defaultMarket string
+}
+
+// DefaultMarketSchedules returns a US equities timetable used when no other
+// schedule is configured
+func DefaultMarketSchedules() []domain.MarketSchedule {
+	return []domain.MarketSchedule{
+		{
+			Market:         "XNYS",
+			Timezone:       "America/New_York",
+			PreOpen:        "09:00",
+			Open:           "09:30",
+			ClosingAuction: "15:50",
+			Close:          "16:00",
+			TradingDays: []time.Weekday{
+				time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+			},
+		},
+	}
+}
+
+// NewSessionCalendar builds a calendar from market schedules and holidays.
+// The first schedule is used for symbols that are not mapped to any market.
+// Holidays with an empty or "*" market apply to every market. On half days
+// the closing auction keeps its usual length and ends at the early close.
+func NewSessionCalendar(schedules []domain.MarketSchedule, holidays []domain.Holiday) (port.SessionCalendar, error) {
+	if len(schedules) == 0 {
+		return nil, fmt.Errorf("at least one market schedule is required")
+	}
+
+	calendar := &sessionCalendar{
+		markets:       map[string]*marketCalendar{},
+		symbols:       map[string]string{},
+		defaultMarket: schedules[0].Market,
+	}
+
+	for _, schedule := range schedules {
+		market, err := newMarketCalendar(schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule for market %s: %w", schedule.Market, err)
+		}
+		calendar.markets[schedule.Market] = market
+		for _, symbol := range schedule.Symbols {
+			calendar.symbols[strings.ToUpper(symbol)] = schedule.Market
+		}
+	}
+
+	for _, holiday := range holidays {
+		date := holiday.Date.Format(dateLayout)
+		markets := []*marketCalendar{}
+		if holiday.Market == "" || holiday.Market == "*" {
+			for _, market := range calendar.markets {
+				markets = append(markets, market)
+			}
+		} else {
+			market, ok := calendar.markets[holiday.Market]
+			if !ok {
+				return nil, fmt.Errorf("holiday %s references unknown market: %s", date, holiday.Market)
+			}
+			markets = append(markets, market)
+		}
+
+		for _, market := range markets {
+			if err := market.addHoliday(date, holiday); err != nil {
+				return nil, fmt.Errorf("holiday %s of market %s: %w", date, market.market, err)
+			}
+		}
+	}
+
+	return calendar, nil
+}
+
+func newMarketCalendar(schedule domain.MarketSchedule) (*marketCalendar, error) {
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone: %w", err)
+	}
+
+	market := &marketCalendar{
+		market:      schedule.Market,
+		location:    location,
+		tradingDays: map[time.Weekday]bool{},
+		holidays:    map[string]string{},
+		halfDays:    map[string]halfDay{},
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+		dst   *int
+	}{
+		{"pre_open", schedule.PreOpen, &market.preOpen},
+		{"open", schedule.Open, &market.open},
+		{"closing_auction", schedule.ClosingAuction, &market.closingAuction},
+		{"close", schedule.Close, &market.close},
+	} {
+		minute, err := parseClock(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s time: %w", field.name, err)
+		}
+		*field.dst = minute
+	}
+
+	if !(market.preOpen <= market.open && market.open < market.closingAuction && market.closingAuction <= market.close) {
+		return nil, fmt.Errorf("session times must satisfy pre_open <= open < closing_auction <= close")
+	}
+
+	for _, day := range schedule.TradingDays {
+		market.tradingDays[day] = true
+	}
+	if len(market.tradingDays) == 0 {
+		return nil, fmt.Errorf("at least one trading day is required")
+	}
+
+	return market, nil
+}
+
+// addHoliday closes the market on date, or closes it early on a half day
+func (m *marketCalendar) addHoliday(date string, holiday domain.Holiday) error {
+	if holiday.EarlyClose == "" {
+		m.holidays[date] = holiday.Name
+		return nil
+	}
+
+	minute, err := parseClock(holiday.EarlyClose)
+	if err != nil {
+		return fmt.Errorf("invalid early close time: %w", err)
+	}
+	if minute <= m.open || minute > m.close {
+		return fmt.Errorf("early close must be after open and no later than close")
+	}
+	m.halfDays[date] = halfDay{name: holiday.Name, close: minute}
+	return nil
+}
+
+// parseClock converts "HH:MM" into minutes since midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (c *sessionCalendar) Session(symbol string, at time.Time) (*domain.MarketSession, error) {
+	marketName, ok := c.symbols[strings.ToUpper(symbol)]
+	if !ok {
+		marketName = c.defaultMarket
+	}
+	market := c.markets[marketName]
+
+	session := market.sessionAt(at.In(market.location))
+	session.Symbol = symbol
+	session.AsOf = at
+
+	return session, nil
+}
+
+func (m *marketCalendar) sessionAt(local time.Time) *domain.MarketSession {
+	session := &domain.MarketSession{Market: m.market}
+
+	if name, closed := m.closedOn(local); closed {
+		session.Phase = domain.SessionPhaseClosed
+		session.Holiday = name
+		session.NextPhase, session.NextPhaseAt = m.nextOpening(local)
+		return session
+	}
+
+	closingAuction, closeAt := m.closingAuction, m.close
+	if day, ok := m.halfDays[local.Format(dateLayout)]; ok {
+		session.Holiday = day.name
+		closingAuction = max(m.open, day.close-(m.close-m.closingAuction))
+		closeAt = day.close
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	switch {
+	case minute < m.preOpen:
+		session.Phase = domain.SessionPhaseClosed
+		session.NextPhase, session.NextPhaseAt = m.firstPhase(local)
+	case minute < m.open:
+		session.Phase = domain.SessionPhasePreOpen
+		session.NextPhase, session.NextPhaseAt = domain.SessionPhaseContinuous, m.clock(local, m.open)
+	case minute < closingAuction:
+		session.Phase = domain.SessionPhaseContinuous
+		if closingAuction < closeAt {
+			session.NextPhase, session.NextPhaseAt = domain.SessionPhaseClosingAuction, m.clock(local, closingAuction)
+		} else {
+			session.NextPhase, session.NextPhaseAt = domain.SessionPhaseClosed, m.clock(local, closeAt)
+		}
+	case minute < closeAt:
+		session.Phase = domain.SessionPhaseClosingAuction
+		session.NextPhase, session.NextPhaseAt = domain.SessionPhaseClosed, m.clock(local, closeAt)
+	default:
+		session.Phase = domain.SessionPhaseClosed
+		session.NextPhase, session.NextPhaseAt = m.nextOpening(local)
+	}
+
+	return session
+}
+
+// closedOn reports whether the whole day is closed, returning the holiday name if any
+func (m *marketCalendar) closedOn(local time.Time) (string, bool) {
+	if name, ok := m.holidays[local.Format(dateLayout)]; ok {
+		return name, true
+	}
+	return "", !m.tradingDays[local.Weekday()]
+}
+
+// firstPhase returns the phase that opens the trading day containing local
+func (m *marketCalendar) firstPhase(local time.Time) (domain.SessionPhase, time.Time) {
+	if m.preOpen < m.open {
+		return domain.SessionPhasePreOpen, m.clock(local, m.preOpen)
+	}
+	return domain.SessionPhaseContinuous, m.clock(local, m.open)
+}
+
+// nextOpening finds the first phase of the next trading day after local
+func (m *marketCalendar) nextOpening(local time.Time) (domain.SessionPhase, time.Time) {
+	day := local
+	for i := 0; i < 366; i++ {
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, m.location)
+		if _, closed := m.closedOn(day); !closed {
+			return m.firstPhase(day)
+		}
+	}
+	return domain.SessionPhaseClosed, time.Time{}
+}
+
+func (m *marketCalendar) clock(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, m.location)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return location
+}
+
+func holiday(market, date, name, earlyClose string) domain.Holiday {
+	day, err := time.Parse(dateLayout, date)
+	if err != nil {
+		panic(err)
+	}
+	return domain.Holiday{Market: market, Date: day, Name: name, EarlyClose: earlyClose}
+}
+
+// newTestCalendar returns the default New York market plus a Tokyo market
+// for 7203 without a pre-open call
+func newTestCalendar(t *testing.T) port.SessionCalendar {
+	t.Helper()
+	schedules := append(DefaultMarketSchedules(), domain.MarketSchedule{
+		Market:         "XTKS",
+		Timezone:       "Asia/Tokyo",
+		PreOpen:        "09:00",
+		Open:           "09:00",
+		ClosingAuction: "15:25",
+		Close:          "15:30",
+		TradingDays:    []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Symbols:        []string{"7203"},
+	})
+	calendar, err := NewSessionCalendar(schedules, []domain.Holiday{
+		holiday("XNYS", "2026-11-26", "Thanksgiving Day", ""),
+		holiday("XNYS", "2026-11-27", "Day after Thanksgiving", "13:00"),
+		holiday("*", "2026-01-01", "New Year's Day", ""),
+	})
+	if err != nil {
+		t.Fatalf("NewSessionCalendar failed: %v", err)
+	}
+	return calendar
+}
+
+func TestSessionCalendarPhases(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	ny := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, newYork)
+	}
+	tk := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, tokyo)
+	}
+	utc := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name        string
+		symbol      string
+		at          time.Time
+		market      string
+		phase       domain.SessionPhase
+		holiday     string
+		nextPhase   domain.SessionPhase
+		nextPhaseAt time.Time
+	}{
+		// 2026-03-02 is a Monday
+		{"sunday night", "AAPL", ny(2026, 3, 1, 3, 0), "XNYS", domain.SessionPhaseClosed, "", domain.SessionPhasePreOpen, ny(2026, 3, 2, 9, 0)},
+		{"before pre-open", "AAPL", ny(2026, 3, 2, 8, 59), "XNYS", domain.SessionPhaseClosed, "", domain.SessionPhasePreOpen, ny(2026, 3, 2, 9, 0)},
+		{"pre-open", "AAPL", ny(2026, 3, 2, 9, 0), "XNYS", domain.SessionPhasePreOpen, "", domain.SessionPhaseContinuous, ny(2026, 3, 2, 9, 30)},
+		{"last pre-open minute", "AAPL", ny(2026, 3, 2, 9, 29), "XNYS", domain.SessionPhasePreOpen, "", domain.SessionPhaseContinuous, ny(2026, 3, 2, 9, 30)},
+		{"open", "AAPL", ny(2026, 3, 2, 9, 30), "XNYS", domain.SessionPhaseContinuous, "", domain.SessionPhaseClosingAuction, ny(2026, 3, 2, 15, 50)},
+		{"closing auction", "AAPL", ny(2026, 3, 2, 15, 50), "XNYS", domain.SessionPhaseClosingAuction, "", domain.SessionPhaseClosed, ny(2026, 3, 2, 16, 0)},
+		{"close", "AAPL", ny(2026, 3, 2, 16, 0), "XNYS", domain.SessionPhaseClosed, "", domain.SessionPhasePreOpen, ny(2026, 3, 3, 9, 0)},
+		{"friday close", "AAPL", ny(2026, 3, 6, 16, 0), "XNYS", domain.SessionPhaseClosed, "", domain.SessionPhasePreOpen, ny(2026, 3, 9, 9, 0)},
+		{"unmapped symbols use the first market", "msft", ny(2026, 3, 2, 10, 0), "XNYS", domain.SessionPhaseContinuous, "", domain.SessionPhaseClosingAuction, ny(2026, 3, 2, 15, 50)},
+
+		// Holidays close the whole day and are skipped by the next opening
+		{"holiday", "AAPL", ny(2026, 11, 26, 10, 0), "XNYS", domain.SessionPhaseClosed, "Thanksgiving Day", domain.SessionPhasePreOpen, ny(2026, 11, 27, 9, 0)},
+		{"close before a holiday", "AAPL", ny(2026, 11, 25, 16, 0), "XNYS", domain.SessionPhaseClosed, "", domain.SessionPhasePreOpen, ny(2026, 11, 27, 9, 0)},
+		{"holiday of every market", "7203", tk(2026, 1, 1, 10, 0), "XTKS", domain.SessionPhaseClosed, "New Year's Day", domain.SessionPhaseContinuous, tk(2026, 1, 2, 9, 0)},
+
+		// Half days close at 13:00 after a ten minute closing auction
+		{"half day open", "AAPL", ny(2026, 11, 27, 9, 30), "XNYS", domain.SessionPhaseContinuous, "Day after Thanksgiving", domain.SessionPhaseClosingAuction, ny(2026, 11, 27, 12, 50)},
+		{"half day closing auction", "AAPL", ny(2026, 11, 27, 12, 50), "XNYS", domain.SessionPhaseClosingAuction, "Day after Thanksgiving", domain.SessionPhaseClosed, ny(2026, 11, 27, 13, 0)},
+		{"half day close", "AAPL", ny(2026, 11, 27, 13, 0), "XNYS", domain.SessionPhaseClosed, "Day after Thanksgiving", domain.SessionPhasePreOpen, ny(2026, 11, 30, 9, 0)},
+		{"half day of another market", "7203", tk(2026, 11, 27, 14, 0), "XTKS", domain.SessionPhaseContinuous, "", domain.SessionPhaseClosingAuction, tk(2026, 11, 27, 15, 25)},
+
+		// Phases follow the market's local time, whatever the zone of at
+		{"utc before daylight saving", "AAPL", utc(2026, 3, 6, 14, 29), "XNYS", domain.SessionPhasePreOpen, "", domain.SessionPhaseContinuous, ny(2026, 3, 6, 9, 30)},
+		{"utc after daylight saving", "AAPL", utc(2026, 3, 9, 13, 30), "XNYS", domain.SessionPhaseContinuous, "", domain.SessionPhaseClosingAuction, ny(2026, 3, 9, 15, 50)},
+		{"daylight saving sunday", "AAPL", ny(2026, 3, 8, 12, 0), "XNYS", domain.SessionPhaseClosed, "", domain.SessionPhasePreOpen, utc(2026, 3, 9, 13, 0)},
+		{"local monday on a utc sunday", "7203", utc(2026, 3, 8, 23, 59), "XTKS", domain.SessionPhaseClosed, "", domain.SessionPhaseContinuous, tk(2026, 3, 9, 9, 0)},
+		{"no pre-open call", "7203", tk(2026, 3, 9, 9, 0), "XTKS", domain.SessionPhaseContinuous, "", domain.SessionPhaseClosingAuction, tk(2026, 3, 9, 15, 25)},
+		{"local friday close", "7203", utc(2026, 3, 13, 6, 30), "XTKS", domain.SessionPhaseClosed, "", domain.SessionPhaseContinuous, tk(2026, 3, 16, 9, 0)},
+	}
+
+	calendar := newTestCalendar(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := calendar.Session(tt.symbol, tt.at)
+			if err != nil {
+				t.Fatalf("Session failed: %v", err)
+			}
+			if session.Market != tt.market || session.Phase != tt.phase || session.Holiday != tt.holiday {
+				t.Errorf("got %s %s holiday %q, want %s %s holiday %q",
+					session.Market, session.Phase, session.Holiday, tt.market, tt.phase, tt.holiday)
+			}
+			if session.NextPhase != tt.nextPhase || !session.NextPhaseAt.Equal(tt.nextPhaseAt) {
+				t.Errorf("got next phase %s at %v, want %s at %v",
+					session.NextPhase, session.NextPhaseAt, tt.nextPhase, tt.nextPhaseAt)
+			}
+			if session.Symbol != tt.symbol || !session.AsOf.Equal(tt.at) {
+				t.Errorf("got symbol %q as of %v, want %q as of %v", session.Symbol, session.AsOf, tt.symbol, tt.at)
+			}
+		})
+	}
+}
+
+func TestNewSessionCalendarValidates(t *testing.T) {
+	schedule := func(change func(*domain.MarketSchedule)) []domain.MarketSchedule {
+		schedules := DefaultMarketSchedules()
+		change(&schedules[0])
+		return schedules
+	}
+
+	tests := []struct {
+		name      string
+		schedules []domain.MarketSchedule
+		holidays  []domain.Holiday
+		wantErr   string
+	}{
+		{"no schedule", nil, nil, "at least one market schedule"},
+		{"unknown timezone", schedule(func(s *domain.MarketSchedule) { s.Timezone = "Mars/Olympus" }), nil, "timezone"},
+		{"invalid time", schedule(func(s *domain.MarketSchedule) { s.Open = "9.30" }), nil, "invalid open time"},
+		{"phases out of order", schedule(func(s *domain.MarketSchedule) { s.Open = "16:30" }), nil, "pre_open <= open"},
+		{"no trading days", schedule(func(s *domain.MarketSchedule) { s.TradingDays = nil }), nil, "trading day"},
+		{"unknown holiday market", DefaultMarketSchedules(), []domain.Holiday{holiday("XLON", "2026-12-28", "Boxing Day", "")}, "unknown market"},
+		{"invalid early close", DefaultMarketSchedules(), []domain.Holiday{holiday("XNYS", "2026-12-24", "Christmas Eve", "1pm")}, "invalid early close"},
+		{"early close before open", DefaultMarketSchedules(), []domain.Holiday{holiday("XNYS", "2026-12-24", "Christmas Eve", "09:00")}, "after open"},
+		{"early close after close", DefaultMarketSchedules(), []domain.Holiday{holiday("*", "2026-12-24", "Christmas Eve", "17:00")}, "no later than close"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSessionCalendar(tt.schedules, tt.holidays)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
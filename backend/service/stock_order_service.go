@@ -2,8 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,46 +12,312 @@ import (
 	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
 )
 
+const (
+	defaultOrderPageSize = 50
+	maxOrderPageSize     = 500
+	maxBatchSize         = 500
+)
+
 type stockOrderService struct {
-	repo port.StockOrderRepository
+	repo        port.StockOrderRepository
+	calendar    port.SessionCalendar
+	engine      *MatchingEngine
+	events      *OrderEventHub
+	trades      port.TradeRepository
+	instruments port.InstrumentRepository
+	orderRate   port.RateLimiter
+	fees        domain.FeeSchedule
+	metrics     port.OrderMetrics
+	requests    *requestValidator
+}
+
+// Option configures optional dependencies of the stock order service
+type Option func(*stockOrderService)
+
+// WithSessionCalendar enforces market trading phases on order entry.
+// Without a calendar the market is treated as always open.
+func WithSessionCalendar(calendar port.SessionCalendar) Option {
+	return func(s *stockOrderService) {
+		s.calendar = calendar
+	}
+}
+
+// WithMatchingEngine matches new orders against the order book.
+// Without an engine orders stay PENDING until cancelled.
+func WithMatchingEngine(engine *MatchingEngine) Option {
+	return func(s *stockOrderService) {
+		s.engine = engine
+	}
+}
+
+// WithOrderEventHub publishes every order status change to the hub
+func WithOrderEventHub(events *OrderEventHub) Option {
+	return func(s *stockOrderService) {
+		s.events = events
+	}
+}
+
+// WithTradeRepository records every execution of the matching engine so that
+// the fills of an order can be listed. Without it orders report no fills.
+func WithTradeRepository(trades port.TradeRepository) Option {
+	return func(s *stockOrderService) {
+		s.trades = trades
+	}
+}
+
+// WithInstrumentRepository enables instrument management and symbol halts,
+// and checks new orders against the lot and tick size of their instrument.
+// Without it every symbol is tradable.
+func WithInstrumentRepository(instruments port.InstrumentRepository) Option {
+	return func(s *stockOrderService) {
+		s.instruments = instruments
+	}
+}
+
+// WithOrderRateLimiter limits the orders each account may place, keyed by
+// account ID. Orders in a batch count individually and retried requests do
+// not count again.
+func WithOrderRateLimiter(limiter port.RateLimiter) Option {
+	return func(s *stockOrderService) {
+		s.orderRate = limiter
+	}
+}
+
+// WithFeeSchedule charges fees on every execution recorded in the trade
+// repository. Without it executions are free.
+func WithFeeSchedule(fees domain.FeeSchedule) Option {
+	return func(s *stockOrderService) {
+		s.fees = fees
+	}
 }
 
-func NewStockOrderService(repo port.StockOrderRepository) port.StockOrderService {
-	return &stockOrderService{
-		repo: repo,
+// WithOrderMetrics counts the orders created, cancelled and filled
+func WithOrderMetrics(metrics port.OrderMetrics) Option {
+	return func(s *stockOrderService) {
+		s.metrics = metrics
 	}
 }
 
+func NewStockOrderService(repo port.StockOrderRepository, opts ...Option) port.StockOrderService {
+	s := &stockOrderService{
+		repo:     repo,
+		requests: newRequestValidator(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// Executions change orders outside of service calls
+	if s.engine != nil && s.events != nil {
+		s.engine.addOrderListener(s.events.publish)
+	}
+	if s.engine != nil && s.trades != nil {
+		s.engine.addTradeListener(s.recordTrade)
+	}
+	if s.engine != nil && s.metrics != nil {
+		s.engine.addOrderListener(s.countExecution)
+	}
+	return s
+}
+
 func (s *stockOrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.StockOrder, error) {
+	// "msft" and "MSFT" must reach the same book
+	req.Symbol = domain.NormalizeSymbol(req.Symbol)
+	order, existing, err := s.newOrder(ctx, req)
+	if existing != nil || err != nil {
+		return existing, err
+	}
+	ctx = domain.ContextWithOrderID(ctx, order.ID)
+
+	// Save the original order to the database
+	save := func(ctx context.Context) error { return s.repo.Create(ctx, order) }
+	if err := s.store(ctx, save, order); err != nil {
+		// A concurrent attempt with the same client order ID won the race
+		if errors.Is(err, domain.ErrDuplicateClientOrderID) {
+			if existing, retryErr := s.findRetry(ctx, order.AccountID, req); existing != nil || retryErr != nil {
+				return existing, retryErr
+			}
+		}
+		slog.ErrorContext(ctx, "Failed to create order in database", "component", "CreateOrder", "error", err)
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// newOrder validates req and builds the order to store. When req retries an
+// earlier request, the original order is returned as existing instead. The
+// caller normalizes req.Symbol.
+func (s *stockOrderService) newOrder(ctx context.Context, req domain.CreateOrderRequest) (order, existing *domain.StockOrder, err error) {
+	if err := s.requests.Struct(req); err != nil {
+		return nil, nil, err
+	}
 	// Validate order type and price
 	if req.OrderType == domain.OrderTypeLimit && req.Price <= 0 {
-		return nil, fmt.Errorf("limit order must have a price greater than 0")
+		return nil, nil, domain.NewValidationError("price", "limit order must have a price greater than 0")
 	}
 
-	order := &domain.StockOrder{
-		ID:        uuid.New().String(),
-		Symbol:    req.Symbol,
-		OrderType: req.OrderType,
-		OrderSide: req.OrderSide,
-		Quantity:  req.Quantity,
-		Price:     req.Price,
-		Status:    domain.OrderStatusPending,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	accountID := domain.AccountIDFromContext(ctx)
+
+	// A retried request returns the order created by the first attempt
+	if existing, err := s.findRetry(ctx, accountID, req); existing != nil || err != nil {
+		return nil, existing, err
 	}
 
-	// Save the original order to the database
-	if err := s.repo.Create(ctx, order); err != nil {
-		log.Printf("[CreateOrder] ERROR: Order %s: Failed to create in database: %v", order.ID, err)
-		return nil, err
+	if err := s.checkOrderRate(ctx, accountID); err != nil {
+		return nil, nil, err
+	}
+
+	// Validate the order against its instrument and the current trading phase
+	if err := s.checkInstrument(ctx, req.Symbol, req.Quantity, req.Price); err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkSession(req); err != nil {
+		return nil, nil, err
 	}
 
-	log.Printf("[CreateOrder] Order %s: Successfully created", order.ID)
+	now := time.Now()
+	order = &domain.StockOrder{
+		ID:            uuid.New().String(),
+		AccountID:     accountID,
+		ClientOrderID: req.ClientOrderID,
+		Symbol:        req.Symbol,
+		OrderType:     req.OrderType,
+		OrderSide:     req.OrderSide,
+		Quantity:      req.Quantity,
+		Price:         req.Price,
+		Status:        domain.OrderStatusPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		// A retry is compared with the request, not the order it may have become
+		RequestFingerprint: req.Fingerprint(),
+	}
+	return order, nil, nil
+}
 
-	return order, nil
+// store saves new orders with save, publishes them and hands them to the
+// matching engine. With an engine this all happens under its lock, so the
+// orders are on their books before anyone can see them.
+func (s *stockOrderService) store(ctx context.Context, save func(context.Context) error, orders ...*domain.StockOrder) error {
+	accept := func(ctx context.Context) error {
+		if err := save(ctx); err != nil {
+			return err
+		}
+		for _, order := range orders {
+			s.accept(ctx, order)
+		}
+		return nil
+	}
+
+	if s.engine == nil {
+		return accept(ctx)
+	}
+	return s.engine.submit(ctx, accept, orders...)
+}
+
+// accept logs and publishes a stored order before it is matched
+func (s *stockOrderService) accept(ctx context.Context, order *domain.StockOrder) {
+	slog.InfoContext(ctx, "Order created", "component", "CreateOrder",
+		"order_id", order.ID, "symbol", order.Symbol, "side", order.OrderSide, "type", order.OrderType,
+		"quantity", order.Quantity, "price", order.Price)
+	s.publish(order)
+	if s.metrics != nil {
+		s.metrics.OrderCreated(order)
+	}
+}
+
+func (s *stockOrderService) BatchCreateOrders(ctx context.Context, req domain.BatchCreateOrdersRequest) ([]domain.BatchOrderResult, error) {
+	if len(req.Orders) == 0 {
+		return nil, domain.NewValidationError("orders", "batch must contain at least one order")
+	}
+	if len(req.Orders) > maxBatchSize {
+		return nil, domain.NewValidationError("orders", "batch must not contain more than %d orders", maxBatchSize)
+	}
+
+	results := make([]domain.BatchOrderResult, len(req.Orders))
+	if !req.Atomic {
+		for i, orderReq := range req.Orders {
+			results[i].Index = i
+			order, err := s.CreateOrder(ctx, orderReq)
+			if err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+			results[i].Order = order
+		}
+		return results, nil
+	}
+
+	// Validate every order before anything is stored
+	created := []*domain.StockOrder{}
+	failed := false
+	seen := map[string]bool{}
+	for i, orderReq := range req.Orders {
+		results[i].Index = i
+		if id := orderReq.ClientOrderID; id != "" {
+			if seen[id] {
+				results[i].Error = fmt.Sprintf("%s: %s", domain.ErrDuplicateClientOrderID, id)
+				failed = true
+				continue
+			}
+			seen[id] = true
+		}
+
+		orderReq.Symbol = domain.NormalizeSymbol(orderReq.Symbol)
+		order, existing, err := s.newOrder(ctx, orderReq)
+		switch {
+		case err != nil:
+			results[i].Error = err.Error()
+			failed = true
+		case existing != nil:
+			results[i].Order = existing
+		default:
+			results[i].Order = order
+			created = append(created, order)
+		}
+	}
+
+	if !failed {
+		save := func(ctx context.Context) error { return s.repo.CreateBatch(ctx, created) }
+		if err := s.store(ctx, save, created...); err != nil {
+			slog.ErrorContext(ctx, "Failed to create orders in database", "component", "BatchCreateOrders", "orders", len(created), "error", err)
+			return nil, err
+		}
+		return results, nil
+	}
+
+	// Nothing was stored, so report every order that was otherwise valid as rolled back
+	for i := range results {
+		if results[i].Error == "" {
+			results[i].Order = nil
+			results[i].Error = "not created: another order in the atomic batch failed"
+		}
+	}
+	return results, domain.ErrBatchRolledBack
+}
+
+// findRetry returns the order previously created with req's client order ID,
+// or an error if that order was created with different parameters
+func (s *stockOrderService) findRetry(ctx context.Context, accountID string, req domain.CreateOrderRequest) (*domain.StockOrder, error) {
+	if req.ClientOrderID == "" {
+		return nil, nil
+	}
+
+	existing, err := s.repo.GetByClientOrderID(ctx, accountID, req.ClientOrderID)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	if !req.Matches(existing) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrClientOrderIDMismatch, req.ClientOrderID)
+	}
+
+	slog.InfoContext(ctx, "Returning original order for client order ID", "component", "CreateOrder",
+		"order_id", existing.ID, "client_order_id", req.ClientOrderID)
+	return existing, nil
 }
 
 func (s *stockOrderService) GetOrder(ctx context.Context, orderID string) (*domain.StockOrder, error) {
+	ctx = domain.ContextWithOrderID(ctx, orderID)
 	order, err := s.repo.GetByID(ctx, orderID)
 	if err != nil {
 		return nil, err
@@ -59,23 +326,127 @@ func (s *stockOrderService) GetOrder(ctx context.Context, orderID string) (*doma
 	return order, nil
 }
 
-func (s *stockOrderService) ListOrders(ctx context.Context) ([]*domain.StockOrder, error) {
-	orders, err := s.repo.List(ctx)
-	if err != nil {
+// ListOrderFills returns the executions of an order, oldest first
+func (s *stockOrderService) ListOrderFills(ctx context.Context, orderID string) ([]domain.Trade, error) {
+	ctx = domain.ContextWithOrderID(ctx, orderID)
+	if _, err := s.repo.GetByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	if s.trades == nil {
+		return []domain.Trade{}, nil
+	}
+
+	return s.trades.ListByOrderID(ctx, orderID)
+}
+
+// recordTrade stores an execution of the matching engine. It runs while the
+// engine lock is held, so a failure is logged rather than retried.
+func (s *stockOrderService) recordTrade(trade domain.Trade) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	trade.Fee = s.fees.Fee(trade.Price, trade.Quantity)
+	if err := s.trades.Save(ctx, trade); err != nil {
+		slog.Error("Failed to save trade", "component", "RecordTrade", "trade_id", trade.ID, "error", err)
+	}
+}
+
+func (s *stockOrderService) ListOrders(ctx context.Context, query domain.OrderQuery) (*domain.OrderPage, error) {
+	switch query.Sort {
+	case "":
+		query.Sort = domain.OrderSortCreatedAtDesc
+	case domain.OrderSortCreatedAtDesc, domain.OrderSortCreatedAtAsc:
+	default:
+		return nil, domain.NewValidationError("sort", "unsupported sort order: %s", query.Sort)
+	}
+
+	switch query.OrderSide {
+	case "", domain.OrderSideBuy, domain.OrderSideSell:
+	default:
+		return nil, domain.NewValidationError("order_side", "unsupported order side: %s", query.OrderSide)
+	}
+
+	switch query.OrderType {
+	case "", domain.OrderTypeMarket, domain.OrderTypeLimit:
+	default:
+		return nil, domain.NewValidationError("order_type", "unsupported order type: %s", query.OrderType)
+	}
+
+	switch query.Status {
+	case "", domain.OrderStatusPending, domain.OrderStatusPartiallyFilled, domain.OrderStatusFilled,
+		domain.OrderStatusCancelled, domain.OrderStatusRejected:
+	default:
+		return nil, domain.NewValidationError("status", "unsupported order status: %s", query.Status)
+	}
+
+	query.Symbol = domain.NormalizeSymbol(query.Symbol)
+	if !query.CreatedFrom.IsZero() && !query.CreatedTo.IsZero() && !query.CreatedFrom.Before(query.CreatedTo) {
+		return nil, domain.NewValidationError("created_from", "created_from must be before created_to")
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultOrderPageSize
+	}
+	if query.Limit > maxOrderPageSize {
+		query.Limit = maxOrderPageSize
+	}
+
+	page, err := s.repo.List(ctx, query)
+	if err != nil || !query.IncludeFills {
+		return page, err
+	}
+
+	// The fills of the whole page are loaded at once
+	page.Fills = map[string][]domain.Trade{}
+	if s.trades == nil || len(page.Orders) == 0 {
+		return page, nil
+	}
+	orderIDs := make([]string, 0, len(page.Orders))
+	for _, order := range page.Orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+	if page.Fills, err = s.trades.ListByOrderIDs(ctx, orderIDs); err != nil {
 		return nil, err
 	}
+	return page, nil
+}
+
+func (s *stockOrderService) GetMarketSession(ctx context.Context, symbol string) (*domain.MarketSession, error) {
+	if s.calendar == nil {
+		return nil, domain.NewUnavailableError(nil, "session calendar is not configured")
+	}
+
+	return s.calendar.Session(symbol, time.Now())
+}
+
+func (s *stockOrderService) GetAuctionState(ctx context.Context, symbol string) (*domain.AuctionState, error) {
+	if s.engine == nil {
+		return nil, domain.NewUnavailableError(nil, "matching engine is not configured")
+	}
 
-	return orders, nil
+	return s.engine.auctionState(domain.NormalizeSymbol(symbol)), nil
 }
 
 func (s *stockOrderService) CancelOrder(ctx context.Context, orderID string) error {
+	ctx = domain.ContextWithOrderID(ctx, orderID)
 	order, err := s.repo.GetByID(ctx, orderID)
 	if err != nil {
 		return err
 	}
 
-	if order.Status != domain.OrderStatusPending {
-		return fmt.Errorf("cannot cancel order with status: %s", order.Status)
+	if !order.IsOpen() {
+		return domain.NewInvalidStateError("cannot cancel order with status: %s", order.Status)
+	}
+
+	if s.engine != nil {
+		if resting := s.engine.cancel(order.ID, order.Symbol); resting != nil {
+			order = resting
+		} else if order, err = s.repo.GetByID(ctx, orderID); err != nil {
+			return err
+		} else if !order.IsOpen() {
+			// The order was executed while the cancel was in flight
+			return domain.NewInvalidStateError("cannot cancel order with status: %s", order.Status)
+		}
 	}
 
 	order.Status = domain.OrderStatusCancelled
@@ -84,18 +455,222 @@ func (s *stockOrderService) CancelOrder(ctx context.Context, orderID string) err
 	if err := s.repo.Update(ctx, order); err != nil {
 		return fmt.Errorf("failed to cancel order: %w", err)
 	}
+	slog.InfoContext(ctx, "Order cancelled", "component", "CancelOrder")
+	s.publish(order)
+	if s.metrics != nil {
+		s.metrics.OrderCancelled(order)
+	}
+
+	return nil
+}
+
+func (s *stockOrderService) AmendOrder(ctx context.Context, orderID string, req domain.AmendOrderRequest) (*domain.StockOrder, error) {
+	if err := s.requests.Struct(req); err != nil {
+		return nil, err
+	}
+	if req.Quantity == 0 && req.Price == 0 {
+		return nil, domain.NewValidationError("quantity", "amend must change the quantity or the price")
+	}
+
+	ctx = domain.ContextWithOrderID(ctx, orderID)
+	order, err := s.repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.IsOpen() {
+		return nil, domain.NewInvalidStateError("cannot amend order with status: %s", order.Status)
+	}
+	if order.OrderType != domain.OrderTypeLimit {
+		return nil, domain.NewInvalidStateError("only limit orders can be amended")
+	}
+	if err := s.checkSession(domain.CreateOrderRequest{Symbol: order.Symbol, OrderType: order.OrderType}); err != nil {
+		return nil, err
+	}
+
+	quantity, price := order.Quantity, order.Price
+	if req.Quantity > 0 {
+		quantity = req.Quantity
+	}
+	if req.Price > 0 {
+		price = req.Price
+	}
+	if quantity <= order.FilledQuantity {
+		return nil, domain.NewValidationError("quantity", "amended quantity must exceed the filled quantity of %d", order.FilledQuantity)
+	}
+	if err := s.checkInstrument(ctx, order.Symbol, quantity, price); err != nil {
+		return nil, err
+	}
+
+	if s.engine != nil {
+		if amended := s.engine.amend(ctx, order.ID, order.Symbol, quantity, price); amended != nil {
+			slog.InfoContext(ctx, "Order amended", "component", "AmendOrder", "quantity", quantity, "price", price)
+			// The engine has persisted and published the change
+			return amended, nil
+		}
+		if order, err = s.repo.GetByID(ctx, orderID); err != nil {
+			return nil, err
+		} else if !order.IsOpen() {
+			// The order was executed while the amend was in flight
+			return nil, domain.NewInvalidStateError("cannot amend order with status: %s", order.Status)
+		}
+	}
+
+	order.Quantity = quantity
+	order.Price = price
+	order.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, order); err != nil {
+		return nil, fmt.Errorf("failed to amend order: %w", err)
+	}
+	slog.InfoContext(ctx, "Order amended", "component", "AmendOrder", "quantity", quantity, "price", price)
+	s.publish(order)
+
+	return order, nil
+}
+
+func (s *stockOrderService) MassCancel(ctx context.Context, req domain.MassCancelRequest) ([]domain.CancelResult, error) {
+	if err := s.requests.Struct(req); err != nil {
+		return nil, err
+	}
+
+	req.Symbol = domain.NormalizeSymbol(req.Symbol)
+	accountID := req.AccountID
+	switch {
+	case req.AllAccounts && accountID != "":
+		return nil, domain.NewValidationError("account_id", "account_id cannot be combined with all_accounts")
+	case req.AllAccounts:
+		// An empty account lists the orders of every account
+	case accountID == "":
+		accountID = domain.AccountIDFromContext(ctx)
+		if accountID == "" {
+			return nil, domain.NewUnauthenticatedError("an account is required to cancel orders")
+		}
+	}
+
+	// Collect first, cancelling while paging would shift the pages
+	orders := []*domain.StockOrder{}
+	for _, status := range []domain.OrderStatus{domain.OrderStatusPending, domain.OrderStatusPartiallyFilled} {
+		query := domain.OrderQuery{
+			AccountID: accountID,
+			Symbol:    req.Symbol,
+			OrderSide: req.OrderSide,
+			Status:    status,
+			Sort:      domain.OrderSortCreatedAtAsc,
+			Limit:     maxOrderPageSize,
+		}
+		for {
+			page, err := s.repo.List(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, page.Orders...)
+			if page.NextPageToken == "" {
+				break
+			}
+			query.PageToken = page.NextPageToken
+		}
+	}
+
+	results := make([]domain.CancelResult, 0, len(orders))
+	cancelled := 0
+	for _, order := range orders {
+		result := domain.CancelResult{OrderID: order.ID}
+		if err := s.CancelOrder(ctx, order.ID); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Cancelled = true
+			cancelled++
+		}
+		results = append(results, result)
+	}
+
+	slog.InfoContext(ctx, "Open orders cancelled", "component", "MassCancel",
+		"cancelled", cancelled, "open", len(orders), "target_account_id", accountID, "symbol", req.Symbol, "side", req.OrderSide)
+	return results, nil
+}
+
+func (s *stockOrderService) WatchOrders(ctx context.Context, filter domain.OrderEventFilter) (port.OrderEventSubscription, error) {
+	if s.events == nil {
+		return nil, domain.NewUnavailableError(nil, "order events are not configured")
+	}
+
+	// Callers only ever see their own orders. An empty account ID would
+	// match every account, so a caller without one cannot watch.
+	filter.AccountID = domain.AccountIDFromContext(ctx)
+	if filter.AccountID == "" {
+		return nil, domain.NewUnauthenticatedError("an account is required to watch orders")
+	}
+
+	return s.events.Subscribe(ctx, filter)
+}
+
+// countExecution counts the orders the matching engine filled or cancelled
+func (s *stockOrderService) countExecution(order *domain.StockOrder) {
+	switch order.Status {
+	case domain.OrderStatusFilled:
+		s.metrics.OrderFilled(order)
+	case domain.OrderStatusCancelled:
+		s.metrics.OrderCancelled(order)
+	}
+}
+
+func (s *stockOrderService) publish(order *domain.StockOrder) {
+	if s.events != nil {
+		s.events.publish(order)
+	}
+}
+
+// checkOrderRate rejects an order when the account has exceeded its order
+// rate. Only authenticated callers are limited per account; anonymous
+// callers name their account themselves and could name a new one for every
+// order, so they are limited per client address.
+func (s *stockOrderService) checkOrderRate(ctx context.Context, accountID string) error {
+	if s.orderRate == nil {
+		return nil
+	}
+	key := "address:" + domain.ClientAddressFromContext(ctx)
+	if domain.PrincipalFromContext(ctx) != nil {
+		key = "account:" + accountID
+	}
+	if ok, retryAfter := s.orderRate.Allow(key); !ok {
+		slog.WarnContext(ctx, "Account exceeded its order rate", "component", "CreateOrder", "account_id", accountID, "retry_after", retryAfter.String())
+		return domain.NewRateLimitedError(retryAfter, "order rate limit exceeded, retry in %s", retryAfter.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// checkSession rejects orders the market cannot accept in its current phase
+func (s *stockOrderService) checkSession(req domain.CreateOrderRequest) error {
+	if s.calendar == nil {
+		return nil
+	}
+
+	session, err := s.calendar.Session(req.Symbol, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to resolve trading session: %w", err)
+	}
+
+	if !session.Phase.AcceptsOrders() {
+		return domain.NewInvalidStateError("market %s is closed, next phase %s at %s",
+			session.Market, session.NextPhase, session.NextPhaseAt.Format(time.RFC3339))
+	}
+
+	if req.OrderType == domain.OrderTypeMarket && session.Phase != domain.SessionPhaseContinuous {
+		return domain.NewInvalidStateError("market orders are only accepted during continuous trading, current phase: %s", session.Phase)
+	}
 
 	return nil
 }
 
 // processOrder simulates order processing and updates the database
 func (s *stockOrderService) processOrder(ctx context.Context, order *domain.StockOrder) {
-	log.Printf("[processOrder] Starting background processing for order: %s", order.ID)
+	slog.InfoContext(ctx, "Starting background processing", "component", "processOrder", "order_id", order.ID)
 
 	if err := s.repo.Create(ctx, order); err != nil {
-		log.Printf("[processOrder] ERROR: Order %s: Failed to update in database: %v", order.ID, err)
+		slog.ErrorContext(ctx, "Failed to update order in database", "component", "processOrder", "order_id", order.ID, "error", err)
 		return
 	}
 
-	log.Printf("[processOrder] SUCCESS: Order %s: Successfully marked as FILLED", order.ID)
+	slog.InfoContext(ctx, "Order marked as FILLED", "component", "processOrder", "order_id", order.ID)
 }
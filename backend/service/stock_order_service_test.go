@@ -0,0 +1,260 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/adaptor"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/service"
+)
+
+// newTestOrderService returns an order service with SQLite repositories,
+// a matching engine in continuous trading and an order event hub
+func newTestOrderService(t *testing.T) port.StockOrderService {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "orders.db")
+	repo, err := adaptor.NewSQLiteRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open order repository: %v", err)
+	}
+	trades, err := adaptor.NewSQLiteTradeRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open trade repository: %v", err)
+	}
+	instruments, err := adaptor.NewSQLiteInstrumentRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open instrument repository: %v", err)
+	}
+
+	engine := service.NewMatchingEngine(repo, nil)
+	events := service.NewOrderEventHub(100)
+	orders := service.NewStockOrderService(repo,
+		service.WithMatchingEngine(engine),
+		service.WithOrderEventHub(events),
+		service.WithTradeRepository(trades),
+		service.WithInstrumentRepository(instruments),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		engine.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		events.Close()
+		cancel()
+		<-done
+		instruments.Close()
+		trades.Close()
+		repo.Close()
+	})
+	return orders
+}
+
+// rolePrincipal returns a context authenticated as acct-1 with role
+func rolePrincipal(role domain.Role) context.Context {
+	return domain.ContextWithPrincipal(context.Background(), &domain.Principal{
+		Subject:   "user-" + string(role),
+		AccountID: "acct-1",
+		Roles:     []string{string(role)},
+		Method:    domain.AuthMethodAPIKey,
+	})
+}
+
+func TestOrderSymbolsAreNormalized(t *testing.T) {
+	orders := newTestOrderService(t)
+	ctx := domain.ContextWithAccountID(context.Background(), "acct-1")
+
+	buy, err := orders.CreateOrder(ctx, domain.CreateOrderRequest{
+		Symbol: " msft", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100,
+	})
+	if err != nil {
+		t.Fatalf("failed to create buy order: %v", err)
+	}
+	if buy.Symbol != "MSFT" {
+		t.Errorf("buy order symbol = %q, want MSFT", buy.Symbol)
+	}
+
+	sell, err := orders.CreateOrder(ctx, domain.CreateOrderRequest{
+		Symbol: "MSFT", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideSell, Quantity: 10, Price: 100,
+	})
+	if err != nil {
+		t.Fatalf("failed to create sell order: %v", err)
+	}
+	if sell.Status != domain.OrderStatusFilled {
+		t.Errorf("sell order status = %s, want FILLED against the lower case buy", sell.Status)
+	}
+
+	page, err := orders.ListOrders(ctx, domain.OrderQuery{Symbol: "msft"})
+	if err != nil {
+		t.Fatalf("failed to list orders: %v", err)
+	}
+	if len(page.Orders) != 2 {
+		t.Errorf("listed %d msft orders, want 2", len(page.Orders))
+	}
+}
+
+// cancellingRepository cancels every order right after storing it, the way
+// a fast client that learnt the order ID could
+type cancellingRepository struct {
+	port.StockOrderRepository
+	cancel func(orderID string) error
+	errs   chan error
+}
+
+func (r *cancellingRepository) Create(ctx context.Context, order *domain.StockOrder) error {
+	if err := r.StockOrderRepository.Create(ctx, order); err != nil {
+		return err
+	}
+	// The cancel waits for the engine while the order is being placed
+	done := make(chan error, 1)
+	go func() { done <- r.cancel(order.ID) }()
+	select {
+	case err := <-done:
+		r.errs <- err
+	case <-time.After(100 * time.Millisecond):
+		go func() { r.errs <- <-done }()
+	}
+	return nil
+}
+
+func TestCancelRightAfterCreateKeepsOrderOffTheBook(t *testing.T) {
+	repo, err := adaptor.NewSQLiteRepository(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatalf("failed to open order repository: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	cancelling := &cancellingRepository{StockOrderRepository: repo, errs: make(chan error, 1)}
+	orders := service.NewStockOrderService(cancelling, service.WithMatchingEngine(service.NewMatchingEngine(cancelling, nil)))
+	ctx := domain.ContextWithAccountID(context.Background(), "acct-1")
+	cancelling.cancel = func(orderID string) error { return orders.CancelOrder(ctx, orderID) }
+
+	buy, err := orders.CreateOrder(ctx, domain.CreateOrderRequest{
+		Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100,
+	})
+	if err != nil {
+		t.Fatalf("failed to create buy order: %v", err)
+	}
+	if err := <-cancelling.errs; err != nil {
+		t.Fatalf("failed to cancel buy order: %v", err)
+	}
+
+	// The sell is cancelled too, after it has rested or executed
+	cancelling.cancel = func(string) error { return nil }
+	sell, err := orders.CreateOrder(ctx, domain.CreateOrderRequest{
+		Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideSell, Quantity: 10, Price: 100,
+	})
+	if err != nil {
+		t.Fatalf("failed to create sell order: %v", err)
+	}
+	<-cancelling.errs
+	if sell.Status != domain.OrderStatusPending {
+		t.Errorf("sell order status = %s, want PENDING with the cancelled buy off the book", sell.Status)
+	}
+	stored, err := orders.GetOrder(ctx, buy.ID)
+	if err != nil {
+		t.Fatalf("failed to get buy order: %v", err)
+	}
+	if stored.Status != domain.OrderStatusCancelled {
+		t.Errorf("buy order status = %s, want CANCELLED", stored.Status)
+	}
+}
+
+func TestWatchOrdersRequiresAnAccount(t *testing.T) {
+	orders := newTestOrderService(t)
+
+	_, err := orders.WatchOrders(context.Background(), domain.OrderEventFilter{})
+	if !errors.Is(err, domain.ErrUnauthenticated) {
+		t.Fatalf("anonymous WatchOrders error = %v, want unauthenticated", err)
+	}
+
+	// Another account's orders never reach the watcher
+	ctx := domain.ContextWithAccountID(context.Background(), "acct-1")
+	subscription, err := orders.WatchOrders(ctx, domain.OrderEventFilter{})
+	if err != nil {
+		t.Fatalf("failed to watch orders: %v", err)
+	}
+	other := domain.ContextWithAccountID(context.Background(), "acct-2")
+	for _, ctx := range []context.Context{other, ctx} {
+		if _, err := orders.CreateOrder(ctx, domain.CreateOrderRequest{
+			Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100,
+		}); err != nil {
+			t.Fatalf("failed to create order: %v", err)
+		}
+	}
+	if event := <-subscription.Events(); event.Order.AccountID != "acct-1" {
+		t.Errorf("watcher received an order of %q, want only acct-1", event.Order.AccountID)
+	}
+}
+
+func TestRetryAfterAmendReturnsOriginalOrder(t *testing.T) {
+	orders := newTestOrderService(t)
+	ctx := domain.ContextWithAccountID(context.Background(), "acct-1")
+	req := domain.CreateOrderRequest{
+		ClientOrderID: "retry-1", Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100,
+	}
+
+	order, err := orders.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("failed to create order: %v", err)
+	}
+	if _, err := orders.AmendOrder(ctx, order.ID, domain.AmendOrderRequest{Price: 99}); err != nil {
+		t.Fatalf("failed to amend order: %v", err)
+	}
+
+	retried, err := orders.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("retry after amend failed: %v", err)
+	}
+	if retried.ID != order.ID {
+		t.Errorf("retry returned order %s, want %s", retried.ID, order.ID)
+	}
+
+	req.Quantity = 20
+	if _, err := orders.CreateOrder(ctx, req); !errors.Is(err, domain.ErrClientOrderIDMismatch) {
+		t.Errorf("retry with a different quantity error = %v, want client order ID mismatch", err)
+	}
+}
+
+func TestMassCancelStaysWithinAnAccount(t *testing.T) {
+	orders := newTestOrderService(t)
+	for _, account := range []string{"acct-1", "acct-2"} {
+		ctx := domain.ContextWithAccountID(context.Background(), account)
+		if _, err := orders.CreateOrder(ctx, domain.CreateOrderRequest{
+			Symbol: "AAPL", OrderType: domain.OrderTypeLimit, OrderSide: domain.OrderSideBuy, Quantity: 10, Price: 100,
+		}); err != nil {
+			t.Fatalf("failed to create order: %v", err)
+		}
+	}
+
+	_, err := orders.MassCancel(context.Background(), domain.MassCancelRequest{})
+	if !errors.Is(err, domain.ErrUnauthenticated) {
+		t.Fatalf("anonymous MassCancel error = %v, want unauthenticated", err)
+	}
+
+	authorized, err := service.NewAuthorizedStockOrderService(orders, service.DefaultPolicy())
+	if err != nil {
+		t.Fatalf("failed to authorize order service: %v", err)
+	}
+	trader := rolePrincipal(domain.RoleTrader)
+	_, err = authorized.MassCancel(trader, domain.MassCancelRequest{AllAccounts: true})
+	if !errors.Is(err, domain.ErrPermissionDenied) {
+		t.Errorf("trader MassCancel of all accounts error = %v, want permission denied", err)
+	}
+
+	ops := rolePrincipal(domain.RoleOps)
+	results, err := authorized.MassCancel(ops, domain.MassCancelRequest{AllAccounts: true})
+	if err != nil {
+		t.Fatalf("ops MassCancel of all accounts failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("cancelled %d orders, want both accounts' orders", len(results))
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/domain"
+	"github.com/newnok6/kkp-dime-golang-meetup-2025/backend/port"
+)
+
+// tenantStockOrderService routes every operation to the order service of the
+// caller's tenant. Each tenant's service has its own repositories, order
+// books and event hub, so an order ID of one tenant is unknown to the others.
+type tenantStockOrderService struct {
+	tenants map[string]port.StockOrderService
+}
+
+// NewTenantStockOrderService routes operations to tenants by the tenant ID of
+// their context. Calls for a tenant missing from tenants fail as not found.
+func NewTenantStockOrderService(tenants map[string]port.StockOrderService) port.StockOrderService {
+	return &tenantStockOrderService{tenants: tenants}
+}
+
+func (s *tenantStockOrderService) tenant(ctx context.Context) (port.StockOrderService, error) {
+	tenantID := domain.TenantIDFromContext(ctx)
+	next, ok := s.tenants[tenantID]
+	if !ok {
+		return nil, domain.NewNotFoundError("tenant", tenantID)
+	}
+	return next, nil
+}
+
+func (s *tenantStockOrderService) CreateOrder(ctx context.Context, req domain.CreateOrderRequest) (*domain.StockOrder, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.CreateOrder(ctx, req)
+}
+
+func (s *tenantStockOrderService) GetOrder(ctx context.Context, orderID string) (*domain.StockOrder, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.GetOrder(ctx, orderID)
+}
+
+func (s *tenantStockOrderService) ListOrders(ctx context.Context, query domain.OrderQuery) (*domain.OrderPage, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.ListOrders(ctx, query)
+}
+
+func (s *tenantStockOrderService) ListOrderFills(ctx context.Context, orderID string) ([]domain.Trade, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.ListOrderFills(ctx, orderID)
+}
+
+func (s *tenantStockOrderService) CancelOrder(ctx context.Context, orderID string) error {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return err
+	}
+	return next.CancelOrder(ctx, orderID)
+}
+
+func (s *tenantStockOrderService) BatchCreateOrders(ctx context.Context, req domain.BatchCreateOrdersRequest) ([]domain.BatchOrderResult, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.BatchCreateOrders(ctx, req)
+}
+
+func (s *tenantStockOrderService) MassCancel(ctx context.Context, req domain.MassCancelRequest) ([]domain.CancelResult, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.MassCancel(ctx, req)
+}
+
+func (s *tenantStockOrderService) AmendOrder(ctx context.Context, orderID string, req domain.AmendOrderRequest) (*domain.StockOrder, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.AmendOrder(ctx, orderID, req)
+}
+
+func (s *tenantStockOrderService) GetMarketSession(ctx context.Context, symbol string) (*domain.MarketSession, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.GetMarketSession(ctx, symbol)
+}
+
+func (s *tenantStockOrderService) GetAuctionState(ctx context.Context, symbol string) (*domain.AuctionState, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.GetAuctionState(ctx, symbol)
+}
+
+func (s *tenantStockOrderService) WatchOrders(ctx context.Context, filter domain.OrderEventFilter) (port.OrderEventSubscription, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.WatchOrders(ctx, filter)
+}
+
+func (s *tenantStockOrderService) ListInstruments(ctx context.Context) ([]*domain.Instrument, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.ListInstruments(ctx)
+}
+
+func (s *tenantStockOrderService) UpsertInstrument(ctx context.Context, symbol string, req domain.UpsertInstrumentRequest) (*domain.Instrument, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.UpsertInstrument(ctx, symbol, req)
+}
+
+func (s *tenantStockOrderService) DeleteInstrument(ctx context.Context, symbol string) error {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return err
+	}
+	return next.DeleteInstrument(ctx, symbol)
+}
+
+func (s *tenantStockOrderService) HaltSymbol(ctx context.Context, symbol string, req domain.HaltSymbolRequest) (*domain.Instrument, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.HaltSymbol(ctx, symbol, req)
+}
+
+func (s *tenantStockOrderService) ResumeSymbol(ctx context.Context, symbol string) (*domain.Instrument, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.ResumeSymbol(ctx, symbol)
+}
+
+// tenantMarketDataService routes market data operations to the cache of the
+// caller's tenant, whose quotes and order books reflect only its own orders
+type tenantMarketDataService struct {
+	tenants map[string]port.MarketDataService
+}
+
+// NewTenantMarketDataService routes operations to tenants by the tenant ID of
+// their context. Calls for a tenant missing from tenants fail as not found.
+func NewTenantMarketDataService(tenants map[string]port.MarketDataService) port.MarketDataService {
+	return &tenantMarketDataService{tenants: tenants}
+}
+
+func (s *tenantMarketDataService) tenant(ctx context.Context) (port.MarketDataService, error) {
+	tenantID := domain.TenantIDFromContext(ctx)
+	next, ok := s.tenants[tenantID]
+	if !ok {
+		return nil, domain.NewNotFoundError("tenant", tenantID)
+	}
+	return next, nil
+}
+
+func (s *tenantMarketDataService) GetQuote(ctx context.Context, symbol string) (*domain.SymbolQuote, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.GetQuote(ctx, symbol)
+}
+
+func (s *tenantMarketDataService) GetCandles(ctx context.Context, query domain.CandleQuery) ([]*domain.Candle, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.GetCandles(ctx, query)
+}
+
+func (s *tenantMarketDataService) SubscribeQuotes(ctx context.Context, symbols []string) (<-chan domain.SymbolQuote, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.SubscribeQuotes(ctx, symbols)
+}
+
+func (s *tenantMarketDataService) StreamOrderBook(ctx context.Context, symbol string, depth int) (<-chan domain.OrderBookUpdate, error) {
+	next, err := s.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return next.StreamOrderBook(ctx, symbol, depth)
+}